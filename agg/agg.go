@@ -254,6 +254,59 @@ func MeanTry(ix *etable.IdxView, colNm string) ([]float64, error) {
 	return MeanIdx(ix, colIdx), nil
 }
 
+///////////////////////////////////////////////////
+//   MeanWeighted
+
+// MeanWeightedIdx returns the weighted mean of non-Null, non-NaN elements in given
+// IdxView indexed view of an etable.Table, for given column index, with each
+// row weighted by the value in the scalar weights column wtIdx.
+// Rows with Null or NaN weights are skipped.
+// Return value is size of each column cell -- 1 for scalar 1D columns
+// and N for higher-dimensional columns.
+func MeanWeightedIdx(ix *etable.IdxView, colIdx, wtIdx int) []float64 {
+	wts := ix.AggColWeighted(colIdx, wtIdx, 0, WtTotalFunc)
+	mean := ix.AggColWeighted(colIdx, wtIdx, 0, WtSumFunc)
+	for i := range mean {
+		if wts[i] != 0 {
+			mean[i] /= wts[i]
+		}
+	}
+	return mean
+}
+
+// MeanWeighted returns the weighted mean of non-Null, non-NaN elements in given
+// IdxView indexed view of an etable.Table, for given column name, with each
+// row weighted by the value in the scalar weights column wtNm.
+// If either name not found, nil is returned -- use Try version for error message.
+// Return value is size of each column cell -- 1 for scalar 1D columns
+// and N for higher-dimensional columns.
+func MeanWeighted(ix *etable.IdxView, colNm, wtNm string) []float64 {
+	colIdx := ix.Table.ColIdx(colNm)
+	wtIdx := ix.Table.ColIdx(wtNm)
+	if colIdx == -1 || wtIdx == -1 {
+		return nil
+	}
+	return MeanWeightedIdx(ix, colIdx, wtIdx)
+}
+
+// MeanWeightedTry returns the weighted mean of non-Null, non-NaN elements in given
+// IdxView indexed view of an etable.Table, for given column name, with each
+// row weighted by the value in the scalar weights column wtNm.
+// If either name not found, returns error message.
+// Return value is size of each column cell -- 1 for scalar 1D columns
+// and N for higher-dimensional columns.
+func MeanWeightedTry(ix *etable.IdxView, colNm, wtNm string) ([]float64, error) {
+	colIdx, err := ix.Table.ColIdxTry(colNm)
+	if err != nil {
+		return nil, err
+	}
+	wtIdx, err := ix.Table.ColIdxTry(wtNm)
+	if err != nil {
+		return nil, err
+	}
+	return MeanWeightedIdx(ix, colIdx, wtIdx), nil
+}
+
 ///////////////////////////////////////////////////
 //   Var
 
@@ -0,0 +1,40 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package agg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+func TestMeanWeighted(t *testing.T) {
+	sc := etable.Schema{
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"N", etensor.FLOAT64, nil, nil},
+	}
+	dt := etable.New(sc, 5)
+	vals := []float64{2, 4, 10, 100, 6}
+	wts := []float64{1, 3, 2, 5, 0}
+	for i := range vals {
+		dt.SetCellFloat("Val", i, vals[i])
+		dt.SetCellFloat("N", i, wts[i])
+	}
+	dt.ColByName("N").SetNull1D(3, true) // drops the 100
+	ix := etable.NewIdxView(dt)
+	mn, err := MeanWeightedTry(ix, "Val", "N")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := (2*1 + 4*3 + 10*2 + 6*0) / 6.0
+	if math.Abs(mn[0]-exp) > 1.0e-12 {
+		t.Errorf("MeanWeighted: %v != %v\n", mn[0], exp)
+	}
+	if _, err := MeanWeightedTry(ix, "Val", "Wt"); err == nil {
+		t.Errorf("MeanWeightedTry: expected error for missing weight column\n")
+	}
+}
@@ -43,3 +43,17 @@ func MinFunc(idx int, val float64, ag float64) float64 {
 func SumSqFunc(idx int, val float64, ag float64) float64 {
 	return ag + val*val
 }
+
+// These are standard etable.WeightedAggFunc functions, used for weighted aggregates
+
+// WtSumFunc is a WeightedAggFunc that computes a weighted sum aggregate.
+// use 0 as initial value.
+func WtSumFunc(idx int, val, wt float64, ag float64) float64 {
+	return ag + wt*val
+}
+
+// WtTotalFunc is a WeightedAggFunc that computes the total of the weights
+// for non-Null, non-NaN elements.  use 0 as initial value.
+func WtTotalFunc(idx int, val, wt float64, ag float64) float64 {
+	return ag + wt
+}
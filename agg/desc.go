@@ -5,8 +5,12 @@
 package agg
 
 import (
+	"runtime"
+	"sync"
+
 	"github.com/emer/etable/etable"
 	"github.com/emer/etable/etensor"
+	"github.com/goki/ki/ints"
 )
 
 // DescAggs are all the standard aggregates
@@ -17,8 +21,21 @@ var DescAggsND = []Aggs{AggCount, AggMean, AggStd, AggSem, AggMin, AggMax}
 
 // DescAll returns a table of standard descriptive aggregates for
 // all numeric columns in given table, operating over all non-Null, non-NaN elements
-// in each column.
+// in each column.  For more than DescAllParallelThr values (rows * columns),
+// the aggregates for different columns are computed in parallel, with results
+// identical to the serial computation.
 func DescAll(ix *etable.IdxView) *etable.Table {
+	return descAll(ix, -1)
+}
+
+// DescAllParallelThr is the threshold number of values (rows * columns)
+// above which DescAll computes the aggregates for different columns
+// in parallel goroutines, to speed up wide tables.
+var DescAllParallelThr = 100000
+
+// descAll computes DescAll, in parallel if parallel > 0, serially
+// if parallel == 0, and according to DescAllParallelThr if < 0.
+func descAll(ix *etable.IdxView, parallel int) *etable.Table {
 	st := ix.Table
 	nonQs := []Aggs{AggCount, AggMean, AggStd, AggSem, AggMin, AggMax} // everything else done wth quantiles
 	allAggs := []Aggs{AggCount, AggMean, AggStd, AggSem, AggMin, AggMax, AggQ1, AggMedian, AggQ3}
@@ -26,23 +43,37 @@ func DescAll(ix *etable.IdxView) *etable.Table {
 	sc := etable.Schema{
 		{"Agg", etensor.STRING, nil, nil},
 	}
+	var cis []int // numeric source columns, in order of dt.Cols[1:]
+	any1D := false
 	for ci := range st.Cols {
 		col := st.Cols[ci]
 		if col.DataType() == etensor.STRING {
 			continue
 		}
 		sc = append(sc, etable.Column{st.ColNames[ci], etensor.FLOAT64, col.Shapes()[1:], col.DimNames()[1:]})
+		cis = append(cis, ci)
+		if col.NumDims() == 1 {
+			any1D = true
+		}
 	}
 	dt := etable.New(sc, nAgg)
 	dtnm := dt.Cols[0]
-	dtci := 1
 	qs := []float64{.25, .5, .75}
 	sq := len(nonQs)
-	for ci := range st.Cols {
-		col := st.Cols[ci]
-		if col.DataType() == etensor.STRING {
-			continue
+	if len(cis) > 0 {
+		for i, agtyp := range nonQs {
+			dtnm.SetString1D(i, AggsName(agtyp))
 		}
+	}
+	if any1D {
+		for i := range qs {
+			dtnm.SetString1D(sq+i, AggsName(allAggs[sq+i]))
+		}
+	}
+	// descCol fills in the aggregates for source column ci into result column dtci,
+	// only reading ix, so it can run for different columns in parallel
+	descCol := func(ci, dtci int) {
+		col := st.Cols[ci]
 		_, csz := col.RowCellSize()
 		dtst := dt.Cols[dtci]
 		for i, agtyp := range nonQs {
@@ -51,19 +82,39 @@ func DescAll(ix *etable.IdxView) *etable.Table {
 			for j := 0; j < csz; j++ {
 				dtst.SetFloat1D(si+j, ag[j])
 			}
-			if dtci == 1 {
-				dtnm.SetString1D(i, AggsName(agtyp))
-			}
 		}
 		if col.NumDims() == 1 {
 			qvs := QuantilesIdx(ix, ci, qs)
 			for i, qv := range qvs {
 				dtst.SetFloat1D(sq+i, qv)
-				dtnm.SetString1D(sq+i, AggsName(allAggs[sq+i]))
 			}
 		}
-		dtci++
 	}
+	if parallel < 0 {
+		parallel = 0
+		if len(cis) > 1 && len(ix.Idxs)*len(cis) > DescAllParallelThr {
+			parallel = 1
+		}
+	}
+	nc := len(cis)
+	if parallel == 0 {
+		for i, ci := range cis {
+			descCol(ci, i+1)
+		}
+		return dt
+	}
+	nw := ints.MinInt(runtime.NumCPU(), nc)
+	var wg sync.WaitGroup
+	for w := 0; w < nw; w++ {
+		wg.Add(1)
+		go func(w int) {
+			for i := w; i < nc; i += nw { // interleaved, as in etable CorrelMatrix
+				descCol(cis[i], i+1)
+			}
+			wg.Done()
+		}(w)
+	}
+	wg.Wait()
 	return dt
 }
 
@@ -0,0 +1,76 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package agg
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// descTable returns a table with given number of rows and float columns,
+// plus a string column and a 2D column, with varied values and a few nulls
+func descTable(rows, ncols int) *etable.Table {
+	sc := etable.Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Vec", etensor.FLOAT64, []int{2}, nil},
+	}
+	for ci := 0; ci < ncols; ci++ {
+		sc = append(sc, etable.Column{fmt.Sprintf("C%d", ci), etensor.FLOAT64, nil, nil})
+	}
+	dt := etable.New(sc, rows)
+	for ci, cl := range dt.Cols[1:] {
+		for i := 0; i < cl.Len(); i++ {
+			cl.SetFloat1D(i, float64((i*(ci+3))%17)+0.1*float64(i))
+		}
+	}
+	dt.Cols[3].SetNull1D(3, true)
+	return dt
+}
+
+func TestDescAllParallel(t *testing.T) {
+	ix := etable.NewIdxView(descTable(200, 20))
+	ix.SortColName("C1", false) // non-trivial view order
+	sd := descAll(ix, 0)
+	pd := descAll(ix, 1)
+	if len(pd.Cols) != len(sd.Cols) || pd.Rows != sd.Rows {
+		t.Fatalf("DescAll parallel: shape %d x %d != serial %d x %d\n", len(pd.Cols), pd.Rows, len(sd.Cols), sd.Rows)
+	}
+	for ci, sc := range sd.Cols {
+		if pd.ColNames[ci] != sd.ColNames[ci] {
+			t.Errorf("DescAll parallel: col %d name %v != serial %v\n", ci, pd.ColNames[ci], sd.ColNames[ci])
+		}
+		pc := pd.Cols[ci]
+		for i := 0; i < sc.Len(); i++ {
+			if ci == 0 {
+				if pc.StringVal1D(i) != sc.StringVal1D(i) {
+					t.Errorf("DescAll parallel: agg name %d: %v != serial %v\n", i, pc.StringVal1D(i), sc.StringVal1D(i))
+				}
+				continue
+			}
+			if pc.FloatVal1D(i) != sc.FloatVal1D(i) {
+				t.Errorf("DescAll parallel: col %v value %d: %v != serial %v\n", sd.ColNames[ci], i, pc.FloatVal1D(i), sc.FloatVal1D(i))
+			}
+		}
+	}
+}
+
+func BenchmarkDescAllSerial(b *testing.B) {
+	ix := etable.NewIdxView(descTable(2000, 200))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		descAll(ix, 0)
+	}
+}
+
+func BenchmarkDescAllParallel(b *testing.B) {
+	ix := etable.NewIdxView(descTable(2000, 200))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		descAll(ix, 1)
+	}
+}
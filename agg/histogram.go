@@ -0,0 +1,128 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package agg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// Histogram returns a new table with the histogram of the non-Null, non-NaN
+// values in given column of the IdxView indexed view of an etable.Table
+// (use etable.NewIdxView for a whole table), using nBins equal-width bins
+// spanning the min to max values.  All values of n-dimensional cells are included.
+// The returned table has a "Bin" column with the bin centers and a "Count" column
+// with the number of values in each bin.  Each bin includes its lower edge and
+// excludes its upper edge, except the last bin which includes the max value.
+// If all values are the same, the bins are centered on that value with unit width.
+func Histogram(ix *etable.IdxView, colNm string, nBins int) (*etable.Table, error) {
+	if nBins < 1 {
+		return nil, fmt.Errorf("etable agg.Histogram: nBins must be >= 1, is: %v", nBins)
+	}
+	vals, err := histVals(ix, colNm)
+	if err != nil {
+		return nil, err
+	}
+	mn, mx := 0.0, 1.0
+	if len(vals) > 0 {
+		mn, mx = vals[0], vals[0]
+		for _, v := range vals {
+			mn = math.Min(mn, v)
+			mx = math.Max(mx, v)
+		}
+	}
+	if mx == mn {
+		mn -= .5
+		mx += .5
+	}
+	edges := make([]float64, nBins+1)
+	for i := range edges {
+		edges[i] = mn + (mx-mn)*float64(i)/float64(nBins)
+	}
+	edges[nBins] = mx // avoid rounding error excluding the max
+	return histTable(vals, edges), nil
+}
+
+// HistogramEdges returns a new table with the histogram of the non-Null, non-NaN
+// values in given column of the IdxView indexed view of an etable.Table
+// (use etable.NewIdxView for a whole table), using the given explicit bin edges,
+// which must be in increasing order, with len(edges)-1 bins.
+// All values of n-dimensional cells are included.
+// The returned table has a "Bin" column with the bin centers and a "Count" column
+// with the number of values in each bin.  Each bin includes its lower edge and
+// excludes its upper edge, except the last bin which includes the last edge.
+// Values outside of the edges are not counted.
+func HistogramEdges(ix *etable.IdxView, colNm string, edges []float64) (*etable.Table, error) {
+	if len(edges) < 2 {
+		return nil, fmt.Errorf("etable agg.HistogramEdges: must have at least 2 edges, have: %v", len(edges))
+	}
+	for i := 1; i < len(edges); i++ {
+		if edges[i] <= edges[i-1] {
+			return nil, fmt.Errorf("etable agg.HistogramEdges: edges must be increasing: %v", edges)
+		}
+	}
+	vals, err := histVals(ix, colNm)
+	if err != nil {
+		return nil, err
+	}
+	return histTable(vals, edges), nil
+}
+
+// histVals returns the non-Null, non-NaN values of given column in the view
+func histVals(ix *etable.IdxView, colNm string) ([]float64, error) {
+	colIdx, err := ix.Table.ColIdxTry(colNm)
+	if err != nil {
+		return nil, err
+	}
+	col := ix.Table.Cols[colIdx]
+	if col.DataType() == etensor.STRING {
+		return nil, fmt.Errorf("etable agg.Histogram: column %v is a string column", colNm)
+	}
+	_, csz := col.RowCellSize()
+	vals := make([]float64, 0, len(ix.Idxs)*csz)
+	for _, srw := range ix.Idxs {
+		for j := 0; j < csz; j++ {
+			ci := srw*csz + j
+			val := col.FloatVal1D(ci)
+			if !col.IsNull1D(ci) && !math.IsNaN(val) {
+				vals = append(vals, val)
+			}
+		}
+	}
+	return vals, nil
+}
+
+// histTable returns the Bin, Count histogram table for given values and bin edges
+func histTable(vals, edges []float64) *etable.Table {
+	nb := len(edges) - 1
+	sc := etable.Schema{
+		{"Bin", etensor.FLOAT64, nil, nil},
+		{"Count", etensor.FLOAT64, nil, nil},
+	}
+	dt := etable.New(sc, nb)
+	bins := dt.Cols[0].(*etensor.Float64).Values
+	cnts := dt.Cols[1].(*etensor.Float64).Values
+	for i := 0; i < nb; i++ {
+		bins[i] = .5 * (edges[i] + edges[i+1])
+	}
+	for _, v := range vals {
+		if v < edges[0] || v > edges[nb] {
+			continue
+		}
+		bi := sort.SearchFloat64s(edges, v) // first edge >= v
+		if bi == len(edges) || edges[bi] > v {
+			bi--
+		}
+		if bi >= nb {
+			bi = nb - 1
+		}
+		cnts[bi]++
+	}
+	return dt
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package agg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+func histTestTable() *etable.Table {
+	vals := []float64{0, 1, 2, 2.5, 3, 4, 9.99, 10, 10}
+	dt := etable.New(etable.Schema{{"Val", etensor.FLOAT64, nil, nil}}, len(vals)+1)
+	for i, v := range vals {
+		dt.SetCellFloat("Val", i, v)
+	}
+	dt.ColByName("Val").SetNull1D(len(vals), true)
+	return dt
+}
+
+func TestHistogram(t *testing.T) {
+	ix := etable.NewIdxView(histTestTable())
+	ht, err := Histogram(ix, "Val", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ht.Rows != 4 {
+		t.Fatalf("Histogram: rows %v != 4\n", ht.Rows)
+	}
+	// edges 0, 2.5, 5, 7.5, 10 -- max lands in last bin
+	bins := []float64{1.25, 3.75, 6.25, 8.75}
+	cnts := []float64{3, 3, 0, 3}
+	for i := range bins {
+		if math.Abs(ht.CellFloat("Bin", i)-bins[i]) > 1.0e-12 || ht.CellFloat("Count", i) != cnts[i] {
+			t.Errorf("Histogram: bin %v: %v %v != %v %v\n", i, ht.CellFloat("Bin", i), ht.CellFloat("Count", i), bins[i], cnts[i])
+		}
+	}
+}
+
+func TestHistogramEdges(t *testing.T) {
+	ix := etable.NewIdxView(histTestTable())
+	ht, err := HistogramEdges(ix, "Val", []float64{1, 2, 4, 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 0 excluded; [1,2): 1; [2,4): 2, 2.5, 3; [4,10]: 4, 9.99, 10, 10
+	bins := []float64{1.5, 3, 7}
+	cnts := []float64{1, 3, 4}
+	for i := range bins {
+		if ht.CellFloat("Bin", i) != bins[i] || ht.CellFloat("Count", i) != cnts[i] {
+			t.Errorf("HistogramEdges: bin %v: %v %v != %v %v\n", i, ht.CellFloat("Bin", i), ht.CellFloat("Count", i), bins[i], cnts[i])
+		}
+	}
+	if _, err := HistogramEdges(ix, "Val", []float64{1, 1}); err == nil {
+		t.Errorf("HistogramEdges: expected error for non-increasing edges\n")
+	}
+}
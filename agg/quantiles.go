@@ -7,6 +7,7 @@ package agg
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/emer/etable/etable"
 )
@@ -35,24 +36,12 @@ func QuantilesIdx(ix *etable.IdxView, colIdx int, qs []float64) []float64 {
 		return true
 	})
 	six.SortCol(colIdx, true)
-	sz := len(six.Idxs) - 1 // length of our own index list
-	fsz := float64(sz)
+	vals := make([]float64, len(six.Idxs))
+	for i, srw := range six.Idxs {
+		vals[i] = col.FloatVal1D(srw)
+	}
 	for i, q := range qs {
-		val := 0.0
-		qi := q * fsz
-		lwi := math.Floor(qi)
-		lwii := int(lwi)
-		if lwii >= sz {
-			val = col.FloatVal1D(six.Idxs[sz])
-		} else if lwii < 0 {
-			val = col.FloatVal1D(six.Idxs[0])
-		} else {
-			phi := qi - lwi
-			lwv := col.FloatVal1D(six.Idxs[lwii])
-			hiv := col.FloatVal1D(six.Idxs[lwii+1])
-			val = (1-phi)*lwv + phi*hiv
-		}
-		rvs[i] = val
+		rvs[i] = etable.QuantileSorted(vals, q)
 	}
 	return rvs
 }
@@ -90,3 +79,65 @@ func QuantilesTry(ix *etable.IdxView, colNm string, qs []float64) ([]float64, er
 	}
 	return rv, nil
 }
+
+// QuantilesCellsIdx returns the given quantile(s) of non-Null, non-NaN elements in given
+// IdxView indexed view of an etable.Table, for given column index, computed
+// separately for each cell of n-dimensional columns (works for 1D columns too).
+// Return value is indexed first by cell and then by quantile, so it is
+// [cell size][len(qs)].  A cell with no valid values has NaN quantiles.
+// qs are 0-1 values, 0 = min, 1 = max, .5 = median, etc.  Uses linear interpolation.
+func QuantilesCellsIdx(ix *etable.IdxView, colIdx int, qs []float64) [][]float64 {
+	nq := len(qs)
+	if nq == 0 {
+		return nil
+	}
+	col := ix.Table.Cols[colIdx]
+	_, csz := col.RowCellSize()
+	rvs := make([][]float64, csz)
+	vals := make([]float64, 0, len(ix.Idxs))
+	for j := range rvs {
+		vals = vals[:0]
+		for _, srw := range ix.Idxs {
+			ci := srw*csz + j
+			val := col.FloatVal1D(ci)
+			if !col.IsNull1D(ci) && !math.IsNaN(val) {
+				vals = append(vals, val)
+			}
+		}
+		sort.Float64s(vals)
+		rv := make([]float64, nq)
+		for i, q := range qs {
+			rv[i] = etable.QuantileSorted(vals, q)
+		}
+		rvs[j] = rv
+	}
+	return rvs
+}
+
+// QuantilesCells returns the given quantile(s) of non-Null, non-NaN elements in given
+// IdxView indexed view of an etable.Table, for given column name, computed
+// separately for each cell of n-dimensional columns -- see QuantilesCellsIdx.
+// If name not found, nil is returned -- use Try version for error message.
+func QuantilesCells(ix *etable.IdxView, colNm string, qs []float64) [][]float64 {
+	colIdx := ix.Table.ColIdx(colNm)
+	if colIdx == -1 {
+		return nil
+	}
+	return QuantilesCellsIdx(ix, colIdx, qs)
+}
+
+// QuantilesCellsTry returns the given quantile(s) of non-Null, non-NaN elements in given
+// IdxView indexed view of an etable.Table, for given column name, computed
+// separately for each cell of n-dimensional columns -- see QuantilesCellsIdx.
+// If name not found, error message is returned.
+func QuantilesCellsTry(ix *etable.IdxView, colNm string, qs []float64) ([][]float64, error) {
+	colIdx, err := ix.Table.ColIdxTry(colNm)
+	if err != nil {
+		return nil, err
+	}
+	rv := QuantilesCellsIdx(ix, colIdx, qs)
+	if rv == nil {
+		return nil, fmt.Errorf("etable agg.QuantilesCellsTry: qs: %v empty", qs)
+	}
+	return rv, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package agg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+func TestQuantilesCells(t *testing.T) {
+	dt := etable.New(etable.Schema{{"Vals", etensor.FLOAT64, []int{2}, nil}}, 5)
+	col := dt.Cols[0]
+	// cell 0: 5, 1, 4, 2, 3 -- cell 1: 10, null, 40, NaN, 20
+	c0 := []float64{5, 1, 4, 2, 3}
+	c1 := []float64{10, 0, 40, math.NaN(), 20}
+	for i := range c0 {
+		col.SetFloat1D(i*2, c0[i])
+		col.SetFloat1D(i*2+1, c1[i])
+	}
+	col.SetNull1D(1*2+1, true)
+	ix := etable.NewIdxView(dt)
+
+	qs := QuantilesCells(ix, "Vals", []float64{0, .5, 1})
+	exp := [][]float64{{1, 3, 5}, {10, 20, 40}}
+	for ci := range exp {
+		for qi, ev := range exp[ci] {
+			if qs[ci][qi] != ev {
+				t.Errorf("QuantilesCells: cell %v q %v: %v != %v\n", ci, qi, qs[ci][qi], ev)
+			}
+		}
+	}
+	qs = QuantilesCells(ix, "Vals", []float64{.25, .75})
+	if qs[0][0] != 2 || qs[0][1] != 4 || qs[1][0] != 15 || qs[1][1] != 30 {
+		t.Errorf("QuantilesCells: interpolated quartiles wrong: %v\n", qs)
+	}
+}
+
+func TestWinsorizeQuantiles(t *testing.T) {
+	// etable.WinsorizeCol and Quantiles share etable.QuantileSorted, so they must match
+	n := 37 // not a multiple of 20, so the 5th / 95th percentiles interpolate
+	dt := etable.New(etable.Schema{{"Val", etensor.FLOAT64, nil, nil}}, n)
+	for i := 0; i < n; i++ {
+		x := float64((i*11)%n) / float64(n)
+		dt.SetCellFloat("Val", i, x*x*x) // skewed
+	}
+	qs := Quantiles(etable.NewIdxView(dt), "Val", []float64{.05, .95})
+	if err := dt.WinsorizeCol("Val", 5, 95); err != nil {
+		t.Fatal(err)
+	}
+	ix := etable.NewIdxView(dt)
+	if mn := Min(ix, "Val")[0]; mn != qs[0] {
+		t.Errorf("WinsorizeCol: low bound %v != Quantiles %v\n", mn, qs[0])
+	}
+	if mx := Max(ix, "Val")[0]; mx != qs[1] {
+		t.Errorf("WinsorizeCol: high bound %v != Quantiles %v\n", mx, qs[1])
+	}
+}
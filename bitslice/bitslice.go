@@ -57,8 +57,10 @@ func (bs *Slice) Cap() int {
 	return (cap(*bs) - 1) * 8
 }
 
-// SetLen sets the length of the slice, copying values if a new allocation is required
+// SetLen sets the length of the slice, copying values if a new allocation is required.
+// Any bits added beyond the previous length are cleared (false).
 func (bs *Slice) SetLen(ln int) {
+	oln := bs.Len()
 	by, bi := BitIdx(ln)
 	bln := by
 	if bi != 0 {
@@ -69,10 +71,13 @@ func (bs *Slice) SetLen(ln int) {
 		(*bs)[0] = byte(bi)
 	} else {
 		sl := make(Slice, bln+1)
-		sl[0] = byte(bi)
 		copy(sl, *bs)
+		sl[0] = byte(bi)
 		*bs = sl
 	}
+	for i := oln; i < ln; i++ {
+		bs.Set(i, false)
+	}
 }
 
 // Set sets value of given bit index -- no extra range checking is performed -- will panic if out of range
@@ -146,3 +146,26 @@ func TestBitSlice7(t *testing.T) {
 		t.Errorf("append false != %v", out)
 	}
 }
+
+func TestBitSliceSetLen(t *testing.T) {
+	bs := Make(5, 0)
+	bs.Set(4, true)
+	bs.SetLen(20)
+	if bs.Len() != 20 {
+		t.Errorf("SetLen grow: len %v != 20\n", bs.Len())
+	}
+	if !bs.Index(4) {
+		t.Errorf("SetLen grow: lost value\n")
+	}
+	bs.Set(19, true)
+	bs.SetLen(3)
+	if bs.Len() != 3 {
+		t.Errorf("SetLen shrink: len %v != 3\n", bs.Len())
+	}
+	bs.SetLen(20)
+	for i := 3; i < 20; i++ {
+		if bs.Index(i) {
+			t.Errorf("SetLen regrow: bit %v not cleared\n", i)
+		}
+	}
+}
@@ -53,6 +53,24 @@ func (nn *Node) Idxs(ix []int, ctr *int) {
 	}
 }
 
+// Leaves returns the indexes of all the leaves under this node,
+// which are indexes into the original distance matrix
+func (nn *Node) Leaves() []int {
+	var lv []int
+	nn.leaves(&lv)
+	return lv
+}
+
+func (nn *Node) leaves(lv *[]int) {
+	if nn.IsLeaf() {
+		*lv = append(*lv, nn.Idx)
+		return
+	}
+	for _, kn := range nn.Kids {
+		kn.leaves(lv)
+	}
+}
+
 // NewNode merges two nodes into a new node
 func NewNode(na, nb *Node, dst float64) *Node {
 	nn := &Node{Dist: dst}
@@ -81,6 +99,16 @@ func GlomStd(smat *simat.SimMat, std StdDists) *Node {
 	return Glom(smat, StdFunc(std))
 }
 
+// Cluster implements basic agglomerative clustering directly on a square
+// distance matrix (e.g., from metric.Matrix64), using given standard
+// linkage distance function (Min = single, Max = complete, Avg = average linkage).
+// Returns the root node, whose Kids contain the single top-level cluster node,
+// with each node having the Dist at which its Kids were merged.
+func Cluster(dmat *etensor.Float64, std StdDists) *Node {
+	smat := &simat.SimMat{Mat: dmat}
+	return GlomStd(smat, std)
+}
+
 // GlomInit returns a standard root node initialized with all of the leaves
 func GlomInit(ntot int) *Node {
 	root := &Node{}
@@ -97,6 +125,9 @@ func GlomInit(ntot int) *Node {
 // The smat.Mat matrix must be an etensor.Float64.
 func GlomClust(root *Node, smat *simat.SimMat, dfunc DistFunc) *Node {
 	ntot := smat.Mat.Dim(0) // number of leaves
+	if len(root.Kids) <= 1 {
+		return root
+	}
 	smatf := smat.Mat.(*etensor.Float64).Values
 	maxd := norm.Max64(smatf)
 	// indexes in each group
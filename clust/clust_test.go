@@ -6,9 +6,11 @@ package clust
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
 	"github.com/emer/etable/metric"
 	"github.com/emer/etable/simat"
 )
@@ -29,3 +31,47 @@ func TestClust(t *testing.T) {
 	s := cl.Sprint(smat, 0)
 	fmt.Println(s)
 }
+
+func TestCluster(t *testing.T) {
+	// 0,1 are very close, 2,3 are close, and the pairs are far apart
+	dmat := etensor.NewFloat64([]int{4, 4}, nil, nil)
+	copy(dmat.Values, []float64{
+		0, 1, 10, 12,
+		1, 0, 11, 10,
+		10, 11, 0, 2,
+		12, 10, 2, 0,
+	})
+	exp := map[StdDists]float64{Min: 10, Max: 12, Avg: 10.75}
+	for std, td := range exp {
+		root := Cluster(dmat, std)
+		if len(root.Kids) != 1 {
+			t.Fatalf("Cluster %v: root has %v kids\n", std, len(root.Kids))
+		}
+		top := root.Kids[0]
+		if top.Dist != td || len(top.Kids) != 2 {
+			t.Errorf("Cluster %v: top dist %v != %v\n", std, top.Dist, td)
+		}
+		if lv := top.Leaves(); len(lv) != 4 {
+			t.Errorf("Cluster %v: top leaves %v\n", std, lv)
+		}
+		for _, kn := range top.Kids {
+			lv := kn.Leaves()
+			sort.Ints(lv)
+			switch {
+			case len(lv) == 2 && lv[0] == 0 && lv[1] == 1:
+				if kn.Dist != 1 {
+					t.Errorf("Cluster %v: {0,1} dist %v != 1\n", std, kn.Dist)
+				}
+			case len(lv) == 2 && lv[0] == 2 && lv[1] == 3:
+				if kn.Dist != 2 {
+					t.Errorf("Cluster %v: {2,3} dist %v != 2\n", std, kn.Dist)
+				}
+			default:
+				t.Errorf("Cluster %v: unexpected cluster %v\n", std, lv)
+			}
+		}
+	}
+	if root := Cluster(etensor.NewFloat64([]int{1, 1}, nil, nil), Min); len(root.Kids) != 1 {
+		t.Errorf("Cluster single: %v kids\n", len(root.Kids))
+	}
+}
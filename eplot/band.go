@@ -0,0 +1,76 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eplot
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/goki/gi/gi"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+)
+
+// BandAlpha is the opacity (0-255) of the filled mean +/- std band drawn
+// for columns with a Band window, relative to the series color
+var BandAlpha uint8 = 64
+
+// RollingBand returns the rolling mean of the Y values of given points over
+// a centered window of given number of points (truncated at the ends),
+// along with the lower and upper edges of the mean +/- std band,
+// with std the population standard deviation within the window.
+func RollingBand(xys plotter.XYer, window int) (mean, lo, hi plotter.XYs) {
+	n := xys.Len()
+	mean = make(plotter.XYs, n)
+	lo = make(plotter.XYs, n)
+	hi = make(plotter.XYs, n)
+	if window < 1 {
+		window = 1
+	}
+	for i := 0; i < n; i++ {
+		st := i - window/2
+		ed := st + window // computed before clamping st, so the window stays centered
+		if st < 0 {
+			st = 0
+		}
+		if ed > n {
+			ed = n
+		}
+		sum, ss := 0.0, 0.0
+		for j := st; j < ed; j++ {
+			_, y := xys.XY(j)
+			sum += y
+			ss += y * y
+		}
+		cnt := float64(ed - st)
+		mn := sum / cnt
+		std := math.Sqrt(math.Max(ss/cnt-mn*mn, 0))
+		x, _ := xys.XY(i)
+		mean[i] = plotter.XY{X: x, Y: mn}
+		lo[i] = plotter.XY{X: x, Y: mn - std}
+		hi[i] = plotter.XY{X: x, Y: mn + std}
+	}
+	return
+}
+
+// addBand adds a filled polygon for the rolling mean +/- std band of given
+// points to the plot, in a translucent version of given color, returning the
+// rolling mean points to plot as the line for the series.
+func addBand(plt *plot.Plot, xys plotter.XYer, window int, clr gi.Color) plotter.XYs {
+	mean, lo, hi := RollingBand(xys, window)
+	n := len(mean)
+	ring := make(plotter.XYs, 0, 2*n)
+	ring = append(ring, hi...)
+	for i := n - 1; i >= 0; i-- {
+		ring = append(ring, lo[i])
+	}
+	poly, err := plotter.NewPolygon(ring)
+	if err == nil {
+		poly.Color = color.NRGBA{R: clr.R, G: clr.G, B: clr.B, A: BandAlpha}
+		poly.LineStyle.Width = 0
+		plt.Add(poly)
+	}
+	return mean
+}
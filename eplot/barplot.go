@@ -81,6 +81,8 @@ func (pl *Plot2D) GenPlotBar() {
 	}
 
 	if nys == 0 {
+		pl.PlotRefLines(plt) // ref lines are drawn regardless of columns
+		pl.GPlot = plt
 		return
 	}
 
@@ -117,7 +119,7 @@ func (pl *Plot2D) GenPlotBar() {
 			}
 			for ii := 0; ii < nidx; ii++ {
 				idx := stidx + ii
-				xy, _ := NewTableXYName(lview, xi, xp.TensorIdx, cp.Col, idx)
+				xy, _ := NewTableXYName(lview, xi, xp.TensorIdx, cp.Col, idx, pl.Params.MaxPoints, pl.Params.Downsample)
 				if xy == nil {
 					continue
 				}
@@ -146,8 +148,7 @@ func (pl *Plot2D) GenPlotBar() {
 				}
 				var bar *ErrBarChart
 				if ec >= 0 {
-					exy, _ := NewTableXY(lview, ec, 0, ec, 0)
-					bar, err = NewErrBarChart(xy, exy)
+					bar, err = NewErrBarChart(xy, barErrs(xy, ec))
 					if err != nil {
 						log.Println(err)
 						continue
@@ -159,6 +160,7 @@ func (pl *Plot2D) GenPlotBar() {
 						continue
 					}
 				}
+				bar.Pos = barPos(xy, lview)
 				bar.Color = clr
 				bar.Stride = float64(stride)
 				bar.Start = float64(start)
@@ -176,23 +178,21 @@ func (pl *Plot2D) GenPlotBar() {
 		mid = (stride - 2) / 2
 	}
 	if firstXY != nil && len(strCols) > 0 {
-		firstXY.Table = xview
-		n := xview.Len()
+		// label the rows of the first series across all legend values
+		fxy, _ := NewTableXYName(xview, xi, xp.TensorIdx, pl.Table.Table.ColNames[firstXY.YCol], firstXY.YIdx, pl.Params.MaxPoints, pl.Params.Downsample)
+		pos := barPos(fxy, xview)
+		n := fxy.Len()
 		for _, cp := range strCols {
-			xy, _ := NewTableXYName(xview, xi, xp.TensorIdx, cp.Col, cp.TensorIdx)
-			xy.LblCol = xy.YCol
-			xy.YCol = firstXY.YCol
-			xy.YIdx = firstXY.YIdx
-
+			lc := pl.Table.Table.ColByName(cp.Col)
 			xyl := plotter.XYLabels{}
 			xyl.XYs = make(plotter.XYs, n)
 			xyl.Labels = make([]string, n)
 
-			for i := range xview.Idxs {
-				y := firstXY.Value(i)
-				x := float64(mid + (i%maxx)*stride)
+			for i, row := range fxy.Table.Idxs {
+				y := fxy.Value(i)
+				x := float64(mid + (pos[i]%maxx)*stride)
 				xyl.XYs[i] = plotter.XY{x, y}
-				xyl.Labels[i] = xy.Label(i)
+				xyl.Labels[i] = lc.StringVal1D(row)
 			}
 			lbls, _ := plotter.NewLabels(xyl)
 			if lbls != nil {
@@ -201,16 +201,17 @@ func (pl *Plot2D) GenPlotBar() {
 		}
 	}
 
-	netn := pl.Table.Len() * stride
+	netn := xview.Len() * stride
 	xc := pl.Table.Table.Cols[xi]
 	vals := make([]string, netn)
-	for i, dx := range pl.Table.Idxs {
+	for i, dx := range xview.Idxs { // same positions as the bars
 		pi := mid + i*stride
 		if pi < netn && dx < xc.Len() {
 			vals[pi] = xc.StringVal1D(dx)
 		}
 	}
 	plt.NominalX(vals...)
+	pl.PlotTickFormats(plt, true, false)
 
 	plt.Legend.Top = true
 	plt.X.Tick.Label.Rotation = math.Pi * (pl.Params.XAxisRot / 180)
@@ -218,5 +219,44 @@ func (pl *Plot2D) GenPlotBar() {
 		plt.X.Tick.Label.YAlign = draw.YCenter
 		plt.X.Tick.Label.XAlign = draw.XRight
 	}
+	pl.PlotRefLines(plt)
 	pl.GPlot = plt
 }
+
+// barPos returns the ordinal position within given view of each row of xy,
+// which is a subset of the view rows in the same order (e.g., with the rows
+// with Null or NaN values removed, or downsampled), so that each bar is
+// drawn at the position of its row, under its X axis label.
+func barPos(xy *TableXY, view *etable.IdxView) []int {
+	pos := make([]int, xy.Len())
+	vi := 0
+	for i, row := range xy.Table.Idxs {
+		for vi < len(view.Idxs)-1 && view.Idxs[vi] != row {
+			vi++
+		}
+		pos[i] = vi
+		vi++
+	}
+	return pos
+}
+
+// barErrs returns the error bar values from column ec for each row of xy,
+// using 0 (no error bar) for Null or NaN error values.
+func barErrs(xy *TableXY, ec int) plotter.Values {
+	xy.ErrCol = ec
+	cl := xy.Table.Table.Cols[ec]
+	_, csz := cl.RowCellSize()
+	eidx := 0
+	if cl.NumDims() > 1 {
+		eidx = xy.YIdx
+	}
+	errs := make(plotter.Values, xy.Len())
+	for i, row := range xy.Table.Idxs {
+		_, ev := xy.YError(i)
+		if cl.IsNull1D(row*csz+eidx) || math.IsNaN(ev) {
+			continue
+		}
+		errs[i] = ev
+	}
+	return errs
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eplot
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+// testBarTable returns a table with a string X column c0..c3, a numeric
+// N column 0..3, a Y column 10..40, an Err column 1..4, and a label column a..d
+func testBarTable() *etable.Table {
+	dt := etable.New(etable.Schema{
+		{"X", etensor.STRING, nil, nil},
+		{"N", etensor.FLOAT64, nil, nil},
+		{"Y", etensor.FLOAT64, nil, nil},
+		{"Err", etensor.FLOAT64, nil, nil},
+		{"Lbl", etensor.STRING, nil, nil},
+	}, 4)
+	for i := 0; i < 4; i++ {
+		dt.SetCellString("X", i, fmt.Sprintf("c%d", i))
+		dt.SetCellFloat("N", i, float64(i))
+		dt.SetCellFloat("Y", i, float64(10*(i+1)))
+		dt.SetCellFloat("Err", i, float64(i+1))
+		dt.SetCellString("Lbl", i, string(rune('a'+i)))
+	}
+	return dt
+}
+
+// drawnStrings draws the current plot and returns the number of times
+// each string was drawn
+func drawnStrings(pl *Plot2D) map[string]int {
+	rc := &recorder.Canvas{}
+	pl.GPlot.Draw(draw.Canvas{Canvas: rc, Rectangle: vg.Rectangle{Max: vg.Point{X: 400, Y: 300}}})
+	strs := make(map[string]int)
+	for _, a := range rc.Actions {
+		if fs, ok := a.(*recorder.FillString); ok {
+			strs[fs.String]++
+		}
+	}
+	return strs
+}
+
+func TestBarNullY(t *testing.T) {
+	dt := testBarTable()
+	dt.ColByName("Y").SetNull1D(1, true)
+	dt.ColByName("Err").SetNull1D(2, true)
+	ix := etable.NewIdxView(dt)
+	xy, err := NewTableXYName(ix, dt.ColIdx("X"), 0, "Y", 0, 0, MinMax)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pos := barPos(xy, ix)
+	errs := barErrs(xy, dt.ColIdx("Err"))
+	expPos := []int{0, 2, 3}
+	expVal := []float64{10, 30, 40}
+	expErr := []float64{1, 0, 4} // Null error at row 2 gives no error bar
+	if len(pos) != 3 || len(errs) != 3 {
+		t.Fatalf("Bar NullY: %v positions, %v errors, expected 3\n", len(pos), len(errs))
+	}
+	for i := range pos {
+		if pos[i] != expPos[i] || xy.Value(i) != expVal[i] || errs[i] != expErr[i] {
+			t.Errorf("Bar NullY: bar %v: pos %v val %v err %v, expected %v %v %v\n", i, pos[i], xy.Value(i), errs[i], expPos[i], expVal[i], expErr[i])
+		}
+	}
+
+	pl := testPlot(dt, "X")
+	pl.ColParams("Y").On = true
+	pl.ColParams("Y").ErrCol = "Err"
+	pl.GenPlotBar()
+	strs := drawnStrings(pl)
+	for i := 0; i < 4; i++ {
+		if lb := fmt.Sprintf("c%d", i); strs[lb] != 1 {
+			t.Errorf("Bar NullY: X label %v drawn %v times, expected 1\n", lb, strs[lb])
+		}
+	}
+}
+
+func TestBarLabelsNullX(t *testing.T) {
+	dt := testBarTable()
+	dt.ColByName("N").SetNull1D(1, true)
+	pl := testPlot(dt, "N")
+	pl.ColParams("Y").On = true
+	pl.ColParams("Lbl").On = true
+	pl.GenPlotBar()
+	strs := drawnStrings(pl)
+	for i, exp := range []int{1, 0, 1, 1} { // no label for the Null X row
+		if lb := string(rune('a' + i)); strs[lb] != exp {
+			t.Errorf("Bar Labels NullX: label %v drawn %v times, expected %v\n", lb, strs[lb], exp)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eplot
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+
+	"github.com/emer/etable/etable"
+	"github.com/goki/gi/gi"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/plotter"
+)
+
+// GenPlotContour generates a Contour plot of the ZAxisCol values over the
+// X-Y plane of the XAxisCol and YAxisCol values, setting GPlot variable.
+// The rows are arranged into a TableGrid -- see NewTableGrid for how
+// irregular and missing grid points are handled.  If Params.Raster is set,
+// each grid cell is filled with the color for its value, and otherwise
+// Params.Levels contour lines are drawn.
+func (pl *Plot2D) GenPlotContour() {
+	plt, _ := plot.New()
+	plt.Title.Text = pl.Params.Title
+	plt.X.Label.Text = pl.XLabel()
+	plt.Y.Label.Text = pl.Params.YAxisLabel
+	if plt.Y.Label.Text == "" {
+		plt.Y.Label.Text = pl.Params.YAxisCol
+	}
+
+	plt.Title.Color = gi.Prefs.Colors.Font
+	plt.X.Color = gi.Prefs.Colors.Font
+	plt.Y.Color = gi.Prefs.Colors.Font
+	plt.X.Label.Color = gi.Prefs.Colors.Font
+	plt.Y.Label.Color = gi.Prefs.Colors.Font
+	plt.X.Tick.Color = gi.Prefs.Colors.Font
+	plt.Y.Tick.Color = gi.Prefs.Colors.Font
+
+	plt.BackgroundColor = nil
+
+	grid, err := NewTableGrid(pl.Table, pl.Params.XAxisCol, pl.Params.YAxisCol, pl.Params.ZAxisCol)
+	if err != nil {
+		log.Println("eplot.GenPlotContour: " + err.Error())
+		return
+	}
+	if nc, nr := grid.Dims(); nc < 2 || nr < 2 {
+		log.Printf("eplot.GenPlotContour: need at least 2 distinct X and Y values, have %d X and %d Y\n", nc, nr)
+		return
+	}
+	nlev := pl.Params.Levels
+	if nlev < 1 {
+		nlev = 10
+	}
+	if pl.Params.Raster {
+		hm := plotter.NewHeatMap(grid, pl.Params.ColorMap.Palette(nlev))
+		plt.Add(hm)
+	} else {
+		min, max := grid.Min(), grid.Max()
+		levs := make([]float64, nlev)
+		for i := range levs {
+			levs[i] = min + float64(i+1)*(max-min)/float64(nlev+1)
+		}
+		ct := plotter.NewContour(grid, levs, pl.Params.ColorMap.Palette(nlev))
+		plt.Add(ct)
+	}
+	pl.PlotTickFormats(plt, false, false)
+	pl.PlotRefLines(plt)
+	pl.GPlot = plt
+}
+
+// Palette returns a palette with n colors from this color map
+func (cm ColorMaps) Palette(n int) palette.Palette {
+	switch cm {
+	case BlackBody:
+		return moreland.BlackBody().Palette(n)
+	case Kindlmann:
+		return moreland.Kindlmann().Palette(n)
+	case Heat:
+		return palette.Heat(n, 1)
+	case Rainbow:
+		return palette.Rainbow(n, palette.Red, palette.Blue, 1, 1, 1)
+	default:
+		return moreland.SmoothBlueRed().Palette(n)
+	}
+}
+
+// TableGrid arranges the X, Y, Z values in three columns of a table into a
+// regular grid, implementing the plotter.GridXYZ interface used by the
+// gonum contour and heat map plotters.
+type TableGrid struct {
+	Xs []float64 `desc:"sorted distinct X values, one per grid column"`
+	Ys []float64 `desc:"sorted distinct Y values, one per grid row"`
+	Zs []float64 `desc:"Z value at each grid point, indexed by row * len(Xs) + column -- NaN for missing points"`
+}
+
+// NewTableGrid returns a new TableGrid with the values of the given X, Y, Z
+// scalar columns, over the rows of given view.  The grid has one column for
+// each distinct X value and one row for each distinct Y value, so irregular
+// sampling results in an uneven grid.  Missing grid points (combinations of
+// X and Y without a row) are not interpolated: they are NaN, and are left
+// blank in heat maps, with no contour lines drawn through them.  Rows where
+// any of the values is Null or NaN are skipped, and the Z values of rows
+// with the same X and Y are averaged.
+func NewTableGrid(ix *etable.IdxView, xcol, ycol, zcol string) (*TableGrid, error) {
+	tg := &TableGrid{}
+	xc, err := ix.Table.ColByNameTry(xcol)
+	if err != nil {
+		return nil, err
+	}
+	yc, err := ix.Table.ColByNameTry(ycol)
+	if err != nil {
+		return nil, err
+	}
+	zc, err := ix.Table.ColByNameTry(zcol)
+	if err != nil {
+		return nil, err
+	}
+	if xc.NumDims() > 1 || yc.NumDims() > 1 || zc.NumDims() > 1 {
+		return nil, fmt.Errorf("eplot.NewTableGrid: X, Y, Z columns must have scalar cells")
+	}
+	type xyz struct{ x, y, z float64 }
+	var pts []xyz
+	xm := make(map[float64]int)
+	ym := make(map[float64]int)
+	for _, row := range ix.Idxs {
+		if xc.IsNull1D(row) || yc.IsNull1D(row) || zc.IsNull1D(row) {
+			continue
+		}
+		p := xyz{xc.FloatVal1D(row), yc.FloatVal1D(row), zc.FloatVal1D(row)}
+		if math.IsNaN(p.x) || math.IsNaN(p.y) || math.IsNaN(p.z) {
+			continue
+		}
+		pts = append(pts, p)
+		if _, has := xm[p.x]; !has {
+			xm[p.x] = 0
+			tg.Xs = append(tg.Xs, p.x)
+		}
+		if _, has := ym[p.y]; !has {
+			ym[p.y] = 0
+			tg.Ys = append(tg.Ys, p.y)
+		}
+	}
+	if len(pts) == 0 {
+		return nil, fmt.Errorf("eplot.NewTableGrid: no valid X, Y, Z values in columns: %v, %v, %v", xcol, ycol, zcol)
+	}
+	sort.Float64s(tg.Xs)
+	sort.Float64s(tg.Ys)
+	for i, x := range tg.Xs {
+		xm[x] = i
+	}
+	for i, y := range tg.Ys {
+		ym[y] = i
+	}
+	nx := len(tg.Xs)
+	tg.Zs = make([]float64, nx*len(tg.Ys))
+	ns := make([]int, len(tg.Zs))
+	for _, p := range pts {
+		i := ym[p.y]*nx + xm[p.x]
+		tg.Zs[i] += p.z
+		ns[i]++
+	}
+	for i, n := range ns {
+		if n == 0 {
+			tg.Zs[i] = math.NaN()
+		} else {
+			tg.Zs[i] /= float64(n)
+		}
+	}
+	return tg, nil
+}
+
+// Dims returns the number of columns (X values) and rows (Y values) of the grid
+func (tg *TableGrid) Dims() (c, r int) {
+	return len(tg.Xs), len(tg.Ys)
+}
+
+// Z returns the Z value at given grid column and row -- NaN if missing
+func (tg *TableGrid) Z(c, r int) float64 {
+	return tg.Zs[r*len(tg.Xs)+c]
+}
+
+// X returns the X value of given grid column
+func (tg *TableGrid) X(c int) float64 {
+	return tg.Xs[c]
+}
+
+// Y returns the Y value of given grid row
+func (tg *TableGrid) Y(r int) float64 {
+	return tg.Ys[r]
+}
+
+// Min returns the minimum Z value, ignoring missing points
+func (tg *TableGrid) Min() float64 {
+	min := math.Inf(1)
+	for _, z := range tg.Zs {
+		if !math.IsNaN(z) {
+			min = math.Min(min, z)
+		}
+	}
+	return min
+}
+
+// Max returns the maximum Z value, ignoring missing points
+func (tg *TableGrid) Max() float64 {
+	max := math.Inf(-1)
+	for _, z := range tg.Zs {
+		if !math.IsNaN(z) {
+			max = math.Max(max, z)
+		}
+	}
+	return max
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eplot
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
+)
+
+// testGridTable returns a table with a regular 3x3 grid of Z = X + 3 * Y values
+func testGridTable() *etable.Table {
+	dt := etable.New(etable.Schema{
+		{"X", etensor.FLOAT64, nil, nil},
+		{"Y", etensor.FLOAT64, nil, nil},
+		{"Z", etensor.FLOAT64, nil, nil},
+	}, 9)
+	for i := 0; i < 9; i++ {
+		x, y := i%3, i/3
+		dt.SetCellFloat("X", i, float64(x))
+		dt.SetCellFloat("Y", i, float64(y))
+		dt.SetCellFloat("Z", i, float64(x+3*y))
+	}
+	return dt
+}
+
+func TestGenPlotContour(t *testing.T) {
+	pl := testPlot(testGridTable(), "X")
+	pl.Params.Type = Contour
+	pl.Params.YAxisCol = "Y"
+	pl.Params.ZAxisCol = "Z"
+	pl.GenPlotContour()
+	if pl.GPlot == nil {
+		t.Fatal("GenPlotContour: no plot")
+	}
+	// contour lines are stroked in palette colors, distinct from the axes,
+	// and Z = X + 3 * Y is constant along each one, at its level
+	levs := make(map[float64]bool)
+	for _, dp := range drawnPaths(pl) {
+		if dp.Fill || dp.Color == gi.Prefs.Colors.Font || len(dp.XYs) < 2 {
+			continue
+		}
+		lev := dp.XYs[0].X + 3*dp.XYs[0].Y
+		for _, xy := range dp.XYs {
+			if z := xy.X + 3*xy.Y; !nearData(z, lev, 8) {
+				t.Errorf("GenPlotContour: line at level %v has point %v with Z %v\n", lev, xy, z)
+			}
+		}
+		if lev <= 0 || lev >= 8 {
+			t.Errorf("GenPlotContour: level %v outside Z range\n", lev)
+		}
+		levs[math.Round(lev*1e4)/1e4] = true
+	}
+	if len(levs) != pl.Params.Levels {
+		t.Errorf("GenPlotContour: %d levels drawn, not %d\n", len(levs), pl.Params.Levels)
+	}
+	if x, y := pl.GPlot.X, pl.GPlot.Y; x.Min != 0 || x.Max != 2 || y.Min != 0 || y.Max != 2 {
+		t.Errorf("GenPlotContour: data range X %v - %v, Y %v - %v\n", x.Min, x.Max, y.Min, y.Max)
+	}
+
+	pl.Params.Raster = true
+	pl.Params.ColorMap = Kindlmann
+	pl.GenPlotContour()
+	pal := pl.Params.ColorMap.Palette(pl.Params.Levels).Colors()
+	ncell := make(map[color.Color]int)
+	for _, dp := range drawnPaths(pl) {
+		if dp.Fill {
+			ncell[dp.Color]++
+		}
+	}
+	nc := 0
+	for _, n := range ncell {
+		nc += n
+	}
+	// one cell per grid point, with Z 0 and 8 at the ends of the palette
+	if nc != 9 || ncell[pal[0]] != 1 || ncell[pal[len(pal)-1]] != 1 {
+		t.Errorf("GenPlotContour: Raster drew %v cells, %v at Z min, %v at Z max\n", nc, ncell[pal[0]], ncell[pal[len(pal)-1]])
+	}
+}
+
+func TestTableGridMissing(t *testing.T) {
+	dt := testGridTable()
+	ix := etable.NewIdxView(dt)
+	ix.Filter(func(et *etable.Table, row int) bool {
+		return row != 4 // center point missing
+	})
+	dt.SetCellFloat("Z", 8, math.NaN()) // skipped
+	dt.AddRows(1)
+	dt.SetCellFloat("X", 9, 0)
+	dt.SetCellFloat("Y", 9, 0)
+	dt.SetCellFloat("Z", 9, 2) // averaged with 0
+	ix.Idxs = append(ix.Idxs, 9)
+	tg, err := NewTableGrid(ix, "X", "Y", "Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, r := tg.Dims(); c != 3 || r != 3 {
+		t.Fatalf("TableGrid: dims %d x %d, not 3 x 3\n", c, r)
+	}
+	if !math.IsNaN(tg.Z(1, 1)) || !math.IsNaN(tg.Z(2, 2)) {
+		t.Errorf("TableGrid: missing points not NaN: %v %v\n", tg.Z(1, 1), tg.Z(2, 2))
+	}
+	if tg.Z(0, 0) != 1 || tg.Z(2, 1) != 5 {
+		t.Errorf("TableGrid: bad values: %v %v\n", tg.Z(0, 0), tg.Z(2, 1))
+	}
+	if tg.Min() != 1 || tg.Max() != 7 {
+		t.Errorf("TableGrid: range %v - %v, not 1 - 7\n", tg.Min(), tg.Max())
+	}
+	if _, err := NewTableGrid(ix, "X", "Y", "W"); err == nil {
+		t.Errorf("TableGrid: no error for missing column\n")
+	}
+}
@@ -7,9 +7,12 @@ package eplot
 import (
 	"fmt"
 	"image"
+	"image/color"
 	"log"
 	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/emer/etable/etable"
 	"github.com/emer/etable/etensor"
@@ -21,6 +24,8 @@ import (
 	"github.com/goki/ki/ki"
 	"github.com/goki/ki/kit"
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
 )
 
 // Plot2D is a GoGi Widget that provides a 2D plot of selected columns of etable data
@@ -33,6 +38,14 @@ type Plot2D struct {
 	SVGFile  gi.FileName     `desc:"current svg file"`
 	DataFile gi.FileName     `desc:"current csv data file"`
 	InPlot   bool            `inactive:"+" desc:"currently doing a plot"`
+	RefLines []RefLine       `view:"-" desc:"horizontal and vertical reference lines drawn across the full range of the plot, independent of the data columns -- see AddHLine, AddVLine"`
+	series   []plotSeries    `desc:"the data of each series plotted in the last XY plot generated, for PlotData"`
+}
+
+// plotSeries records the X, Y points of one plotted series, for PlotData
+type plotSeries struct {
+	Label string
+	XYs   plotter.XYs
 }
 
 var KiT_Plot2D = kit.Types.AddType(&Plot2D{}, Plot2DProps)
@@ -47,6 +60,7 @@ func (pl *Plot2D) CopyFieldsFrom(frm interface{}) {
 	pl.Layout.CopyFieldsFrom(&fr.Layout)
 	pl.Params.CopyFrom(&fr.Params)
 	pl.SetTableView(fr.Table)
+	pl.RefLines = append([]RefLine(nil), fr.RefLines...)
 	mx := ints.MinInt(len(pl.Cols), len(fr.Cols))
 	for i := 0; i < mx; i++ {
 		pl.Cols[i].CopyFrom(fr.Cols[i])
@@ -122,6 +136,149 @@ func (pl *Plot2D) SetColParams(colNm string, on bool, fixMin bool, min float64,
 	return cp
 }
 
+// RefLine is a horizontal or vertical reference line drawn across the plot
+type RefLine struct {
+	Horiz bool        `desc:"if true, line is horizontal at Y = Val, else vertical at X = Val"`
+	Val   float64     `desc:"position of the line on the Y axis if Horiz, else X axis"`
+	Color color.Color `desc:"color of the line"`
+}
+
+// AddHLine adds a horizontal reference line at given Y value, e.g., a chance level,
+// drawn across the full X range of the plot regardless of which columns are On.
+func (pl *Plot2D) AddHLine(y float64, clr color.Color) {
+	pl.RefLines = append(pl.RefLines, RefLine{Horiz: true, Val: y, Color: clr})
+}
+
+// AddVLine adds a vertical reference line at given X value,
+// drawn across the full Y range of the plot regardless of which columns are On.
+func (pl *Plot2D) AddVLine(x float64, clr color.Color) {
+	pl.RefLines = append(pl.RefLines, RefLine{Horiz: false, Val: x, Color: clr})
+}
+
+// ClearRefLines removes all the reference lines added by AddHLine, AddVLine
+func (pl *Plot2D) ClearRefLines() {
+	pl.RefLines = nil
+}
+
+// PlotRefLines adds the RefLines to given plot, spanning the current
+// range of the plot axes, extended to include the lines themselves.
+// If an axis has no finite range (e.g., no data columns are On), the
+// lines span the range of the line values on that axis, or 0..1 if none.
+// Must be called after all the data has been added to the plot.
+func (pl *Plot2D) PlotRefLines(plt *plot.Plot) {
+	if len(pl.RefLines) == 0 {
+		return
+	}
+	xmin, xmax := plt.X.Min, plt.X.Max
+	ymin, ymax := plt.Y.Min, plt.Y.Max
+	for _, rl := range pl.RefLines {
+		if rl.Horiz {
+			ymin = math.Min(ymin, rl.Val)
+			ymax = math.Max(ymax, rl.Val)
+		} else {
+			xmin = math.Min(xmin, rl.Val)
+			xmax = math.Max(xmax, rl.Val)
+		}
+	}
+	xmin, xmax = finiteRange(xmin, xmax)
+	ymin, ymax = finiteRange(ymin, ymax)
+	for _, rl := range pl.RefLines {
+		var xys plotter.XYs
+		if rl.Horiz {
+			xys = plotter.XYs{{X: xmin, Y: rl.Val}, {X: xmax, Y: rl.Val}}
+		} else {
+			xys = plotter.XYs{{X: rl.Val, Y: ymin}, {X: rl.Val, Y: ymax}}
+		}
+		lns, err := plotter.NewLine(xys)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		lns.LineStyle.Width = vg.Points(pl.Params.LineWidth)
+		lns.LineStyle.Color = rl.Color
+		plt.Add(lns)
+	}
+}
+
+// finiteRange returns the given axis range made finite, for PlotRefLines:
+// an infinite end is set 1 away from the other end, or to 0..1 if both are.
+func finiteRange(min, max float64) (float64, float64) {
+	minf, maxf := !math.IsInf(min, 0), !math.IsInf(max, 0)
+	switch {
+	case minf && maxf:
+		return min, max
+	case minf:
+		return min, min + 1
+	case maxf:
+		return max - 1, max
+	}
+	return 0, 1
+}
+
+// TickFormatter is a plot.Ticker that uses another Ticker for the tick
+// positions, and formats the labels of the major (labeled) ticks from their
+// values using a printf-style format string.
+type TickFormatter struct {
+	Ticker plot.Ticker `desc:"ticker that determines the tick positions"`
+	Format string      `desc:"printf-style format for the tick labels, e.g., %.2e or %.1f"`
+}
+
+// Ticks returns the ticks of the Ticker with labels formatted by Format
+func (tf TickFormatter) Ticks(min, max float64) []plot.Tick {
+	ticks := tf.Ticker.Ticks(min, max)
+	for i := range ticks {
+		if ticks[i].Label != "" {
+			ticks[i].Label = fmt.Sprintf(tf.Format, ticks[i].Value)
+		}
+	}
+	return ticks
+}
+
+// DefaultTimeLayout is the Go time layout used for time X axis tick labels
+// if XTimeLayout is not set
+var DefaultTimeLayout = "2006-01-02 15:04:05"
+
+// UnixTime converts a Unix timestamp in seconds, including any fractional
+// seconds, into a UTC time.Time, for time X axis tick labels
+func UnixTime(t float64) time.Time {
+	sec, frac := math.Modf(t)
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC()
+}
+
+// PlotTickFormats installs TickFormatter tickers on the numeric axes of given
+// plot according to the XTickFormat and YTickFormat params -- if these are
+// empty, the format meta data of the X axis column and the first column plotted
+// are used, if set (see etable.Table ColFormat).  An X format of time
+// installs a plot.TimeTicks ticker with XTimeLayout, treating X values as
+// Unix timestamps (see UnixTime).  Nominal (string)
+// axes are marked by the nomX and nomY args, and are left as is.
+func (pl *Plot2D) PlotTickFormats(plt *plot.Plot, nomX, nomY bool) {
+	xfmt := pl.Params.XTickFormat
+	yfmt := pl.Params.YTickFormat
+	if pl.Table != nil && pl.Table.Table != nil {
+		if xfmt == "" && pl.Params.XAxisCol != "" {
+			xfmt = pl.Table.Table.ColFormat(pl.Params.XAxisCol)
+		}
+		if cp := pl.yLabelCol(); yfmt == "" && cp != nil {
+			yfmt = pl.Table.Table.ColFormat(cp.Col)
+		}
+	}
+	switch {
+	case nomX || xfmt == "":
+	case xfmt == "time":
+		lay := pl.Params.XTimeLayout
+		if lay == "" {
+			lay = DefaultTimeLayout
+		}
+		plt.X.Tick.Marker = plot.TimeTicks{Ticker: plt.X.Tick.Marker, Format: lay, Time: UnixTime}
+	default:
+		plt.X.Tick.Marker = TickFormatter{Ticker: plt.X.Tick.Marker, Format: xfmt}
+	}
+	if yfmt != "" && !nomY {
+		plt.Y.Tick.Marker = TickFormatter{Ticker: plt.Y.Tick.Marker, Format: yfmt}
+	}
+}
+
 // SaveSVG saves the plot to an svg -- first updates to ensure that plot is current
 func (pl *Plot2D) SaveSVG(fname gi.FileName) {
 	pl.Update()
@@ -142,6 +299,34 @@ func (pl *Plot2D) SaveCSV(fname gi.FileName, delim etable.Delims) {
 	pl.DataFile = fname
 }
 
+// PlotData returns a table with the X, Y data points of each series as actually
+// rendered in the last XY plot generated (i.e., after any filtering of Null
+// values and downsampling), for exporting the numbers behind a plot.
+// The table has Series (the series label), X and Y columns, with one row
+// per point, in plotting order.  Returns an empty table if nothing
+// has been plotted.
+func (pl *Plot2D) PlotData() *etable.Table {
+	n := 0
+	for _, ps := range pl.series {
+		n += len(ps.XYs)
+	}
+	dt := etable.New(etable.Schema{
+		{"Series", etensor.STRING, nil, nil},
+		{"X", etensor.FLOAT64, nil, nil},
+		{"Y", etensor.FLOAT64, nil, nil},
+	}, n)
+	ri := 0
+	for _, ps := range pl.series {
+		for _, xy := range ps.XYs {
+			dt.SetCellString("Series", ri, ps.Label)
+			dt.SetCellFloat("X", ri, xy.X)
+			dt.SetCellFloat("Y", ri, xy.Y)
+			ri++
+		}
+	}
+	return dt
+}
+
 // OpenCSV opens the Table data from a csv (comma-separated values) file (or any delim)
 func (pl *Plot2D) OpenCSV(fname gi.FileName, delim etable.Delims) {
 	pl.Table.Table.OpenCSV(fname, delim)
@@ -150,20 +335,30 @@ func (pl *Plot2D) OpenCSV(fname gi.FileName, delim etable.Delims) {
 	pl.Update()
 }
 
-// YLabel returns the Y-axis label
+// YLabel returns the Y-axis label -- the label of the first column plotted,
+// with its units if set in the table meta data (see etable.Table ColUnit)
 func (pl *Plot2D) YLabel() string {
 	if pl.Params.YAxisLabel != "" {
 		return pl.Params.YAxisLabel
 	}
+	if cp := pl.yLabelCol(); cp != nil {
+		return pl.unitLabel(cp.Label(), cp.Col)
+	}
+	return "Y"
+}
+
+// yLabelCol returns the first column plotted, which labels the Y axis, or nil if none
+func (pl *Plot2D) yLabelCol() *ColParams {
 	for _, cp := range pl.Cols {
 		if cp.On {
-			return cp.Label()
+			return cp
 		}
 	}
-	return "Y"
+	return nil
 }
 
-// XLabel returns the X-axis label
+// XLabel returns the X-axis label, with the units of the X axis column
+// if set in the table meta data (see etable.Table ColUnit)
 func (pl *Plot2D) XLabel() string {
 	if pl.Params.XAxisLabel != "" {
 		return pl.Params.XAxisLabel
@@ -171,13 +366,25 @@ func (pl *Plot2D) XLabel() string {
 	if pl.Params.XAxisCol != "" {
 		cp := pl.ColParams(pl.Params.XAxisCol)
 		if cp != nil {
-			return cp.Label()
+			return pl.unitLabel(cp.Label(), cp.Col)
 		}
 		return pl.Params.XAxisCol
 	}
 	return "X"
 }
 
+// unitLabel returns given axis label for given column name, followed by the
+// units of the column in parentheses, e.g., Time (ms), if set in the table meta data
+func (pl *Plot2D) unitLabel(lbl, colNm string) string {
+	if pl.Table == nil || pl.Table.Table == nil {
+		return lbl
+	}
+	if un := pl.Table.Table.ColUnit(colNm); un != "" {
+		return lbl + " (" + un + ")"
+	}
+	return lbl
+}
+
 // GoUpdate updates the display based on current state of table.
 // This version must be used when called from another goroutine
 // does proper blocking to synchronize with updating in the main
@@ -280,6 +487,8 @@ func (pl *Plot2D) GenPlot() {
 		pl.GenPlotXY()
 	case Bar:
 		pl.GenPlotBar()
+	case Contour:
+		pl.GenPlotContour()
 	}
 	if pl.GPlot != nil {
 		PlotViewSVG(pl.GPlot, sv, pl.Params.Scale)
@@ -336,27 +545,50 @@ func (pl *Plot2D) PlotXAxis(plt *plot.Plot, ixvw *etable.IdxView) (xi int, xview
 			return true
 		})
 	}
-	if pl.Params.NegXDraw {
-		return
+	if !pl.Params.NegXDraw || xp.Breaks {
+		xbreaks = colBreaks(xview, xc, xp.TensorIdx)
 	}
-	lastx := -math.MaxFloat64
-	for row := 0; row < xview.Len(); row++ {
-		trow := xview.Idxs[row] // true table row
-		var xv float64
-		if xc.NumDims() > 1 {
-			xv = xc.FloatValRowCell(trow, xp.TensorIdx)
-		} else {
-			xv = xc.FloatVal1D(trow)
+	for ci, cp := range pl.Cols {
+		if !cp.Breaks || ci == xi || ci >= ixvw.Table.NumCols() {
+			continue
 		}
-		if xv < lastx {
-			xbreaks = append(xbreaks, row)
+		xbreaks = append(xbreaks, colBreaks(xview, ixvw.Table.Cols[ci], cp.TensorIdx)...)
+	}
+	sort.Ints(xbreaks)
+	nb := 0
+	for _, br := range xbreaks { // remove duplicates
+		if nb == 0 || xbreaks[nb-1] != br {
+			xbreaks[nb] = br
+			nb++
 		}
-		lastx = xv
 	}
-	xbreaks = append(xbreaks, xview.Len())
+	xbreaks = append(xbreaks[:nb], xview.Len())
 	return
 }
 
+// colBreaks returns the rows in given view at which the value of given column
+// decreases relative to the prior row (e.g., a counter that resets), which are
+// treated as breaks between repeated series.  tsrIdx is the index within the
+// cell for n-dimensional columns.
+func colBreaks(view *etable.IdxView, col etensor.Tensor, tsrIdx int) []int {
+	var brks []int
+	last := -math.MaxFloat64
+	for row := 0; row < view.Len(); row++ {
+		trow := view.Idxs[row] // true table row
+		var v float64
+		if col.NumDims() > 1 {
+			v = col.FloatValRowCell(trow, ints.MaxInt(tsrIdx, 0))
+		} else {
+			v = col.FloatVal1D(trow)
+		}
+		if v < last {
+			brks = append(brks, row)
+		}
+		last = v
+	}
+	return brks
+}
+
 // Config configures the overall view widget
 func (pl *Plot2D) Config() {
 	pl.Lay = gi.LayoutVert
@@ -440,6 +672,7 @@ func (pl *Plot2D) ColsListUpdate() {
 			inc = 0
 		}
 		cp := &ColParams{Col: cn, ColorName: gi.ColorName(PlotColorNames[clri%npc])}
+		cp.defColor = cp.ColorName
 		cp.Defaults()
 		tcol := dt.Cols[ci]
 		if tcol.DataType() == etensor.STRING {
@@ -757,5 +990,26 @@ var Plot2DProps = ki.Props{
 	},
 }
 
+// AutoColor returns the color to use for plotting given column, as the
+// next color from the Params.Palette (or PlotColorNames) if Params.AutoColors
+// is set and the column color has not been explicitly set, advancing the
+// given counter of auto-colored series, and otherwise the column Color.
+func (pl *Plot2D) AutoColor(cp *ColParams, ai *int) gi.Color {
+	if !pl.Params.AutoColors || cp.ColorName != cp.defColor {
+		return cp.Color
+	}
+	pal := pl.Params.Palette
+	if len(pal) == 0 {
+		pal = PlotColorNames
+	}
+	clr, err := gi.ColorFromString(pal[*ai%len(pal)], nil)
+	*ai++
+	if err != nil {
+		log.Println("eplot.Palette: " + err.Error())
+		return cp.Color
+	}
+	return clr
+}
+
 // these are the plot color names to use in order for successive lines -- feel free to choose your own!
 var PlotColorNames = []string{"black", "red", "blue", "ForestGreen", "purple", "orange", "brown", "chartreuse", "navy", "cyan", "magenta", "tan", "salmon", "yellow", "SkyBlue", "pink"}
@@ -37,6 +37,10 @@ type ErrBarChart struct {
 	// YErrors is a copy of the Y errors for each point.
 	Errors plotter.Values
 
+	// Pos, if non-nil, is the ordinal position of each bar, used instead
+	// of its index in Values, e.g., to leave gaps for missing values.
+	Pos []int
+
 	// Start is starting offset -- first bar is centered at this point.
 	// Defaults to 1.
 	Start float64
@@ -108,6 +112,14 @@ func (b *ErrBarChart) Defaults() {
 	b.LineStyle = plotter.DefaultLineStyle
 }
 
+// pos returns the ordinal position of the ith bar
+func (b *ErrBarChart) pos(i int) int {
+	if b.Pos != nil && i >= 0 && i < len(b.Pos) {
+		return b.Pos[i]
+	}
+	return i
+}
+
 // BarHeight returns the maximum y value of the
 // ith bar, taking into account any bars upon
 // which it is stacked.
@@ -143,7 +155,7 @@ func (b *ErrBarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 	}
 
 	for i, ht := range b.Values {
-		cat := b.Start + float64(i)*b.Stride
+		cat := b.Start + float64(b.pos(i))*b.Stride
 		catVal := trCat(cat)
 		if !b.Horizontal {
 			if !c.ContainsX(catVal) {
@@ -210,7 +222,7 @@ func (b *ErrBarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 // DataRange implements the plot.DataRanger interface.
 func (b *ErrBarChart) DataRange() (xmin, xmax, ymin, ymax float64) {
 	catMin := b.Start - b.Pad
-	catMax := b.Start + float64(len(b.Values)-1)*b.Stride + b.Pad
+	catMax := b.Start + float64(b.pos(len(b.Values)-1))*b.Stride + b.Pad
 
 	valMin := math.Inf(1)
 	valMax := math.Inf(-1)
@@ -233,7 +245,7 @@ func (b *ErrBarChart) DataRange() (xmin, xmax, ymin, ymax float64) {
 func (b *ErrBarChart) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	boxes := make([]plot.GlyphBox, len(b.Values))
 	for i := range b.Values {
-		cat := b.Start + float64(i)*b.Stride
+		cat := b.Start + float64(b.pos(i))*b.Stride
 		if !b.Horizontal {
 			boxes[i].X = plt.X.Norm(cat)
 			xr := plt.X.Max - plt.X.Min
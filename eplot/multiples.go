@@ -0,0 +1,122 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eplot
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/emer/etable/etable"
+	"github.com/goki/gi/gi"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgsvg"
+)
+
+// SmallMultiples returns a grid of small line plots (sparklines) for a column
+// with n-dimensional tensor cells, one plot for the cell of each of the given
+// table rows, plotting the cell values in order against their index within
+// the cell -- e.g., for inspecting how a pattern evolves across trials.
+// The plots are laid out in row-major order in a grid of nRows x nCols plots,
+// with any unused positions at the end left nil.  If nRows <= 0, it is set
+// to the number of rows needed to hold all the plots in nCols columns.
+// All plots share the same Y axis range so they can be compared directly.
+// Null or NaN values are omitted.  Use DrawSmallMultiples or
+// SaveSmallMultiplesSVG to render the grid.
+func SmallMultiples(dt *etable.Table, colNm string, rows []int, nRows, nCols int) ([][]*plot.Plot, error) {
+	cl, err := dt.ColByNameTry(colNm)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("eplot.SmallMultiples: no rows provided")
+	}
+	if nCols <= 0 {
+		return nil, fmt.Errorf("eplot.SmallMultiples: nCols must be > 0, is: %v", nCols)
+	}
+	if nRows <= 0 {
+		nRows = (len(rows) + nCols - 1) / nCols
+	}
+	if nRows*nCols < len(rows) {
+		return nil, fmt.Errorf("eplot.SmallMultiples: %d x %d grid cannot hold %d plots", nRows, nCols, len(rows))
+	}
+	_, csz := cl.RowCellSize()
+	ymin, ymax := math.Inf(1), math.Inf(-1)
+	xyss := make([]plotter.XYs, len(rows))
+	for i, row := range rows {
+		if row < 0 || row >= dt.Rows {
+			return nil, fmt.Errorf("eplot.SmallMultiples: row %d out of range", row)
+		}
+		xys := make(plotter.XYs, 0, csz)
+		for j := 0; j < csz; j++ {
+			vi := row*csz + j
+			v := cl.FloatVal1D(vi)
+			if cl.IsNull1D(vi) || math.IsNaN(v) {
+				continue
+			}
+			xys = append(xys, plotter.XY{X: float64(j), Y: v})
+			ymin = math.Min(ymin, v)
+			ymax = math.Max(ymax, v)
+		}
+		xyss[i] = xys
+	}
+	plots := make([][]*plot.Plot, nRows)
+	for r := range plots {
+		plots[r] = make([]*plot.Plot, nCols)
+	}
+	for i, row := range rows {
+		plt, err := plot.New()
+		if err != nil {
+			return nil, err
+		}
+		plt.Title.Text = fmt.Sprintf("%s %d", colNm, row)
+		plt.BackgroundColor = nil
+		if len(xyss[i]) > 0 {
+			lns, err := plotter.NewLine(xyss[i])
+			if err != nil {
+				return nil, err
+			}
+			plt.Add(lns)
+			plt.Y.Min, plt.Y.Max = ymin, ymax
+		}
+		plots[i/nCols][i%nCols] = plt
+	}
+	return plots, nil
+}
+
+// DrawSmallMultiples draws the given grid of plots from SmallMultiples
+// onto the given canvas, aligning them so their data areas are evenly
+// sized and spaced.
+func DrawSmallMultiples(plots [][]*plot.Plot, c draw.Canvas) {
+	if len(plots) == 0 {
+		return
+	}
+	t := draw.Tiles{Rows: len(plots), Cols: len(plots[0]), PadX: vg.Millimeter, PadY: vg.Millimeter}
+	cvs := plot.Align(plots, t, c)
+	for r, prow := range plots {
+		for ci, plt := range prow {
+			if plt != nil {
+				plt.Draw(cvs[r][ci])
+			}
+		}
+	}
+}
+
+// SaveSmallMultiplesSVG saves the given grid of plots from SmallMultiples
+// to an svg file of given overall width and height.
+func SaveSmallMultiplesSVG(fname gi.FileName, plots [][]*plot.Plot, w, h vg.Length) error {
+	c := vgsvg.New(w, h)
+	DrawSmallMultiples(plots, draw.New(c))
+	fp, err := os.Create(string(fname))
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	_, err = c.WriteTo(fp)
+	return err
+}
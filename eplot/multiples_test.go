@@ -0,0 +1,67 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eplot
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+func TestSmallMultiples(t *testing.T) {
+	dt := etable.New(etable.Schema{
+		{"Pat", etensor.FLOAT32, []int{2, 3}, nil},
+	}, 6)
+	for i := 0; i < dt.Cols[0].Len(); i++ {
+		dt.Cols[0].SetFloat1D(i, float64(i%7))
+	}
+	rows := []int{0, 2, 3, 5}
+	plots, err := SmallMultiples(dt, "Pat", rows, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plots) != 2 || len(plots[0]) != 3 || plots[1][2] != nil {
+		t.Fatalf("SmallMultiples: bad grid layout: %v\n", plots)
+	}
+	for i := range rows {
+		plt := plots[i/3][i%3]
+		if plt == nil {
+			t.Fatalf("SmallMultiples: no plot for row %v\n", rows[i])
+		}
+		lns := drawnLines(&Plot2D{GPlot: plt}, color.Black)
+		nln := 0
+		for _, ln := range lns {
+			if len(ln.XYs) == 2 { // axis and tick lines
+				continue
+			}
+			nln++
+			if len(ln.XYs) != 6 {
+				t.Errorf("SmallMultiples: row %v: %v points, expected 6\n", rows[i], len(ln.XYs))
+				continue
+			}
+			for j, xy := range ln.XYs {
+				if ey := float64((rows[i]*6 + j) % 7); !nearData(xy.X, float64(j), 5) || !nearData(xy.Y, ey, 6) {
+					t.Errorf("SmallMultiples: row %v: point %v is %v, expected {%v %v}\n", rows[i], j, xy, j, ey)
+				}
+			}
+		}
+		if nln != 1 {
+			t.Errorf("SmallMultiples: row %v: %v lines, expected 1\n", rows[i], nln)
+		}
+	}
+	rc := &recorder.Canvas{}
+	DrawSmallMultiples(plots, draw.Canvas{Canvas: rc, Rectangle: vg.Rectangle{Max: vg.Point{X: 600, Y: 400}}})
+	if len(rc.Actions) == 0 {
+		t.Errorf("DrawSmallMultiples: nothing drawn\n")
+	}
+	if _, err := SmallMultiples(dt, "Pat", rows, 1, 3); err == nil {
+		t.Errorf("SmallMultiples: no error for too small a grid\n")
+	}
+}
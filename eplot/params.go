@@ -9,25 +9,41 @@ import (
 	"github.com/emer/etable/minmax"
 	"github.com/goki/gi/gi"
 	"github.com/goki/ki/kit"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
 )
 
 // PlotParams are parameters for overall plot
 type PlotParams struct {
-	Title      string    `desc:"optional title at top of plot"`
-	Type       PlotTypes `desc:"type of plot to generate.  For a Bar plot, items are plotted ordinally by row and the XAxis is optional"`
-	Lines      bool      `desc:"plot lines"`
-	Points     bool      `desc:"plot points with symbols"`
-	LineWidth  float64   `desc:"width of lines"`
-	PointSize  float64   `desc:"size of points"`
-	BarWidth   float64   `min:"0.01" max:"1" desc:"width of bars for bar plot, as fraction of available space -- 1 = no gaps, .8 default"`
-	NegXDraw   bool      `desc:"draw lines that connect points with a negative X-axis direction -- otherwise these are treated as breaks between repeated series and not drawn"`
-	Scale      float64   `def:"2" desc:"overall scaling factor -- the larger the number, the larger the fonts are relative to the graph"`
-	XAxisCol   string    `desc:"what column to use for the common X axis -- if empty or not found, the row number is used.  This optional for Bar plots -- if present and LegendCol is also present, then an extra space will be put between X values."`
-	XAxisLabel string    `desc:"optional label to use for XAxis instead of column name"`
-	YAxisLabel string    `desc:"optional label to use for YAxis -- if empty, first column name is used"`
-	XAxisRot   float64   `desc:"rotation of the X Axis labels, in degrees"`
-	LegendCol  string    `desc:"optional column for adding a separate colored / styled line or bar according to this value -- acts just like a separate Y variable, crossed with Y variables"`
-	Plot       *Plot2D   `copy:"-" json:"-" xml:"-" view:"-" desc:"our plot, for update method"`
+	Title       string          `desc:"optional title at top of plot"`
+	Type        PlotTypes       `desc:"type of plot to generate.  For a Bar plot, items are plotted ordinally by row and the XAxis is optional"`
+	Lines       bool            `desc:"plot lines"`
+	Points      bool            `desc:"plot points with symbols"`
+	LineWidth   float64         `desc:"width of lines"`
+	PointSize   float64         `desc:"size of points"`
+	BarWidth    float64         `min:"0.01" max:"1" desc:"width of bars for bar plot, as fraction of available space -- 1 = no gaps, .8 default"`
+	Stacked     bool            `desc:"for XY plots, stack the values of each numeric Y series on top of the previous ones, at each row, so the topmost line shows the total -- negative and Null values add nothing to the stack"`
+	NegXDraw    bool            `desc:"draw lines that connect points with a negative X-axis direction -- otherwise these are treated as breaks between repeated series and not drawn"`
+	YClip       minmax.Range64  `desc:"for XY plots, Y values below Min (if FixMin) or above Max (if FixMax) are not drawn, and lines are broken at these points -- unlike the column Range, which sets the axis bounds but still draws outlying values, this omits spikes so the rest of the data is legible"`
+	MaxPoints   int             `desc:"if > 0, maximum number of points to plot for each line or set of bars -- tables with more rows than this are downsampled according to Downsample, which keeps large plots responsive"`
+	Downsample  DownsampleModes `desc:"how to downsample rows when there are more than MaxPoints -- MinMax preserves the envelope of noisy signals"`
+	Scale       float64         `def:"2" desc:"overall scaling factor -- the larger the number, the larger the fonts are relative to the graph"`
+	XAxisCol    string          `desc:"what column to use for the common X axis -- if empty or not found, the row number is used.  This optional for Bar plots -- if present and LegendCol is also present, then an extra space will be put between X values."`
+	XAxisLabel  string          `desc:"optional label to use for XAxis instead of column name"`
+	YAxisLabel  string          `desc:"optional label to use for YAxis -- if empty, first column name is used"`
+	YAxisCol    string          `desc:"for Contour plots, the column with the Y coordinate of each grid point -- XAxisCol provides the X coordinate"`
+	ZAxisCol    string          `desc:"for Contour plots, the column with the Z value plotted at each X, Y grid point"`
+	Raster      bool            `desc:"for Contour plots, fill each grid cell with the color for its Z value (i.e., a heat map), instead of drawing contour lines"`
+	Levels      int             `def:"10" desc:"for Contour plots, number of contour levels, evenly spaced between the minimum and maximum Z values, and the number of colors used for Raster plots"`
+	ColorMap    ColorMaps       `desc:"for Contour plots, the color map used for the Z values"`
+	XAxisRot    float64         `desc:"rotation of the X Axis labels, in degrees"`
+	XTickFormat string          `desc:"optional printf-style format for the numeric X axis tick labels, e.g., %.2e for scientific notation or %.1f for a fixed number of decimals -- if empty, the default labels are used.  The special format time labels the ticks as dates / times using XTimeLayout, treating the X values as Unix timestamps in seconds"`
+	XTimeLayout string          `desc:"Go time layout for X axis tick labels when XTickFormat (or the X column format meta data) is time -- if empty, DefaultTimeLayout is used"`
+	YTickFormat string          `desc:"optional printf-style format for the numeric Y axis tick labels, e.g., %.2e for scientific notation or %.1f for a fixed number of decimals -- if empty, the default labels are used"`
+	AutoColors  bool            `desc:"if true, plotted series whose color has not been explicitly set (i.e., ColorName is still the default assigned when the table was set) get successive distinct colors from Palette, in plotting order, cycling if there are more series than colors"`
+	Palette     []string        `desc:"color names to use for AutoColors -- if empty, PlotColorNames is used"`
+	LegendCol   string          `desc:"optional column for adding a separate colored / styled line or bar according to this value -- acts just like a separate Y variable, crossed with Y variables"`
+	Plot        *Plot2D         `copy:"-" json:"-" xml:"-" view:"-" desc:"our plot, for update method"`
 }
 
 // Defaults sets defaults if nil vals present
@@ -42,6 +58,9 @@ func (pp *PlotParams) Defaults() {
 	if pp.Scale == 0 {
 		pp.Scale = 2
 	}
+	if pp.Levels == 0 {
+		pp.Levels = 10
+	}
 }
 
 // Update satisfies the gi.Updater interface and will trigger display update on edits
@@ -81,18 +100,26 @@ func (pp *PlotParams) FmMeta(dt *etable.Table) {
 
 // ColParams are parameters for plotting one column of data
 type ColParams struct {
-	On        bool           `desc:"plot this column"`
-	Col       string         `desc:"name of column we're plotting"`
-	Range     minmax.Range64 `desc:"effective range of data to plot -- either end can be fixed"`
-	FullRange minmax.F64     `desc:"full actual range of data -- only valid if specifically computed"`
-	ColorName gi.ColorName   `desc:"if non-empty, color is set by this name"`
-	Color     gi.Color       `desc:"color to use in plotting the line"`
-	NTicks    int            `desc:"desired number of ticks"`
-	Lbl       string         `desc:"if non-empty, this is an alternative label to use in plotting"`
-	TensorIdx int            `desc:"if column has n-dimensional tensor cells in each row, this is the index within each cell to plot -- use -1 to plot *all* indexes as separate lines"`
-	ErrCol    string         `desc:"specifies a column containing error bars for this column"`
-	IsString  bool           `inactive:"+" desc:"if true this is a string column -- plots as labels"`
-	Plot      *Plot2D        `copy:"-" json:"-" xml:"-" view:"-" desc:"our plot, for update method"`
+	On         bool           `desc:"plot this column"`
+	Col        string         `desc:"name of column we're plotting"`
+	Range      minmax.Range64 `desc:"effective range of data to plot -- either end can be fixed"`
+	FullRange  minmax.F64     `desc:"full actual range of data -- only valid if specifically computed"`
+	ColorName  gi.ColorName   `desc:"if non-empty, color is set by this name"`
+	Color      gi.Color       `desc:"color to use in plotting the line"`
+	NTicks     int            `desc:"desired number of ticks"`
+	Dashes     []vg.Length    `desc:"if non-empty, line is drawn with this dash pattern, as alternating lengths of dashes and gaps -- useful for distinguishing lines without relying on color"`
+	GlyphShape GlyphShapes    `desc:"shape of the glyph used for plotting points -- DefaultGlyph uses the standard plot glyph -- different shapes help distinguish overlapping series without relying on color"`
+	Lbl        string         `desc:"if non-empty, this is an alternative label to use in plotting"`
+	TensorIdx  int            `desc:"if column has n-dimensional tensor cells in each row, this is the index within each cell to plot -- use -1 to plot *all* indexes as separate lines"`
+	ErrCol     string         `desc:"specifies a column containing error bars for this column"`
+	Band       int            `desc:"for XY plots, if > 0, the line plots the rolling mean of this column over a centered window of this many points, over a translucent filled band of +/- the rolling standard deviation -- e.g., for noisy learning curves"`
+	SizeCol    string         `desc:"for XY plots with Points, if non-empty, the column whose values set the radius of each point, as in a bubble chart -- values are scaled linearly from their min to max over the table onto SizeRange -- if this column is a tensor then the same TensorIdx is used"`
+	SizeRange  minmax.F64     `desc:"for SizeCol, the range of point radii (in points) that the min to max size values are mapped onto"`
+	XCol       string         `desc:"for XY plots, if non-empty, the column to use for the X values of this column, instead of the common XAxisCol -- for overlaying series with different X values (e.g., sampled on different grids) -- its TensorIdx is used for n-dimensional cells"`
+	Breaks     bool           `desc:"if true, lines are broken into separate segments wherever the value of this column decreases (resets), e.g., for an Epoch or Cycle counter in logs concatenated across multiple runs -- this column need not be plotted or be the X axis"`
+	IsString   bool           `inactive:"+" desc:"if true this is a string column -- plots as labels, or on a nominal Y axis if there are no numeric Y columns"`
+	defColor   gi.ColorName   `desc:"default ColorName assigned when the table was set, for AutoColors"`
+	Plot       *Plot2D        `copy:"-" json:"-" xml:"-" view:"-" desc:"our plot, for update method"`
 }
 
 // Defaults sets defaults if nil vals present
@@ -100,6 +127,9 @@ func (cp *ColParams) Defaults() {
 	if cp.NTicks == 0 {
 		cp.NTicks = 10
 	}
+	if cp.SizeRange.Max == 0 {
+		cp.SizeRange.Set(1, 10)
+	}
 }
 
 // Update satisfies the gi.Updater interface and will trigger display update on edits
@@ -151,5 +181,116 @@ const (
 	// Bar plots vertical bars
 	Bar
 
+	// Contour plots the Z values of ZAxisCol over the X-Y plane given by
+	// XAxisCol and YAxisCol, as contour lines or a filled Raster
+	Contour
+
 	PlotTypesN
 )
+
+// DownsampleModes are different ways of reducing the number of points plotted
+type DownsampleModes int32
+
+//go:generate stringer -type=DownsampleModes
+
+var KiT_DownsampleModes = kit.Enums.AddEnum(DownsampleModesN, kit.NotBitFlag, nil)
+
+func (ev DownsampleModes) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *DownsampleModes) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+const (
+	// MinMax divides the rows into MaxPoints / 2 equal buckets and keeps
+	// the rows with the minimum and maximum Y value within each bucket,
+	// preserving the envelope (e.g., spikes) of the data -- an odd
+	// MaxPoints also keeps the last row
+	MinMax DownsampleModes = iota
+
+	// Even keeps MaxPoints rows evenly spaced across the data
+	Even
+
+	DownsampleModesN
+)
+
+// GlyphShapes are the shapes of the glyphs used for plotting points
+type GlyphShapes int32
+
+//go:generate stringer -type=GlyphShapes
+
+var KiT_GlyphShapes = kit.Enums.AddEnum(GlyphShapesN, kit.NotBitFlag, nil)
+
+func (ev GlyphShapes) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *GlyphShapes) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+const (
+	// DefaultGlyph leaves the standard plot glyph shape in place
+	DefaultGlyph GlyphShapes = iota
+
+	// RingGlyph is an open circle
+	RingGlyph
+
+	// CircleGlyph is a filled circle
+	CircleGlyph
+
+	// SquareGlyph is an open square
+	SquareGlyph
+
+	// TriangleGlyph is an open triangle
+	TriangleGlyph
+
+	// CrossGlyph is an X
+	CrossGlyph
+
+	// PlusGlyph is a +
+	PlusGlyph
+
+	GlyphShapesN
+)
+
+// Drawer returns the gonum draw.GlyphDrawer for this shape,
+// or nil for DefaultGlyph
+func (gs GlyphShapes) Drawer() draw.GlyphDrawer {
+	switch gs {
+	case RingGlyph:
+		return draw.RingGlyph{}
+	case CircleGlyph:
+		return draw.CircleGlyph{}
+	case SquareGlyph:
+		return draw.SquareGlyph{}
+	case TriangleGlyph:
+		return draw.TriangleGlyph{}
+	case CrossGlyph:
+		return draw.CrossGlyph{}
+	case PlusGlyph:
+		return draw.PlusGlyph{}
+	}
+	return nil
+}
+
+// ColorMaps are the color maps available for Contour plots
+type ColorMaps int32
+
+//go:generate stringer -type=ColorMaps
+
+var KiT_ColorMaps = kit.Enums.AddEnum(ColorMapsN, kit.NotBitFlag, nil)
+
+func (ev ColorMaps) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *ColorMaps) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+const (
+	// BlueRed is a smooth diverging map from blue through white to red
+	BlueRed ColorMaps = iota
+
+	// BlackBody goes from black through red and yellow to white
+	BlackBody
+
+	// Kindlmann goes from black through blue, green and yellow to white
+	Kindlmann
+
+	// Heat goes from red through yellow to white
+	Heat
+
+	// Rainbow goes through the hues from red through green to blue
+	Rainbow
+
+	ColorMapsN
+)
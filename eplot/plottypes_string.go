@@ -15,12 +15,13 @@ func _() {
 	var x [1]struct{}
 	_ = x[XY-0]
 	_ = x[Bar-1]
-	_ = x[PlotTypesN-2]
+	_ = x[Contour-2]
+	_ = x[PlotTypesN-3]
 }
 
-const _PlotTypes_name = "XYBarPlotTypesN"
+const _PlotTypes_name = "XYBarContourPlotTypesN"
 
-var _PlotTypes_index = [...]uint8{0, 2, 5, 15}
+var _PlotTypes_index = [...]uint8{0, 2, 5, 12, 22}
 
 func (i PlotTypes) String() string {
 	if i < 0 || i >= PlotTypes(len(_PlotTypes_index)-1) {
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"log"
 	"math"
+	"sort"
 
 	"github.com/emer/etable/etable"
 	"github.com/emer/etable/etensor"
@@ -26,7 +27,10 @@ type TableXY struct {
 	XIdx, YIdx     int             `desc:"the indexes of the element within each tensor cell if cells are n-dimensional, respectively"`
 	LblCol         int             `desc:"the column to use for returning a label using Label interface -- for string cols"`
 	ErrCol         int             `desc:"the column to use for returning errorbars (+/- given value) -- if YCol is tensor then this must also be a tensor and given YIdx used"`
+	SizeCol        int             `desc:"the column to use for returning point sizes using SizeValue -- if YCol is tensor then this must also be a tensor and given YIdx used"`
 	XRange         minmax.Range64
+	Gaps           []int           `desc:"indexes into the view where rows with Null or NaN values were removed -- lines are broken at these points"`
+	YOff           map[int]float64 `desc:"if non-nil, offsets added to the Y values, keyed by true table row, for stacked plots"`
 }
 
 // NewTableXY returns a new XY plot view onto the given IdxView of etable.Table (makes a copy),
@@ -40,14 +44,18 @@ func NewTableXY(dt *etable.IdxView, xcol, xtsrIdx, ycol, ytsrIdx int) (*TableXY,
 // NewTableXYName returns a new XY plot view onto the given IdxView of etable.Table (makes a copy),
 // from given column name and tensor indexes within each cell.
 // Column indexes are enforced to be valid, with an error message if they are not.
-func NewTableXYName(dt *etable.IdxView, xi, xtsrIdx int, ycol string, ytsrIdx int) (*TableXY, error) {
+// If maxPts > 0, the view is downsampled to at most that many rows using given
+// mode (see Downsample), after removing rows with Null or NaN values.
+func NewTableXYName(dt *etable.IdxView, xi, xtsrIdx int, ycol string, ytsrIdx int, maxPts int, mode DownsampleModes) (*TableXY, error) {
 	yi, err := dt.Table.ColIdxTry(ycol)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 	txy := &TableXY{Table: dt.Clone(), XCol: xi, YCol: yi, XIdx: xtsrIdx, YIdx: ytsrIdx}
-	return txy, txy.Validate()
+	err = txy.Validate()
+	txy.Downsample(maxPts, mode)
+	return txy, err
 }
 
 // Validate returns error message if column indexes are invalid, else nil
@@ -82,16 +90,194 @@ func (txy *TableXY) Validate() error {
 	return nil
 }
 
-// FilterVals removes items with NaN values, and out of X range
+// FilterVals removes rows with Null or NaN X or Y values, recording
+// the locations of any interior rows removed in the Gaps
 func (txy *TableXY) FilterVals() {
-	txy.Table.Filter(func(et *etable.Table, row int) bool {
-		xv := txy.TRowXValue(row)
-		yv := txy.TRowValue(row)
-		if math.IsNaN(yv) || math.IsNaN(xv) {
-			return false
+	txy.Gaps = nil
+	txy.dropRows(txy.TRowIsNull)
+}
+
+// ClipY removes rows with Y values below clip.Min (if FixMin) or above
+// clip.Max (if FixMax), recording the locations of any interior rows removed
+// in the Gaps, so that lines are broken at these points instead of drawing
+// the outlying values.
+func (txy *TableXY) ClipY(clip minmax.Range64) {
+	if !clip.FixMin && !clip.FixMax {
+		return
+	}
+	txy.dropRows(func(row int) bool {
+		y := txy.TRowValue(row)
+		return (clip.FixMin && y < clip.Min) || (clip.FixMax && y > clip.Max)
+	})
+}
+
+// dropRows removes rows for which drop returns true (given the true table row),
+// adding the locations of any interior rows removed to the existing Gaps
+func (txy *TableXY) dropRows(drop func(row int) bool) {
+	gaps := txy.Gaps
+	txy.Gaps = nil
+	nidx := make([]int, 0, len(txy.Table.Idxs))
+	addGap := func() {
+		if len(nidx) > 0 && (len(txy.Gaps) == 0 || txy.Gaps[len(txy.Gaps)-1] != len(nidx)) {
+			txy.Gaps = append(txy.Gaps, len(nidx))
 		}
+	}
+	gi := 0
+	for i, row := range txy.Table.Idxs {
+		if gi < len(gaps) && gaps[gi] == i {
+			addGap()
+			gi++
+		}
+		if drop(row) {
+			addGap()
+			continue
+		}
+		nidx = append(nidx, row)
+	}
+	if ng := len(txy.Gaps); ng > 0 && txy.Gaps[ng-1] == len(nidx) {
+		txy.Gaps = txy.Gaps[:ng-1]
+	}
+	txy.Table.Idxs = nidx
+}
+
+// TRowIsNull returns true if either the X or Y value at given true table row
+// is flagged as Null or is NaN
+func (txy *TableXY) TRowIsNull(row int) bool {
+	if cellIsNull(txy.Table.Table.Cols[txy.XCol], row, txy.XIdx) || cellIsNull(txy.Table.Table.Cols[txy.YCol], row, txy.YIdx) {
 		return true
-	})
+	}
+	return math.IsNaN(txy.TRowXValue(row)) || math.IsNaN(txy.TRowValue(row))
+}
+
+// cellIsNull returns true if the value at given row and tensor index
+// within the cell is flagged as Null in given column
+func cellIsNull(col etensor.Tensor, row, idx int) bool {
+	if col.DataType() == etensor.STRING {
+		return false
+	}
+	if col.NumDims() > 1 {
+		_, sz := col.RowCellSize()
+		if idx < 0 || idx >= sz {
+			return false
+		}
+		return col.IsNull1D(row*sz + idx)
+	}
+	return col.IsNull1D(row)
+}
+
+// Segments returns the view split into separate contiguous segments at each
+// of the Gaps where Null / NaN rows were removed, so that lines can be broken
+// at those points.  Returns just the view itself if there are no Gaps.
+func (txy *TableXY) Segments() []*TableXY {
+	if len(txy.Gaps) == 0 {
+		return []*TableXY{txy}
+	}
+	segs := make([]*TableXY, 0, len(txy.Gaps)+1)
+	st := 0
+	for _, ed := range append(txy.Gaps, txy.Table.Len()) {
+		sxy := *txy
+		sxy.Table = &etable.IdxView{Table: txy.Table.Table, Idxs: txy.Table.Idxs[st:ed]}
+		sxy.Gaps = nil
+		segs = append(segs, &sxy)
+		st = ed
+	}
+	return segs
+}
+
+// Downsample reduces the number of rows in the view to at most maxPts,
+// using given mode, if there are more rows than that.
+// MinMax keeps the rows with the min and max Y value within each of
+// maxPts / 2 buckets of rows, preserving the envelope of the data, plus
+// the last row for an odd maxPts (fewer rows are kept only for buckets
+// where one row is both min and max), while Even keeps maxPts
+// evenly-spaced rows (also used for MinMax if maxPts < 2).
+// maxPts <= 0 = no limit.  NewTableXYName calls this.
+func (txy *TableXY) Downsample(maxPts int, mode DownsampleModes) {
+	if txy.Table == nil || maxPts <= 0 {
+		return
+	}
+	n := txy.Table.Len()
+	if n <= maxPts {
+		return
+	}
+	idxs := txy.Table.Idxs
+	nidx := make([]int, 0, maxPts)
+	npos := make([]int, 0, maxPts) // positions of kept rows in original view
+	switch {
+	case mode == MinMax && maxPts >= 2:
+		// each bucket spans rows in proportion to the points it keeps:
+		// 2 for each min / max bucket, and 1 for the last row if maxPts is odd
+		nb := maxPts / 2
+		for bi := 0; bi < nb; bi++ {
+			st := (2 * bi * n) / maxPts
+			ed := (2 * (bi + 1) * n) / maxPts
+			mni, mxi := st, st
+			mnv := txy.TRowValue(idxs[st])
+			mxv := mnv
+			for i := st + 1; i < ed; i++ {
+				v := txy.TRowValue(idxs[i])
+				if v < mnv {
+					mnv = v
+					mni = i
+				}
+				if v > mxv {
+					mxv = v
+					mxi = i
+				}
+			}
+			switch {
+			case mni == mxi:
+				npos = append(npos, mni)
+			case mni < mxi:
+				npos = append(npos, mni, mxi)
+			default:
+				npos = append(npos, mxi, mni)
+			}
+		}
+		if maxPts%2 == 1 {
+			npos = append(npos, n-1)
+		}
+	default:
+		for i := 0; i < maxPts; i++ {
+			npos = append(npos, (i*n)/maxPts)
+		}
+	}
+	for _, p := range npos {
+		nidx = append(nidx, idxs[p])
+	}
+	txy.Table.Idxs = nidx
+	if len(txy.Gaps) == 0 {
+		return
+	}
+	gaps := txy.Gaps
+	txy.Gaps = nil
+	for _, g := range gaps {
+		ng := sort.SearchInts(npos, g)
+		if ng == 0 || ng >= len(npos) {
+			continue
+		}
+		if len(txy.Gaps) == 0 || txy.Gaps[len(txy.Gaps)-1] != ng {
+			txy.Gaps = append(txy.Gaps, ng)
+		}
+	}
+}
+
+// Stack sets the YOff offsets of this series to the current totals in given
+// stack, keyed by true table row, and then adds the values of this series to
+// the stack, so the next series is drawn on top of this one.  Negative values
+// are treated as zero, as are the Null / NaN rows already removed from the view,
+// so the stacked line for those rows stays at the total of the previous series.
+func (txy *TableXY) Stack(stack map[int]float64) {
+	txy.YOff = nil
+	off := make(map[int]float64, txy.Len())
+	for _, row := range txy.Table.Idxs {
+		v := txy.TRowValue(row)
+		off[row] = stack[row]
+		if v > 0 {
+			stack[row] += v
+		}
+	}
+	txy.YOff = off
 }
 
 // Len returns the number of rows in the view of table
@@ -117,6 +303,9 @@ func (txy *TableXY) TRowValue(row int) float64 {
 	default:
 		y = yc.FloatVal1D(row)
 	}
+	if txy.YOff != nil {
+		y += txy.YOff[row]
+	}
 	return y
 }
 
@@ -139,6 +328,9 @@ func (txy *TableXY) Value(row int) float64 {
 	default:
 		y = yc.FloatVal1D(trow)
 	}
+	if txy.YOff != nil {
+		y += txy.YOff[trow]
+	}
 	return y
 }
 
@@ -225,3 +417,28 @@ func (txy *TableXY) YError(row int) (float64, float64) {
 	}
 	return -eval, eval
 }
+
+// SizeValue returns the value of the SizeCol column at given row in table view,
+// for setting the size of points, and NaN if the value is Null
+func (txy *TableXY) SizeValue(row int) float64 {
+	if txy.Table == nil || txy.Table.Table == nil {
+		return math.NaN()
+	}
+	trow := txy.Table.Idxs[row] // true table row
+	sc := txy.Table.Table.Cols[txy.SizeCol]
+	switch {
+	case sc.NumDims() > 1:
+		_, sz := sc.RowCellSize()
+		if txy.YIdx < sz && txy.YIdx >= 0 {
+			i := trow*sz + txy.YIdx
+			if sc.IsNull1D(i) {
+				return math.NaN()
+			}
+			return sc.FloatVal1D(i)
+		}
+		return math.NaN()
+	case sc.IsNull1D(trow):
+		return math.NaN()
+	}
+	return sc.FloatVal1D(trow)
+}
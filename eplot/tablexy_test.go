@@ -0,0 +1,112 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eplot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+func TestDownsample(t *testing.T) {
+	nr := 1000
+	dt := etable.New(etable.Schema{
+		{"X", etensor.FLOAT64, nil, nil},
+		{"Y", etensor.FLOAT64, nil, nil},
+	}, nr)
+	for i := 0; i < nr; i++ {
+		dt.SetCellFloat("X", i, float64(i))
+		dt.SetCellFloat("Y", i, math.Sin(float64(i)*.1))
+	}
+	dt.SetCellFloat("Y", 517, 10) // spike
+	ix := etable.NewIdxView(dt)
+	for _, mode := range []DownsampleModes{MinMax, Even} {
+		for _, mx := range []int{0, 1, 2, 99, 100, 2000} {
+			xy, err := NewTableXYName(ix, 0, 0, "Y", 0, mx, mode)
+			if err != nil {
+				t.Error(err)
+			}
+			n := xy.Len()
+			if mx <= 0 || mx >= nr {
+				if n != nr {
+					t.Errorf("Downsample %v %v: len %v != %v\n", mode, mx, n, nr)
+				}
+				continue
+			}
+			if n != mx { // no MinMax bucket of this data has one row as both min and max
+				t.Errorf("Downsample %v %v: len %v, expected %v\n", mode, mx, n, mx)
+			}
+			lx := -1.0
+			for i := 0; i < n; i++ {
+				x, _ := xy.XY(i)
+				if x <= lx {
+					t.Errorf("Downsample %v %v: x not increasing: %v <= %v\n", mode, mx, x, lx)
+				}
+				lx = x
+			}
+			if x, _ := xy.XY(n - 1); mode == MinMax && mx%2 == 1 && mx >= 2 && x != float64(nr-1) {
+				t.Errorf("Downsample MinMax %v: last x %v, expected last row for odd max\n", mx, x)
+			}
+			if mode == MinMax && mx >= 2 {
+				has := false
+				for i := 0; i < n; i++ {
+					if xy.Value(i) == 10 {
+						has = true
+					}
+				}
+				if !has {
+					t.Errorf("Downsample MinMax %v: spike not preserved\n", mx)
+				}
+			}
+		}
+	}
+}
+
+func TestNullRows(t *testing.T) {
+	nr := 10
+	dt := etable.New(etable.Schema{
+		{"X", etensor.FLOAT64, nil, nil},
+		{"Y", etensor.FLOAT64, nil, nil},
+	}, nr)
+	for i := 0; i < nr; i++ {
+		dt.SetCellFloat("X", i, float64(i))
+		dt.SetCellFloat("Y", i, float64(i))
+	}
+	ix := etable.NewIdxView(dt)
+	xy, err := NewTableXYName(ix, 0, 0, "Y", 0, 0, MinMax)
+	if err != nil {
+		t.Error(err)
+	}
+	if xy.Len() != nr || len(xy.Gaps) != 0 {
+		t.Errorf("NullRows: len %v gaps %v, expected %v and none\n", xy.Len(), xy.Gaps, nr)
+	}
+	dt.ColByName("Y").SetNull1D(4, true)
+	xy, err = NewTableXYName(ix, 0, 0, "Y", 0, 0, MinMax)
+	if err != nil {
+		t.Error(err)
+	}
+	if xy.Len() != nr-1 {
+		t.Errorf("NullRows: len %v != %v\n", xy.Len(), nr-1)
+	}
+	for i := 0; i < xy.Len(); i++ {
+		if x, y := xy.XY(i); x == 4 || y == 0 && i != 0 {
+			t.Errorf("NullRows: null row included at %v: %v, %v\n", i, x, y)
+		}
+	}
+	if len(xy.Gaps) != 1 || xy.Gaps[0] != 4 {
+		t.Errorf("NullRows: gaps %v, expected [4]\n", xy.Gaps)
+	}
+	segs := xy.Segments()
+	if len(segs) != 2 || segs[0].Len() != 4 || segs[1].Len() != 5 {
+		t.Errorf("NullRows: bad segments: %v\n", len(segs))
+	}
+	dt.SetCellFloat("X", 0, math.NaN()) // leading rows are not gaps
+	xy, _ = NewTableXYName(ix, 0, 0, "Y", 0, 0, MinMax)
+	if xy.Len() != nr-2 || len(xy.Gaps) != 1 || xy.Gaps[0] != 3 {
+		t.Errorf("NullRows: NaN X: len %v gaps %v\n", xy.Len(), xy.Gaps)
+	}
+}
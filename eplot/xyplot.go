@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"sort"
 
 	"github.com/emer/etable/etable"
 	"github.com/emer/etable/etensor"
+	"github.com/emer/etable/minmax"
 	"github.com/emer/etable/split"
 	"github.com/goki/gi/gi"
 	"github.com/goki/ki/ints"
@@ -37,6 +39,7 @@ func (pl *Plot2D) GenPlotXY() {
 	plt.Y.Tick.Color = gi.Prefs.Colors.Font
 
 	plt.BackgroundColor = nil
+	pl.series = nil
 
 	// process xaxis first
 	xi, xview, xbreaks, err := pl.PlotXAxis(plt, pl.Table)
@@ -86,11 +89,22 @@ func (pl *Plot2D) GenPlotXY() {
 	}
 
 	if nys == 0 {
-		return
+		if len(strCols) == 0 {
+			pl.PlotRefLines(plt) // ref lines are drawn regardless of columns
+			pl.GPlot = plt
+			return
+		}
+		pl.genNominalY(plt, xview, xi, xp, strCols)
+	}
+
+	var stack map[int]float64
+	if pl.Params.Stacked {
+		stack = make(map[int]float64)
 	}
 
 	firstXY = nil
 	yidx := 0
+	autoi := 0
 	for _, cp := range pl.Cols {
 		if !cp.On || cp == xp {
 			continue
@@ -98,6 +112,28 @@ func (pl *Plot2D) GenPlotXY() {
 		if cp.IsString {
 			continue
 		}
+		cpClr := pl.AutoColor(cp, &autoi)
+		sxi := xi // own X column for this series
+		if cp.XCol != "" {
+			sxi, err = pl.Table.Table.ColIdxTry(cp.XCol)
+			if err != nil {
+				log.Println("eplot.XCol: " + err.Error())
+				sxi = xi
+			}
+		}
+		sxp := pl.Cols[sxi]
+		sci := -1 // point size column
+		var szRange minmax.F64
+		if cp.SizeCol != "" && pl.Params.Points {
+			sci, err = pl.Table.Table.ColIdxTry(cp.SizeCol)
+			if err != nil {
+				log.Println("eplot.SizeCol: " + err.Error())
+				sci = -1
+			} else {
+				szRange = colRange(pl.Table, sci, cp.TensorIdx)
+			}
+		}
+		empty := true // no valid values to plot in this column
 		for li := 0; li < nleg; li++ {
 			lview := xview
 			leg := ""
@@ -106,8 +142,17 @@ func (pl *Plot2D) GenPlotXY() {
 				lview = lsplit.Splits[li]
 				_, _, xbreaks, _ = pl.PlotXAxis(plt, lview)
 			}
+			sxbreaks := xbreaks
+			if sxi != xi {
+				sxbreaks = nil
+				if !pl.Params.NegXDraw || sxp.Breaks {
+					sxbreaks = colBreaks(lview, pl.Table.Table.Cols[sxi], sxp.TensorIdx)
+				}
+				sxbreaks = append(sxbreaks, lview.Len())
+			}
+			lgd := make(map[int]bool) // legend added for each tensor idx
 			stRow := 0
-			for bi, edRow := range xbreaks {
+			for _, edRow := range sxbreaks {
 				nidx := 1
 				stidx := cp.TensorIdx
 				if cp.TensorIdx < 0 { // do all
@@ -120,8 +165,15 @@ func (pl *Plot2D) GenPlotXY() {
 					idx := stidx + ii
 					tix := lview.Clone()
 					tix.Idxs = tix.Idxs[stRow:edRow]
-					xy, _ := NewTableXYName(tix, xi, xp.TensorIdx, cp.Col, idx)
-					if xy == nil {
+					xy, _ := NewTableXYName(tix, sxi, sxp.TensorIdx, cp.Col, idx, pl.Params.MaxPoints, pl.Params.Downsample)
+					if xy == nil || xy.Len() == 0 { // all Null / NaN
+						continue
+					}
+					empty = false
+					if stack != nil {
+						pl.stackXY(xy, stack, tix, cp.Col)
+					}
+					if xy.ClipY(pl.Params.YClip); xy.Len() == 0 { // all clipped
 						continue
 					}
 					if firstXY == nil {
@@ -130,7 +182,7 @@ func (pl *Plot2D) GenPlotXY() {
 					var pts *plotter.Scatter
 					var lns *plotter.Line
 					lbl := cp.Label()
-					clr := cp.Color
+					clr := cpClr
 					if nleg > 1 {
 						cidx := yidx*nleg + li
 						clr, _ = gi.ColorFromString(PlotColorNames[cidx%len(PlotColorNames)], nil)
@@ -144,27 +196,49 @@ func (pl *Plot2D) GenPlotXY() {
 						clr, _ = gi.ColorFromString(PlotColorNames[idx%len(PlotColorNames)], nil)
 						lbl = fmt.Sprintf("%s_%02d", lbl, idx)
 					}
-					if pl.Params.Lines && pl.Params.Points {
-						lns, pts, _ = plotter.NewLinePoints(xy)
-					} else if pl.Params.Points {
-						pts, _ = plotter.NewScatter(xy)
-					} else {
-						lns, _ = plotter.NewLine(xy)
-					}
-					if lns != nil {
-						lns.LineStyle.Width = vg.Points(pl.Params.LineWidth)
-						lns.LineStyle.Color = clr
-						plt.Add(lns)
-						if bi == 0 {
-							plt.Legend.Add(lbl, lns)
+					sxys, _ := plotter.CopyXYs(xy)
+					pl.series = append(pl.series, plotSeries{Label: lbl, XYs: sxys})
+					if pl.Params.Lines || !pl.Params.Points || cp.Band > 0 {
+						for si, sxy := range xy.Segments() { // separate lines across Null / NaN gaps
+							var lxy plotter.XYer = sxy
+							if cp.Band > 0 {
+								lxy = addBand(plt, sxy, cp.Band, clr)
+							}
+							sl, _ := plotter.NewLine(lxy)
+							if sl == nil {
+								continue
+							}
+							sl.LineStyle.Width = vg.Points(pl.Params.LineWidth)
+							sl.LineStyle.Color = clr
+							if len(cp.Dashes) > 0 {
+								sl.LineStyle.Dashes = cp.Dashes
+							}
+							plt.Add(sl)
+							if si == 0 {
+								lns = sl
+								if !lgd[idx] {
+									plt.Legend.Add(lbl, lns)
+									lgd[idx] = true
+								}
+							}
 						}
 					}
+					if pl.Params.Points {
+						pts, _ = plotter.NewScatter(xy)
+					}
 					if pts != nil {
 						pts.GlyphStyle.Color = clr
 						pts.GlyphStyle.Radius = vg.Points(pl.Params.PointSize)
+						if gd := cp.GlyphShape.Drawer(); gd != nil {
+							pts.GlyphStyle.Shape = gd
+						}
+						if sci >= 0 {
+							setPointSizes(pts, xy, sci, cp.SizeRange, szRange)
+						}
 						plt.Add(pts)
-						if lns == nil && bi == 0 {
+						if lns == nil && !lgd[idx] {
 							plt.Legend.Add(lbl, pts)
+							lgd[idx] = true
 						}
 					}
 					if cp.ErrCol != "" {
@@ -180,11 +254,14 @@ func (pl *Plot2D) GenPlotXY() {
 				stRow = edRow
 			}
 		}
+		if empty {
+			log.Printf("eplot.GenPlotXY: column %v has no valid (non-Null, non-NaN) values -- not plotted\n", cp.Col)
+		}
 		yidx++
 	}
 	if firstXY != nil && len(strCols) > 0 {
 		for _, cp := range strCols {
-			xy, _ := NewTableXYName(xview, xi, xp.TensorIdx, cp.Col, cp.TensorIdx)
+			xy, _ := NewTableXYName(xview, xi, xp.TensorIdx, cp.Col, cp.TensorIdx, pl.Params.MaxPoints, pl.Params.Downsample)
 			xy.LblCol = xy.YCol
 			xy.YCol = firstXY.YCol
 			xy.YIdx = firstXY.YIdx
@@ -197,6 +274,7 @@ func (pl *Plot2D) GenPlotXY() {
 
 	// Use string labels for X axis if X is a string
 	xc := pl.Table.Table.Cols[xi]
+	pl.PlotTickFormats(plt, xc.DataType() == etensor.STRING, nys == 0)
 	if xc.DataType() == etensor.STRING {
 		xcs := xc.(*etensor.String)
 		vals := make([]string, pl.Table.Len())
@@ -212,5 +290,145 @@ func (pl *Plot2D) GenPlotXY() {
 		plt.X.Tick.Label.YAlign = draw.YCenter
 		plt.X.Tick.Label.XAlign = draw.XRight
 	}
+	pl.PlotRefLines(plt)
 	pl.GPlot = plt
 }
+
+// stackXY stacks the values of given series on the stack (see TableXY.Stack),
+// summing over all the rows of given view, not just those kept when the
+// series was downsampled to MaxPoints.
+func (pl *Plot2D) stackXY(xy *TableXY, stack map[int]float64, view *etable.IdxView, ycol string) {
+	if pl.Params.MaxPoints <= 0 || view.Len() <= pl.Params.MaxPoints { // not downsampled
+		xy.Stack(stack)
+		return
+	}
+	fxy, _ := NewTableXYName(view, xy.XCol, xy.XIdx, ycol, xy.YIdx, 0, pl.Params.Downsample)
+	fxy.Stack(stack)
+	xy.YOff = fxy.YOff
+}
+
+// colRange returns the min and max of the non-Null, non-NaN values of
+// given column in the view, using given tensor index for n-dimensional cells
+func colRange(ix *etable.IdxView, ci, idx int) minmax.F64 {
+	var rng minmax.F64
+	rng.SetInfinity()
+	cl := ix.Table.Cols[ci]
+	_, sz := cl.RowCellSize()
+	if idx < 0 || idx >= sz {
+		idx = 0
+	}
+	for _, row := range ix.Idxs {
+		i := row*sz + idx
+		if v := cl.FloatVal1D(i); !cl.IsNull1D(i) && !math.IsNaN(v) {
+			rng.FitValInRange(v)
+		}
+	}
+	return rng
+}
+
+// setPointSizes sets the radius of each point in the scatter according to
+// the values of the size column sci, mapping szRange onto the radius range rad.
+// Points with Null or NaN size values get the minimum radius, and all points
+// get the midpoint radius if all the sizes are the same.
+func setPointSizes(pts *plotter.Scatter, xy *TableXY, sci int, rad, szRange minmax.F64) {
+	xy.SizeCol = sci
+	rads := make([]vg.Length, xy.Len())
+	for i := range rads {
+		v := xy.SizeValue(i)
+		nv := 0.0
+		switch {
+		case math.IsNaN(v):
+		case szRange.Range() <= 0:
+			nv = 0.5
+		default:
+			nv = szRange.ClipNormVal(v)
+		}
+		rads[i] = vg.Points(rad.ProjVal(nv))
+	}
+	pts.GlyphStyleFunc = func(i int) draw.GlyphStyle {
+		gs := pts.GlyphStyle
+		gs.Radius = rads[i]
+		return gs
+	}
+}
+
+// genNominalY plots string Y columns against the X axis, when there are no
+// numeric Y columns, using a nominal Y axis with the sorted distinct string
+// values of the columns, and mapping each row to the position of its value.
+// Empty strings are treated as missing values.
+func (pl *Plot2D) genNominalY(plt *plot.Plot, xview *etable.IdxView, xi int, xp *ColParams, strCols []*ColParams) {
+	pos := make(map[string]int)
+	var vals []string
+	cellStr := func(yc etensor.Tensor, row, idx int) string {
+		if yc.NumDims() > 1 {
+			return yc.StringValRowCell(row, ints.MaxInt(idx, 0))
+		}
+		return yc.StringVal1D(row)
+	}
+	for _, cp := range strCols {
+		if cp == xp {
+			continue
+		}
+		yc := pl.Table.Table.ColByName(cp.Col)
+		for _, row := range xview.Idxs {
+			sv := cellStr(yc, row, cp.TensorIdx)
+			if _, has := pos[sv]; !has && sv != "" {
+				pos[sv] = 0
+				vals = append(vals, sv)
+			}
+		}
+	}
+	if len(vals) == 0 {
+		return
+	}
+	sort.Strings(vals)
+	for i, sv := range vals {
+		pos[sv] = i
+	}
+	for _, cp := range strCols {
+		if cp == xp {
+			continue
+		}
+		xy, _ := NewTableXYName(xview, xi, xp.TensorIdx, cp.Col, cp.TensorIdx, pl.Params.MaxPoints, pl.Params.Downsample)
+		if xy == nil {
+			continue
+		}
+		yc := pl.Table.Table.ColByName(cp.Col)
+		xyv := make(plotter.XYs, 0, xy.Len())
+		for i, row := range xy.Table.Idxs {
+			sv := cellStr(yc, row, cp.TensorIdx)
+			if sv == "" {
+				continue
+			}
+			xyv = append(xyv, plotter.XY{X: xy.XValue(i), Y: float64(pos[sv])})
+		}
+		var lns *plotter.Line
+		if pl.Params.Lines || !pl.Params.Points {
+			lns, _ = plotter.NewLine(xyv)
+			if lns != nil {
+				lns.LineStyle.Width = vg.Points(pl.Params.LineWidth)
+				lns.LineStyle.Color = cp.Color
+				if len(cp.Dashes) > 0 {
+					lns.LineStyle.Dashes = cp.Dashes
+				}
+				plt.Add(lns)
+				plt.Legend.Add(cp.Label(), lns)
+			}
+		}
+		if pl.Params.Points {
+			pts, _ := plotter.NewScatter(xyv)
+			if pts != nil {
+				pts.GlyphStyle.Color = cp.Color
+				pts.GlyphStyle.Radius = vg.Points(pl.Params.PointSize)
+				if gd := cp.GlyphShape.Drawer(); gd != nil {
+					pts.GlyphStyle.Shape = gd
+				}
+				plt.Add(pts)
+				if lns == nil {
+					plt.Legend.Add(cp.Label(), pts)
+				}
+			}
+		}
+	}
+	plt.NominalY(vals...)
+}
@@ -0,0 +1,748 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eplot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+// testPlot returns a Plot2D on given table, set up for generating
+// plots without any gui, with given X axis column
+func testPlot(dt *etable.Table, xcol string) *Plot2D {
+	pl := &Plot2D{}
+	pl.Defaults()
+	pl.Params.XAxisCol = xcol
+	pl.Table = etable.NewIdxView(dt)
+	pl.ColsListUpdate()
+	return pl
+}
+
+func testXYTable(nr int) *etable.Table {
+	dt := etable.New(etable.Schema{
+		{"X", etensor.FLOAT64, nil, nil},
+		{"Y", etensor.FLOAT64, nil, nil},
+		{"Z", etensor.FLOAT64, nil, nil},
+	}, nr)
+	for i := 0; i < nr; i++ {
+		dt.SetCellFloat("X", i, float64(i))
+		dt.SetCellFloat("Y", i, float64(i*i))
+		dt.SetCellFloat("Z", i, float64(nr-i))
+	}
+	return dt
+}
+
+// nDashed draws the current plot and returns the number of
+// times a non-empty line dash pattern was set
+func nDashed(pl *Plot2D) int {
+	rc := &recorder.Canvas{}
+	pl.GPlot.Draw(draw.Canvas{Canvas: rc, Rectangle: vg.Rectangle{Max: vg.Point{X: 400, Y: 300}}})
+	n := 0
+	for _, a := range rc.Actions {
+		if ld, ok := a.(*recorder.SetLineDash); ok && len(ld.Dashes) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDashes(t *testing.T) {
+	pl := testPlot(testXYTable(10), "X")
+	pl.ColParams("Y").On = true
+	pl.ColParams("Z").On = true
+	pl.GenPlotXY()
+	if pl.GPlot == nil {
+		t.Fatal("GenPlotXY: no plot generated")
+	}
+	if n := nDashed(pl); n != 0 {
+		t.Errorf("Dashes: got %v dashed lines without Dashes\n", n)
+	}
+	pl.ColParams("Z").Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+	pl.GenPlotXY()
+	if n := nDashed(pl); n == 0 {
+		t.Errorf("Dashes: no dashed lines drawn with Dashes set\n")
+	}
+}
+
+// drawnPath is a path stroked or filled when drawing a plot
+type drawnPath struct {
+	Fill  bool
+	Color color.Color
+	Path  vg.Path
+	XYs   plotter.XYs // the Move and Line points of the path, in data coordinates
+	Radii []vg.Length // the radius of each arc in the path, e.g., for a ring glyph
+}
+
+// drawnPaths draws the current plot without its legend (whose entries
+// repeat the series colors) and returns the paths drawn, in order
+func drawnPaths(pl *Plot2D) []drawnPath {
+	plt := *pl.GPlot
+	plt.Legend, _ = plot.NewLegend()
+	rc := &recorder.Canvas{}
+	c := draw.Canvas{Canvas: rc, Rectangle: vg.Rectangle{Max: vg.Point{X: 400, Y: 300}}}
+	plt.Draw(c)
+	dc := plt.DataCanvas(c)
+	toData := func(pt vg.Point) plotter.XY {
+		return plotter.XY{
+			X: plt.X.Min + float64((pt.X-dc.Min.X)/(dc.Max.X-dc.Min.X))*(plt.X.Max-plt.X.Min),
+			Y: plt.Y.Min + float64((pt.Y-dc.Min.Y)/(dc.Max.Y-dc.Min.Y))*(plt.Y.Max-plt.Y.Min),
+		}
+	}
+	var paths []drawnPath
+	var clr color.Color
+	var clrs []color.Color // Push / Pop stack
+	for _, a := range rc.Actions {
+		var path vg.Path
+		fill := false
+		switch ac := a.(type) {
+		case *recorder.SetColor:
+			clr = ac.Color
+			continue
+		case *recorder.Push:
+			clrs = append(clrs, clr)
+			continue
+		case *recorder.Pop:
+			if len(clrs) > 0 {
+				clr = clrs[len(clrs)-1]
+				clrs = clrs[:len(clrs)-1]
+			}
+			continue
+		case *recorder.Stroke:
+			path = ac.Path
+		case *recorder.Fill:
+			path = ac.Path
+			fill = true
+		default:
+			continue
+		}
+		dp := drawnPath{Fill: fill, Color: clr, Path: path}
+		for _, pc := range path {
+			switch pc.Type {
+			case vg.MoveComp, vg.LineComp:
+				dp.XYs = append(dp.XYs, toData(pc.Pos))
+			case vg.ArcComp:
+				dp.Radii = append(dp.Radii, pc.Radius)
+			}
+		}
+		paths = append(paths, dp)
+	}
+	return paths
+}
+
+// drawnLines returns the paths stroked in given color with more than one
+// point and no arcs, i.e., the lines drawn by plotter.Line
+func drawnLines(pl *Plot2D, clr color.Color) []drawnPath {
+	var lns []drawnPath
+	for _, dp := range drawnPaths(pl) {
+		if !dp.Fill && dp.Color == clr && len(dp.XYs) > 1 && len(dp.Radii) == 0 {
+			lns = append(lns, dp)
+		}
+	}
+	return lns
+}
+
+// nearData returns true if drawn data value v is equal to exp to within
+// the precision of drawing over a data range of given size
+func nearData(v, exp, rng float64) bool {
+	return math.Abs(v-exp) <= 1e-6*math.Max(rng, 1)
+}
+
+// nColorStrokes draws the current plot and returns the number of
+// lines stroked in given color
+func nColorStrokes(pl *Plot2D, clr color.Color) int {
+	rc := &recorder.Canvas{}
+	pl.GPlot.Draw(draw.Canvas{Canvas: rc, Rectangle: vg.Rectangle{Max: vg.Point{X: 400, Y: 300}}})
+	n := 0
+	inClr := false
+	for _, a := range rc.Actions {
+		switch ac := a.(type) {
+		case *recorder.SetColor:
+			inClr = ac.Color == clr
+		case *recorder.Stroke:
+			if inClr {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestRefLines(t *testing.T) {
+	refClr := color.RGBA{R: 1, G: 2, B: 3, A: 255} // distinct from anything else drawn
+	pl := testPlot(testXYTable(10), "X")
+	pl.ColParams("Y").On = true
+	pl.GenPlotXY()
+	if n := nColorStrokes(pl, refClr); n != 0 {
+		t.Errorf("RefLines: %v lines drawn with no RefLines\n", n)
+	}
+	pl.AddHLine(200, refClr) // beyond Y data range of 0..81
+	pl.GenPlotXY()
+	if nh := nColorStrokes(pl, refClr); nh != 1 {
+		t.Errorf("AddHLine: %v lines drawn, expected 1\n", nh)
+	}
+	if pl.GPlot.Y.Max < 200 {
+		t.Errorf("AddHLine: Y range %v..%v does not include line\n", pl.GPlot.Y.Min, pl.GPlot.Y.Max)
+	}
+	pl.AddVLine(20, refClr) // beyond X data range of 0..9
+	pl.GenPlotXY()
+	if nv := nColorStrokes(pl, refClr); nv != 2 {
+		t.Errorf("AddVLine: %v lines drawn, expected 2\n", nv)
+	}
+	if pl.GPlot.X.Max < 20 {
+		t.Errorf("AddVLine: X range %v..%v does not include line\n", pl.GPlot.X.Min, pl.GPlot.X.Max)
+	}
+	pl.ClearRefLines()
+	pl.GenPlotXY()
+	if nc := nColorStrokes(pl, refClr); nc != 0 {
+		t.Errorf("ClearRefLines: %v lines drawn, expected 0\n", nc)
+	}
+	if pl.GPlot.Y.Max >= 200 || pl.GPlot.X.Max >= 20 {
+		t.Errorf("ClearRefLines: range %v, %v still includes lines\n", pl.GPlot.X.Max, pl.GPlot.Y.Max)
+	}
+}
+
+func TestRefLinesNoCols(t *testing.T) {
+	refClr := color.RGBA{R: 1, G: 2, B: 3, A: 255}
+	pl := testPlot(testXYTable(10), "X")
+	for _, cp := range pl.Cols {
+		cp.On = false
+	}
+	pl.AddHLine(50, refClr)
+	pl.AddVLine(20, refClr)
+	for _, gen := range []func(){pl.GenPlotXY, pl.GenPlotBar} {
+		pl.GPlot = nil
+		gen()
+		if pl.GPlot == nil {
+			t.Fatalf("RefLines: no plot generated with all columns Off\n")
+		}
+		x, y := pl.GPlot.X, pl.GPlot.Y
+		if math.IsInf(x.Min, 0) || math.IsInf(x.Max, 0) || math.IsInf(y.Min, 0) || math.IsInf(y.Max, 0) {
+			t.Errorf("RefLines: infinite axis range X %v..%v Y %v..%v\n", x.Min, x.Max, y.Min, y.Max)
+		}
+		if y.Min > 50 || y.Max < 50 || x.Min > 20 || x.Max < 20 {
+			t.Errorf("RefLines: axis range X %v..%v Y %v..%v does not span lines\n", x.Min, x.Max, y.Min, y.Max)
+		}
+		if n := nColorStrokes(pl, refClr); n != 2 {
+			t.Errorf("RefLines: %v lines drawn with all columns Off, expected 2\n", n)
+		}
+	}
+}
+
+func TestNominalY(t *testing.T) {
+	dt := etable.New(etable.Schema{
+		{"X", etensor.FLOAT64, nil, nil},
+		{"Class", etensor.STRING, nil, nil},
+	}, 6)
+	for i, c := range []string{"dog", "cat", "cat", "", "bird", "dog"} {
+		dt.SetCellFloat("X", i, float64(i))
+		dt.SetCellString("Class", i, c)
+	}
+	pl := testPlot(dt, "X")
+	pl.ColParams("Class").On = true
+	pl.GenPlotXY()
+	if pl.GPlot == nil {
+		t.Fatal("GenPlotXY: no plot generated for string Y column")
+	}
+	ticks := pl.GPlot.Y.Tick.Marker.Ticks(pl.GPlot.Y.Min, pl.GPlot.Y.Max)
+	exp := []string{"bird", "cat", "dog"}
+	if len(ticks) != len(exp) {
+		t.Fatalf("NominalY: ticks %v != %v\n", ticks, exp)
+	}
+	for i, tk := range ticks {
+		if tk.Label != exp[i] || tk.Value != float64(i) {
+			t.Errorf("NominalY: tick %v: %v at %v, expected %v at %v\n", i, tk.Label, tk.Value, exp[i], i)
+		}
+	}
+	if n := len(drawnLines(pl, pl.ColParams("Class").Color)); n == 0 {
+		t.Errorf("NominalY: no series plotted\n")
+	}
+}
+
+func TestGlyphShape(t *testing.T) {
+	pl := testPlot(testXYTable(10), "X")
+	pl.Params.Points = true
+	pl.ColParams("Y").On = true
+	pl.ColParams("Z").On = true
+	pl.ColParams("Z").GlyphShape = CrossGlyph
+	pl.GenPlotXY()
+	// the standard glyph is a ring (an arc), and a cross is two 2-point strokes
+	nring := make(map[string]int)
+	nstroke2 := make(map[string]int)
+	for _, dp := range drawnPaths(pl) {
+		for _, cn := range []string{"Y", "Z"} {
+			if dp.Fill || dp.Color != pl.ColParams(cn).Color {
+				continue
+			}
+			if len(dp.Radii) > 0 {
+				nring[cn]++
+			} else if len(dp.XYs) == 2 {
+				nstroke2[cn]++
+			}
+		}
+	}
+	if nring["Y"] != 10 || nstroke2["Y"] != 0 {
+		t.Errorf("GlyphShape: default glyph changed: %v rings, %v crossing strokes\n", nring["Y"], nstroke2["Y"])
+	}
+	if nring["Z"] != 0 || nstroke2["Z"] != 20 {
+		t.Errorf("GlyphShape: not CrossGlyph: %v rings, %v crossing strokes\n", nring["Z"], nstroke2["Z"])
+	}
+}
+
+func TestColBreaks(t *testing.T) {
+	dt := etable.New(etable.Schema{
+		{"Time", etensor.FLOAT64, nil, nil},
+		{"Epoch", etensor.FLOAT64, nil, nil},
+		{"Err", etensor.FLOAT64, nil, nil},
+	}, 6)
+	for i := 0; i < 6; i++ {
+		dt.SetCellFloat("Time", i, float64(i))
+		dt.SetCellFloat("Epoch", i, float64(i%3))
+		dt.SetCellFloat("Err", i, 1/float64(i+1))
+	}
+	pl := testPlot(dt, "Time")
+	pl.ColParams("Err").On = true
+	plt, _ := plot.New()
+	_, _, xbreaks, err := pl.PlotXAxis(plt, pl.Table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(xbreaks) != 1 || xbreaks[0] != 6 {
+		t.Errorf("PlotXAxis: breaks %v without Breaks col, expected [6]\n", xbreaks)
+	}
+	pl.ColParams("Epoch").Breaks = true
+	_, _, xbreaks, _ = pl.PlotXAxis(plt, pl.Table)
+	if len(xbreaks) != 2 || xbreaks[0] != 3 || xbreaks[1] != 6 {
+		t.Errorf("PlotXAxis: breaks %v with Epoch Breaks, expected [3 6]\n", xbreaks)
+	}
+	pl.GenPlotXY()
+	if nln := len(drawnLines(pl, pl.ColParams("Err").Color)); nln != 2 {
+		t.Errorf("Breaks: %v line segments, expected 2\n", nln)
+	}
+}
+
+func TestTickFormat(t *testing.T) {
+	dt := testXYTable(10)
+	for i := 0; i < 10; i++ {
+		dt.SetCellFloat("X", i, float64(i)*1e6)
+	}
+	pl := testPlot(dt, "X")
+	pl.ColParams("Y").On = true
+	pl.GenPlotXY()
+	if _, ok := pl.GPlot.X.Tick.Marker.(TickFormatter); ok {
+		t.Errorf("TickFormat: formatter installed with no XTickFormat\n")
+	}
+	pl.Params.XTickFormat = "%.1e"
+	pl.Params.YTickFormat = "%.2f"
+	pl.GenPlotXY()
+	if _, ok := pl.GPlot.X.Tick.Marker.(TickFormatter); !ok {
+		t.Fatalf("TickFormat: X axis Marker is %T, not TickFormatter\n", pl.GPlot.X.Tick.Marker)
+	}
+	nlbl := 0
+	for _, tk := range pl.GPlot.X.Tick.Marker.Ticks(pl.GPlot.X.Min, pl.GPlot.X.Max) {
+		if tk.Label == "" {
+			continue
+		}
+		nlbl++
+		if exp := fmt.Sprintf("%.1e", tk.Value); tk.Label != exp {
+			t.Errorf("TickFormat: X label %q, expected %q\n", tk.Label, exp)
+		}
+	}
+	if nlbl == 0 {
+		t.Errorf("TickFormat: no labeled X ticks\n")
+	}
+	for _, tk := range pl.GPlot.Y.Tick.Marker.Ticks(pl.GPlot.Y.Min, pl.GPlot.Y.Max) {
+		if exp := fmt.Sprintf("%.2f", tk.Value); tk.Label != "" && tk.Label != exp {
+			t.Errorf("TickFormat: Y label %q, expected %q\n", tk.Label, exp)
+		}
+	}
+}
+
+func TestStacked(t *testing.T) {
+	dt := testXYTable(2)
+	for i, v := range []float64{1, 2} {
+		dt.SetCellFloat("Y", i, v)
+		dt.SetCellFloat("Z", i, v+2)
+	}
+	pl := testPlot(dt, "X")
+	pl.ColParams("Y").On = true
+	pl.ColParams("Z").On = true
+	pl.Params.Stacked = true
+	pl.GenPlotXY()
+	exp := [][]float64{{1, 2}, {4, 6}}
+	for li, cn := range []string{"Y", "Z"} {
+		lns := drawnLines(pl, pl.ColParams(cn).Color)
+		if len(lns) != 1 || len(lns[0].XYs) != 2 {
+			t.Fatalf("Stacked: %v lines for %v, expected 1 with 2 points\n", len(lns), cn)
+		}
+		for i, xy := range lns[0].XYs {
+			if !nearData(xy.Y, exp[li][i], 6) {
+				t.Errorf("Stacked: line %v point %v: %v, expected %v\n", li, i, xy.Y, exp[li][i])
+			}
+		}
+	}
+
+	dt.SetCellFloat("Y", 0, -1)
+	dt.ColByName("Y").SetNull1D(1, true)
+	pl.GenPlotXY()
+	lns := drawnLines(pl, pl.ColParams("Z").Color)
+	if len(lns) != 1 || len(lns[0].XYs) != 2 {
+		t.Fatalf("Stacked: %v Z lines, expected 1 with 2 points\n", len(lns))
+	}
+	if xys := lns[0].XYs; !nearData(xys[0].Y, 3, 4) || !nearData(xys[1].Y, 4, 4) {
+		t.Errorf("Stacked: negative / Null not treated as zero: %v\n", xys)
+	}
+}
+
+func TestMaxPoints(t *testing.T) {
+	nr := 1000
+	pl := testPlot(testXYTable(nr), "X")
+	pl.ColParams("Y").On = true
+	pl.ColParams("Z").On = true
+	pl.Params.Stacked = true
+	pl.Params.MaxPoints = 51
+	pl.GenPlotXY()
+	if len(pl.series) != 2 {
+		t.Fatalf("MaxPoints: %v series, expected 2\n", len(pl.series))
+	}
+	for _, sr := range pl.series {
+		if len(sr.XYs) != 51 {
+			t.Errorf("MaxPoints: series %v has %v points, expected 51\n", sr.Label, len(sr.XYs))
+		}
+	}
+	for _, xy := range pl.series[1].XYs { // Z stacked on Y for the rows kept
+		if exp := xy.X*xy.X + float64(nr) - xy.X; xy.Y != exp {
+			t.Errorf("MaxPoints: stacked value at %v: %v, expected %v\n", xy.X, xy.Y, exp)
+		}
+	}
+	pl.GenPlotBar()
+	nbar := make(map[string]int)
+	for _, dp := range drawnPaths(pl) {
+		for _, cn := range []string{"Y", "Z"} {
+			if dp.Fill && dp.Color == pl.ColParams(cn).Color {
+				nbar[cn]++
+			}
+		}
+	}
+	if nbar["Y"] != 51 || nbar["Z"] != 51 {
+		t.Errorf("MaxPoints: %v Y and %v Z bars, expected 51\n", nbar["Y"], nbar["Z"])
+	}
+}
+
+func TestAutoColors(t *testing.T) {
+	dt := testXYTable(5)
+	dt.AddCol(etensor.NewFloat64([]int{5}, nil, nil), "W")
+	pl := testPlot(dt, "X")
+	for _, cn := range []string{"Y", "Z", "W"} {
+		pl.ColParams(cn).On = true
+	}
+	pl.Params.AutoColors = true
+	pl.Params.Palette = []string{"red", "green", "blue"}
+	pl.GenPlotXY()
+	lineColors := func() []color.Color { // colors of the 5-point lines, in plotting order
+		var clrs []color.Color
+		for _, dp := range drawnPaths(pl) {
+			if !dp.Fill && len(dp.XYs) == 5 && len(dp.Radii) == 0 {
+				clrs = append(clrs, dp.Color)
+			}
+		}
+		return clrs
+	}
+	clrs := lineColors()
+	if len(clrs) != 3 {
+		t.Fatalf("AutoColors: %v lines, expected 3\n", len(clrs))
+	}
+	for i, pn := range pl.Params.Palette {
+		exp, _ := gi.ColorFromString(pn, nil)
+		if clrs[i] != exp {
+			t.Errorf("AutoColors: line %v color %v, expected %v\n", i, clrs[i], pn)
+		}
+	}
+	if clrs[0] == clrs[1] || clrs[1] == clrs[2] || clrs[0] == clrs[2] {
+		t.Errorf("AutoColors: colors not distinct: %v\n", clrs)
+	}
+	pl.ColParams("Z").ColorName = "orange" // explicit color is kept
+	pl.ColParams("Z").UpdateVals()
+	pl.GenPlotXY()
+	clrs = lineColors()
+	orange, _ := gi.ColorFromString("orange", nil)
+	green, _ := gi.ColorFromString("green", nil)
+	if clrs[1] != orange || clrs[2] != green {
+		t.Errorf("AutoColors: explicit color not kept: %v\n", clrs)
+	}
+}
+
+func TestPlotData(t *testing.T) {
+	dt := testXYTable(4)
+	dt.ColByName("Z").SetNull1D(2, true)
+	pl := testPlot(dt, "X")
+	pl.ColParams("Y").On = true
+	pl.ColParams("Z").On = true
+	pl.GenPlotXY()
+	pd := pl.PlotData()
+	if pd.Rows != 7 {
+		t.Fatalf("PlotData: %v rows, expected 7\n", pd.Rows)
+	}
+	exp := []struct {
+		ser  string
+		x, y float64
+	}{
+		{"Y", 0, 0}, {"Y", 1, 1}, {"Y", 2, 4}, {"Y", 3, 9},
+		{"Z", 0, 4}, {"Z", 1, 3}, {"Z", 3, 1},
+	}
+	for ri, e := range exp {
+		if s, x, y := pd.CellString("Series", ri), pd.CellFloat("X", ri), pd.CellFloat("Y", ri); s != e.ser || x != e.x || y != e.y {
+			t.Errorf("PlotData: row %v: %v %v %v, expected %v %v %v\n", ri, s, x, y, e.ser, e.x, e.y)
+		}
+	}
+}
+
+func TestColXCol(t *testing.T) {
+	dt := testXYTable(4)
+	pl := testPlot(dt, "X")
+	pl.ColParams("Y").On = true
+	pl.ColParams("Z").On = true
+	pl.ColParams("Z").XCol = "Y"
+	pl.GenPlotXY()
+	pd := pl.PlotData()
+	exp := []struct {
+		ser  string
+		x, y float64
+	}{
+		{"Y", 0, 0}, {"Y", 1, 1}, {"Y", 2, 4}, {"Y", 3, 9},
+		{"Z", 0, 4}, {"Z", 1, 3}, {"Z", 4, 2}, {"Z", 9, 1},
+	}
+	if pd.Rows != len(exp) {
+		t.Fatalf("XCol: %v rows, expected %v\n", pd.Rows, len(exp))
+	}
+	for ri, e := range exp {
+		if s, x, y := pd.CellString("Series", ri), pd.CellFloat("X", ri), pd.CellFloat("Y", ri); s != e.ser || x != e.x || y != e.y {
+			t.Errorf("XCol: row %v: %v %v %v, expected %v %v %v\n", ri, s, x, y, e.ser, e.x, e.y)
+		}
+	}
+	pl.ColParams("Z").XCol = "NoSuchCol" // falls back to the common X axis
+	pl.GenPlotXY()
+	pd = pl.PlotData()
+	if pd.Rows != len(exp) || pd.CellFloat("X", 6) != 2 {
+		t.Errorf("XCol: invalid column did not fall back to common X: %v rows, X %v\n", pd.Rows, pd.CellFloat("X", 6))
+	}
+}
+
+func TestEmptyCol(t *testing.T) {
+	dt := testXYTable(5)
+	for i := 0; i < 5; i++ {
+		dt.SetCellFloat("Y", i, float64(10+i))
+		dt.ColByName("Z").SetNull1D(i, true)
+	}
+	dt.SetCellFloat("Z", 2, math.NaN())
+	dt.ColByName("Z").SetNull1D(2, false)
+	pl := testPlot(dt, "X")
+	pl.ColParams("Y").On = true
+	pl.ColParams("Z").On = true
+	pl.GenPlotXY()
+	if pl.GPlot.Y.Min != 10 || pl.GPlot.Y.Max != 14 {
+		t.Errorf("EmptyCol: Y range %v - %v, expected 10 - 14\n", pl.GPlot.Y.Min, pl.GPlot.Y.Max)
+	}
+	if n := len(drawnLines(pl, pl.ColParams("Y").Color)); n != 1 {
+		t.Errorf("EmptyCol: %v Y lines, expected 1\n", n)
+	}
+	if n := len(drawnLines(pl, pl.ColParams("Z").Color)); n != 0 {
+		t.Errorf("EmptyCol: %v Z lines, expected none\n", n)
+	}
+	if pd := pl.PlotData(); pd.Rows != 5 || pd.CellString("Series", 4) != "Y" {
+		t.Errorf("EmptyCol: empty series in plot data: %v rows\n", pd.Rows)
+	}
+}
+
+func TestColUnitMeta(t *testing.T) {
+	dt := testXYTable(10)
+	dt.SetColMetaData("X", "unit", "ms")
+	dt.SetColMetaData("Y", "unit", "mV")
+	dt.SetColMetaData("Y", "format", "%.3f")
+	pl := testPlot(dt, "X")
+	pl.ColParams("X").On = false
+	pl.ColParams("Y").On = true
+	pl.GenPlotXY()
+	if lbl := pl.GPlot.X.Label.Text; lbl != "X (ms)" {
+		t.Errorf("ColUnit: X label %q, expected %q\n", lbl, "X (ms)")
+	}
+	if lbl := pl.GPlot.Y.Label.Text; lbl != "Y (mV)" {
+		t.Errorf("ColUnit: Y label %q, expected %q\n", lbl, "Y (mV)")
+	}
+	tf, ok := pl.GPlot.Y.Tick.Marker.(TickFormatter)
+	if !ok || tf.Format != "%.3f" {
+		t.Errorf("ColFormat: Y axis Marker %T not using column format\n", pl.GPlot.Y.Tick.Marker)
+	}
+	if _, ok := pl.GPlot.X.Tick.Marker.(TickFormatter); ok {
+		t.Errorf("ColFormat: X formatter installed with no format\n")
+	}
+	pl.Params.YAxisLabel = "Voltage"
+	pl.GenPlotXY()
+	if lbl := pl.GPlot.Y.Label.Text; lbl != "Voltage" {
+		t.Errorf("ColUnit: explicit Y label %q changed\n", lbl)
+	}
+}
+
+func TestBand(t *testing.T) {
+	dt := testXYTable(20)
+	for i := 0; i < dt.Rows; i++ {
+		dt.SetCellFloat("Y", i, float64(i%3)) // noisy
+	}
+	pl := testPlot(dt, "X")
+	cp := pl.ColParams("Y")
+	cp.On = true
+	cp.Band = 5
+	pl.GenPlotXY()
+	var poly *drawnPath
+	dps := drawnPaths(pl)
+	for i, dp := range dps {
+		if _, _, _, a := dp.Color.RGBA(); dp.Fill && a > 0 && a < 0xffff { // translucent band
+			poly = &dps[i]
+		}
+	}
+	lns := drawnLines(pl, cp.Color)
+	if poly == nil || len(lns) != 1 {
+		t.Fatalf("Band: missing band fill %v or line: %v lines\n", poly != nil, len(lns))
+	}
+	if n := len(poly.XYs) - 1; n != 2*dt.Rows { // the fill path repeats the first point
+		t.Errorf("Band: polygon has %v points, expected %v\n", n, 2*dt.Rows)
+	}
+	if y := lns[0].XYs[10].Y; math.Abs(y-1) > 0.21 { // mean of 1,2,0,1,2
+		t.Errorf("Band: rolling mean %v, expected about 1\n", y)
+	}
+}
+
+func TestRollingBand(t *testing.T) {
+	xys := make(plotter.XYs, 20)
+	for i := range xys {
+		xys[i] = plotter.XY{X: float64(i), Y: float64(i)}
+	}
+	mean, lo, hi := RollingBand(xys, 5)
+	// ends are truncated to the 3 points of the window within range,
+	// with population std of 3 consecutive ints, vs. 5 in the middle
+	std3, std5 := math.Sqrt(2.0/3.0), math.Sqrt2
+	for _, c := range []struct {
+		i       int
+		mn, std float64
+	}{{0, 1, std3}, {10, 10, std5}, {19, 18, std3}} {
+		m, l, h := mean[c.i], lo[c.i], hi[c.i]
+		if m.X != float64(c.i) || math.Abs(m.Y-c.mn) > 1e-9 || math.Abs(l.Y-(c.mn-c.std)) > 1e-9 || math.Abs(h.Y-(c.mn+c.std)) > 1e-9 {
+			t.Errorf("RollingBand: point %v: mean %v lo %v hi %v, expected mean %v +/- %v\n", c.i, m, l.Y, h.Y, c.mn, c.std)
+		}
+	}
+}
+
+func TestSizeCol(t *testing.T) {
+	dt := testXYTable(5) // Z = 5, 4, 3, 2, 1
+	pl := testPlot(dt, "X")
+	pl.Params.Points = true
+	cp := pl.ColParams("Y")
+	cp.On = true
+	cp.SizeCol = "Z"
+	cp.SizeRange.Set(2, 10)
+	// glyphRadii returns the radius of each point glyph (ring) drawn, in order
+	glyphRadii := func() []vg.Length {
+		var rads []vg.Length
+		for _, dp := range drawnPaths(pl) {
+			if !dp.Fill && dp.Color == cp.Color {
+				rads = append(rads, dp.Radii...)
+			}
+		}
+		return rads
+	}
+	pl.GenPlotXY()
+	exp := []float64{10, 8, 6, 4, 2}
+	rads := glyphRadii()
+	if len(rads) != len(exp) {
+		t.Fatalf("SizeCol: %v point glyphs, expected %v\n", len(rads), len(exp))
+	}
+	for i, e := range exp {
+		if r := rads[i]; math.Abs(float64(r)-float64(vg.Points(e))) > 1e-9 {
+			t.Errorf("SizeCol: point %v radius %v, expected %v\n", i, r, vg.Points(e))
+		}
+	}
+	cp.SizeCol = ""
+	pl.GenPlotXY()
+	for _, r := range glyphRadii() {
+		if r != vg.Points(pl.Params.PointSize) {
+			t.Errorf("SizeCol: point radius %v without SizeCol, expected %v\n", r, vg.Points(pl.Params.PointSize))
+		}
+	}
+}
+
+func TestYClip(t *testing.T) {
+	dt := testXYTable(5)
+	for i, y := range []float64{1, 2, 100, 3, 4} {
+		dt.SetCellFloat("Y", i, y)
+	}
+	pl := testPlot(dt, "X")
+	pl.ColParams("Y").On = true
+	pl.Params.YClip.FixMax = true
+	pl.Params.YClip.Max = 10
+	pl.GenPlotXY()
+	lines := drawnLines(pl, pl.ColParams("Y").Color)
+	if len(lines) != 2 {
+		t.Fatalf("YClip: %v lines, expected 2 separated by the clipped point\n", len(lines))
+	}
+	for _, ln := range lines {
+		for _, p := range ln.XYs {
+			if p.Y > 10+1e-6 {
+				t.Errorf("YClip: clipped point drawn: %v\n", p)
+			}
+		}
+	}
+	if x := lines[1].XYs[0].X; !nearData(x, 3, 4) {
+		t.Errorf("YClip: second line starts at X %v, expected 3\n", x)
+	}
+	pl.Params.YClip.FixMax = false
+	pl.GenPlotXY()
+	if n := len(drawnLines(pl, pl.ColParams("Y").Color)); n != 1 {
+		t.Errorf("YClip: %v lines without clipping, expected 1\n", n)
+	}
+}
+
+func TestTimeTicks(t *testing.T) {
+	dt := testXYTable(10)
+	st := 1600000000.0 // 2020-09-13 12:26:40 UTC
+	for i := 0; i < 10; i++ {
+		dt.SetCellFloat("X", i, st+float64(i)*3600)
+	}
+	dt.SetColMetaData("X", "format", "time")
+	pl := testPlot(dt, "X")
+	pl.ColParams("Y").On = true
+	pl.Params.XTimeLayout = "2006-01-02 15:04"
+	pl.GenPlotXY()
+	tt, ok := pl.GPlot.X.Tick.Marker.(plot.TimeTicks)
+	if !ok {
+		t.Fatalf("TimeTicks: X axis Marker is %T, not plot.TimeTicks\n", pl.GPlot.X.Tick.Marker)
+	}
+	nlbl := 0
+	for _, tk := range tt.Ticks(pl.GPlot.X.Min, pl.GPlot.X.Max) {
+		if tk.Label == "" {
+			continue
+		}
+		nlbl++
+		if exp := UnixTime(tk.Value).Format("2006-01-02 15:04"); tk.Label != exp || !strings.HasPrefix(tk.Label, "2020-09-13") {
+			t.Errorf("TimeTicks: X label %q, expected %q on 2020-09-13\n", tk.Label, exp)
+		}
+	}
+	if nlbl == 0 {
+		t.Errorf("TimeTicks: no labeled X ticks\n")
+	}
+	if tm := UnixTime(st + 0.5); tm.Unix() != int64(st) || tm.Nanosecond() != 5e8 {
+		t.Errorf("UnixTime: %v for %v + 0.5\n", tm, st)
+	}
+}
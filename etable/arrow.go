@@ -0,0 +1,162 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/emer/etable/etensor"
+)
+
+// ToArrow returns an Apache Arrow Record with the data from this table,
+// for interchange with other Arrow consumers (e.g., PyArrow / pandas).
+// Each scalar column becomes an Arrow array: INT64 and INT as Int64,
+// INT32 as Int32, FLOAT32 as Float32, STRING as String, and all other
+// numeric types as Float64.  Null values in the columns are set as nulls
+// in the Arrow validity bitmaps.  Columns with n-dimensional cells
+// are not supported and are skipped, with a log message.
+// The caller must call Release on the record when done with it.
+func (dt *Table) ToArrow() array.Record {
+	mem := memory.NewGoAllocator()
+	var flds []arrow.Field
+	var arrs []array.Interface
+	for ci, cl := range dt.Cols {
+		cn := dt.ColNames[ci]
+		if cl.NumDims() > 1 {
+			log.Printf("etable.Table ToArrow: column %v has n-dimensional cells, which are not supported -- skipped\n", cn)
+			continue
+		}
+		n := dt.Rows
+		valid := make([]bool, n)
+		hasNull := false
+		for ri := 0; ri < n; ri++ {
+			valid[ri] = !cl.IsNull1D(ri)
+			if !valid[ri] {
+				hasNull = true
+			}
+		}
+		if !hasNull {
+			valid = nil
+		}
+		var arr array.Interface
+		var atyp arrow.DataType
+		switch cl.DataType() {
+		case etensor.STRING:
+			bl := array.NewStringBuilder(mem)
+			bl.AppendValues(cl.(*etensor.String).Values[:n], valid)
+			arr = bl.NewArray()
+			bl.Release()
+			atyp = arrow.BinaryTypes.String
+		case etensor.INT64, etensor.INT:
+			vals := make([]int64, n)
+			switch tc := cl.(type) {
+			case *etensor.Int64:
+				copy(vals, tc.Values)
+			case *etensor.Int:
+				for ri := range vals {
+					vals[ri] = int64(tc.Values[ri])
+				}
+			}
+			bl := array.NewInt64Builder(mem)
+			bl.AppendValues(vals, valid)
+			arr = bl.NewArray()
+			bl.Release()
+			atyp = arrow.PrimitiveTypes.Int64
+		case etensor.INT32:
+			bl := array.NewInt32Builder(mem)
+			bl.AppendValues(cl.(*etensor.Int32).Values[:n], valid)
+			arr = bl.NewArray()
+			bl.Release()
+			atyp = arrow.PrimitiveTypes.Int32
+		case etensor.FLOAT32:
+			bl := array.NewFloat32Builder(mem)
+			bl.AppendValues(cl.(*etensor.Float32).Values[:n], valid)
+			arr = bl.NewArray()
+			bl.Release()
+			atyp = arrow.PrimitiveTypes.Float32
+		default:
+			vals := make([]float64, n)
+			for ri := range vals {
+				vals[ri] = cl.FloatVal1D(ri)
+			}
+			bl := array.NewFloat64Builder(mem)
+			bl.AppendValues(vals, valid)
+			arr = bl.NewArray()
+			bl.Release()
+			atyp = arrow.PrimitiveTypes.Float64
+		}
+		flds = append(flds, arrow.Field{Name: cn, Type: atyp, Nullable: true})
+		arrs = append(arrs, arr)
+	}
+	sc := arrow.NewSchema(flds, nil)
+	rec := array.NewRecord(sc, arrs, int64(dt.Rows))
+	for _, arr := range arrs {
+		arr.Release() // record retains its own reference
+	}
+	return rec
+}
+
+// FromArrow returns a new table with the data from given Apache Arrow Record.
+// Arrow Int64, Int32, Float64, Float32 and String arrays are supported, mapping
+// to the corresponding etensor types, and Arrow nulls are marked as Null in the
+// column tensors.  Returns an error for any other Arrow data types.
+func FromArrow(rec array.Record) (*Table, error) {
+	nc := int(rec.NumCols())
+	nr := int(rec.NumRows())
+	sc := make(Schema, nc)
+	for ci := 0; ci < nc; ci++ {
+		var typ etensor.Type
+		switch rec.Column(ci).DataType().ID() {
+		case arrow.INT64:
+			typ = etensor.INT64
+		case arrow.INT32:
+			typ = etensor.INT32
+		case arrow.FLOAT64:
+			typ = etensor.FLOAT64
+		case arrow.FLOAT32:
+			typ = etensor.FLOAT32
+		case arrow.STRING:
+			typ = etensor.STRING
+		default:
+			return nil, fmt.Errorf("etable.FromArrow: column %v has unsupported Arrow data type: %v", rec.ColumnName(ci), rec.Column(ci).DataType())
+		}
+		sc[ci] = Column{Name: rec.ColumnName(ci), Type: typ}
+	}
+	dt := New(sc, nr)
+	for ci := 0; ci < nc; ci++ {
+		arr := rec.Column(ci)
+		col := dt.Cols[ci]
+		switch a := arr.(type) {
+		case *array.Int64:
+			copy(col.(*etensor.Int64).Values, a.Int64Values())
+		case *array.Int32:
+			copy(col.(*etensor.Int32).Values, a.Int32Values())
+		case *array.Float64:
+			copy(col.(*etensor.Float64).Values, a.Float64Values())
+		case *array.Float32:
+			copy(col.(*etensor.Float32).Values, a.Float32Values())
+		case *array.String:
+			sv := col.(*etensor.String).Values
+			for ri := 0; ri < nr; ri++ {
+				if a.IsValid(ri) {
+					sv[ri] = a.Value(ri)
+				}
+			}
+		}
+		if arr.NullN() == 0 {
+			continue
+		}
+		for ri := 0; ri < nr; ri++ {
+			if arr.IsNull(ri) {
+				col.SetNull1D(ri, true)
+			}
+		}
+	}
+	return dt, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestArrow(t *testing.T) {
+	dt := New(Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Count", etensor.INT64, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"Val32", etensor.FLOAT32, nil, nil},
+		{"Pat", etensor.FLOAT32, []int{2, 2}, nil},
+	}, 4)
+	for ri := 0; ri < 4; ri++ {
+		dt.SetCellString("Name", ri, string(rune('a'+ri)))
+		dt.SetCellFloat("Count", ri, float64(ri*10))
+		dt.SetCellFloat("Val", ri, float64(ri)+.5)
+		dt.SetCellFloat("Val32", ri, float64(ri)+.25)
+	}
+	dt.ColByName("Name").SetNull1D(1, true)
+	dt.ColByName("Count").SetNull1D(2, true)
+	dt.ColByName("Val").SetNull1D(0, true)
+	dt.ColByName("Val").SetNull1D(3, true)
+
+	rec := dt.ToArrow()
+	defer rec.Release()
+	if rec.NumCols() != 4 || rec.NumRows() != 4 { // Pat is skipped
+		t.Fatalf("ToArrow: cols %v rows %v\n", rec.NumCols(), rec.NumRows())
+	}
+	if rec.Column(2).NullN() != 2 || !rec.Column(2).IsNull(3) || rec.Column(3).NullN() != 0 {
+		t.Errorf("ToArrow: nulls not translated\n")
+	}
+
+	ndt, err := FromArrow(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ndt.Rows != 4 || ndt.NumCols() != 4 {
+		t.Fatalf("FromArrow: rows %v cols %v\n", ndt.Rows, ndt.NumCols())
+	}
+	for ci := 0; ci < 4; ci++ {
+		if ndt.ColNames[ci] != dt.ColNames[ci] || ndt.Cols[ci].DataType() != dt.Cols[ci].DataType() {
+			t.Errorf("FromArrow: col %v: %v %v\n", ci, ndt.ColNames[ci], ndt.Cols[ci].DataType())
+		}
+		for ri := 0; ri < 4; ri++ {
+			oc := dt.Cols[ci]
+			nc := ndt.Cols[ci]
+			if oc.IsNull1D(ri) != nc.IsNull1D(ri) {
+				t.Errorf("FromArrow: col %v row %v null: %v != %v\n", ci, ri, nc.IsNull1D(ri), oc.IsNull1D(ri))
+			}
+			if !oc.IsNull1D(ri) && oc.StringVal1D(ri) != nc.StringVal1D(ri) {
+				t.Errorf("FromArrow: col %v row %v: %v != %v\n", ci, ri, nc.StringVal1D(ri), oc.StringVal1D(ri))
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
+)
+
+// BinaryVersion is the version of the binary table format written by WriteBinary
+const BinaryVersion = 1
+
+// binaryTable is the gob-encoded representation of a Table for WriteBinary
+type binaryTable struct {
+	Version  int
+	Rows     int
+	MetaData map[string]string
+	Cols     []binaryCol
+}
+
+// binaryCol is the gob-encoded representation of one column for WriteBinary.
+// Values holds the Values slice of the column tensor, except for BOOL
+// columns, which are stored as []bool.
+type binaryCol struct {
+	Name     string
+	Type     etensor.Type
+	Shape    []int
+	DimNames []string
+	MetaData map[string]string
+	Values   interface{}
+	Nulls    []int
+}
+
+// SaveBinary saves the table to a compact binary file, which exactly preserves
+// the schema, meta data, values and Null values of all the columns, unlike CSV
+// files -- e.g., for checkpoints between stages of processing.
+// Use OpenBinary to read it back.  The file is encoded using encoding/gob.
+func (dt *Table) SaveBinary(filename gi.FileName) error {
+	fp, err := os.Create(string(filename))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer fp.Close()
+	bw := bufio.NewWriter(fp)
+	if err := dt.WriteBinary(bw); err != nil {
+		log.Println(err)
+		return err
+	}
+	return bw.Flush()
+}
+
+// OpenBinary returns a new table read from a binary file saved by SaveBinary.
+func OpenBinary(filename gi.FileName) (*Table, error) {
+	fp, err := os.Open(string(filename))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer fp.Close()
+	return ReadBinary(bufio.NewReader(fp))
+}
+
+// WriteBinary writes the table in the binary format of SaveBinary to given writer.
+// Returns error for columns of types that are not supported by etensor.New
+// (e.g., etensor.INT), which could not be read back.
+func (dt *Table) WriteBinary(w io.Writer) error {
+	bt := binaryTable{Version: BinaryVersion, Rows: dt.Rows, MetaData: dt.MetaData}
+	for ci, cl := range dt.Cols {
+		if cl.DataType() == etensor.INT {
+			return fmt.Errorf("etable.Table WriteBinary: column %v has unsupported type: %v", dt.ColNames[ci], cl.DataType())
+		}
+		bc := binaryCol{Name: dt.ColNames[ci], Type: cl.DataType(), Shape: cl.Shapes(), DimNames: cl.DimNames(), MetaData: cl.MetaDataMap()}
+		n := cl.Len()
+		if cl.DataType() == etensor.BOOL {
+			bv := make([]bool, n)
+			for i := range bv {
+				bv[i] = cl.FloatVal1D(i) != 0
+			}
+			bc.Values = bv
+		} else {
+			bc.Values = reflect.ValueOf(cl).Elem().FieldByName("Values").Interface()
+		}
+		for i := 0; i < n; i++ {
+			if cl.IsNull1D(i) {
+				bc.Nulls = append(bc.Nulls, i)
+			}
+		}
+		bt.Cols = append(bt.Cols, bc)
+	}
+	return gob.NewEncoder(w).Encode(&bt)
+}
+
+// ReadBinary returns a new table read from given reader in the binary
+// format written by WriteBinary.
+func ReadBinary(r io.Reader) (*Table, error) {
+	var bt binaryTable
+	if err := gob.NewDecoder(r).Decode(&bt); err != nil {
+		return nil, err
+	}
+	if bt.Version != BinaryVersion {
+		return nil, fmt.Errorf("etable.ReadBinary: unsupported format version: %v", bt.Version)
+	}
+	dt := &Table{Rows: bt.Rows, MetaData: bt.MetaData}
+	for _, bc := range bt.Cols {
+		cl := etensor.New(bc.Type, bc.Shape, nil, bc.DimNames)
+		if cl == nil {
+			return nil, fmt.Errorf("etable.ReadBinary: column %v has unsupported type: %v", bc.Name, bc.Type)
+		}
+		if bv, ok := bc.Values.([]bool); ok {
+			for i, b := range bv {
+				if b {
+					cl.SetFloat1D(i, 1)
+				}
+			}
+		} else {
+			vf := reflect.ValueOf(cl).Elem().FieldByName("Values")
+			vals := reflect.ValueOf(bc.Values)
+			if vals.Type() != vf.Type() || vals.Len() != cl.Len() {
+				return nil, fmt.Errorf("etable.ReadBinary: column %v values do not match type %v and shape %v", bc.Name, bc.Type, bc.Shape)
+			}
+			vf.Set(vals)
+		}
+		for _, i := range bc.Nulls {
+			cl.SetNull1D(i, true)
+		}
+		for k, v := range bc.MetaData {
+			cl.SetMetaData(k, v)
+		}
+		dt.Cols = append(dt.Cols, cl)
+		dt.ColNames = append(dt.ColNames, bc.Name)
+	}
+	dt.UpdateColNameMap()
+	return dt, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
+)
+
+func TestSaveBinary(t *testing.T) {
+	sc := Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"F64", etensor.FLOAT64, nil, nil},
+		{"I64", etensor.INT64, nil, nil},
+		{"U8", etensor.UINT8, nil, nil},
+		{"Flag", etensor.BOOL, nil, nil},
+		{"Pat", etensor.FLOAT32, []int{2, 3}, []string{"Y", "X"}},
+	}
+	dt := New(sc, 4)
+	dt.SetMetaData("name", "bintest")
+	dt.ColByName("F64").SetMetaData("desc", "a float")
+	for ri := 0; ri < 4; ri++ {
+		dt.SetCellString("Name", ri, []string{"a", "b,c", "", "d\n"}[ri])
+		dt.SetCellFloat("F64", ri, math.Pi*float64(ri)/3)
+		dt.ColByName("I64").(*etensor.Int64).Values[ri] = 1<<60 + int64(ri) // beyond float64 precision
+		dt.SetCellFloat("U8", ri, float64(ri*60))
+		dt.SetCellFloat("Flag", ri, float64(ri%2))
+		for j := 0; j < 6; j++ {
+			dt.Cols[5].SetFloat1D(ri*6+j, float64(ri)+float64(j)/7)
+		}
+	}
+	dt.ColByName("F64").SetNull1D(1, true)
+	dt.ColByName("I64").SetNull1D(3, true)
+	dt.Cols[5].SetNull1D(8, true)
+
+	dir, err := ioutil.TempDir("", "etable_bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fn := gi.FileName(filepath.Join(dir, "test.etb"))
+	if err := dt.SaveBinary(fn); err != nil {
+		t.Fatal(err)
+	}
+	rt, err := OpenBinary(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rt.Rows != dt.Rows || !reflect.DeepEqual(rt.Schema(), dt.Schema()) {
+		t.Fatalf("OpenBinary: schema %v rows %v != %v %v\n", rt.Schema(), rt.Rows, dt.Schema(), dt.Rows)
+	}
+	if !reflect.DeepEqual(rt.MetaData, dt.MetaData) {
+		t.Errorf("OpenBinary: meta data %v != %v\n", rt.MetaData, dt.MetaData)
+	}
+	if d, _ := rt.ColByName("F64").MetaData("desc"); d != "a float" {
+		t.Errorf("OpenBinary: column meta data not restored: %v\n", d)
+	}
+	if diffs := dt.DiffCells(rt, 0); diffs != nil {
+		t.Errorf("OpenBinary: values differ: %v\n", diffs)
+	}
+	if !reflect.DeepEqual(rt.ColByName("I64").(*etensor.Int64).Values, dt.ColByName("I64").(*etensor.Int64).Values) {
+		t.Errorf("OpenBinary: int64 values not exact\n")
+	}
+	for ci, cl := range dt.Cols {
+		for i := 0; i < cl.Len(); i++ {
+			if rt.Cols[ci].IsNull1D(i) != cl.IsNull1D(i) {
+				t.Errorf("OpenBinary: column %v value %v: Null %v != %v\n", dt.ColNames[ci], i, rt.Cols[ci].IsNull1D(i), cl.IsNull1D(i))
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/emer/etable/etensor"
+)
+
+// BinCol discretizes the values of the numeric (1-dimensional) source column
+// into labeled bins, setting the label of the bin for each row into the
+// string destination column, which is added if it does not already exist.
+// The edges must be strictly increasing, with one more edge than labels:
+// bin i holds values v with edges[i] <= v < edges[i+1], except that the last
+// bin also includes its upper edge.  Values below the first edge or above
+// the last edge, and Null or NaN values, are not in any bin, and are set to
+// Null in the destination column (with an empty label).
+// Returns an error if the columns are not valid or the edges and labels do
+// not match, without changing the table.
+func (dt *Table) BinCol(srcName, dstName string, edges []float64, labels []string) error {
+	sc, err := dt.ColByNameTry(srcName)
+	if err != nil {
+		return err
+	}
+	if sc.NumDims() != 1 || sc.DataType() == etensor.STRING {
+		return fmt.Errorf("etable.BinCol: source column %v must be a 1-dimensional numeric column", srcName)
+	}
+	if len(labels) == 0 || len(labels) != len(edges)-1 {
+		return fmt.Errorf("etable.BinCol: %v labels for %v edges -- must be one fewer labels than edges", len(labels), len(edges))
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i] > edges[i-1]) {
+			return fmt.Errorf("etable.BinCol: edges must be strictly increasing: %v", edges)
+		}
+	}
+	dc := dt.ColByName(dstName)
+	if dc == nil {
+		dc = etensor.NewString([]int{dt.Rows}, nil, nil)
+		dt.AddCol(dc, dstName)
+	} else if dc.NumDims() != 1 || dc.DataType() != etensor.STRING {
+		return fmt.Errorf("etable.BinCol: destination column %v must be a 1-dimensional string column", dstName)
+	}
+	nb := len(labels)
+	for row := 0; row < dt.Rows; row++ {
+		v := sc.FloatVal1D(row)
+		if sc.IsNull1D(row) || math.IsNaN(v) || v < edges[0] || v > edges[nb] {
+			dc.SetString1D(row, "")
+			dc.SetNull1D(row, true)
+			continue
+		}
+		bi := sort.SearchFloat64s(edges, v) // first edge >= v
+		if bi == len(edges) || edges[bi] != v {
+			bi-- // v is above edges[bi-1]
+		}
+		if bi >= nb {
+			bi = nb - 1 // upper edge of last bin
+		}
+		dc.SetString1D(row, labels[bi])
+		dc.SetNull1D(row, false)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestBinCol(t *testing.T) {
+	vals := []float64{-1, 0, 0.5, 1, 1.5, 2, 3, 4}
+	dt := New(Schema{{"Val", etensor.FLOAT64, nil, nil}}, len(vals)+1)
+	for i, v := range vals {
+		dt.SetCellFloat("Val", i, v)
+	}
+	dt.Cols[0].SetNull1D(len(vals), true)
+	err := dt.BinCol("Val", "Level", []float64{0, 1, 2, 3}, []string{"low", "med", "high"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{"", "low", "low", "med", "med", "high", "high", "", ""}
+	lc := dt.ColByName("Level")
+	for i, e := range exp {
+		if s := dt.CellString("Level", i); s != e || lc.IsNull1D(i) != (e == "") {
+			t.Errorf("BinCol: row %v: %q null %v, expected %q\n", i, s, lc.IsNull1D(i), e)
+		}
+	}
+	if err := dt.BinCol("Val", "Level", []float64{0, 1}, []string{"a", "b"}); err == nil {
+		t.Errorf("BinCol: no error for mismatched edges and labels\n")
+	}
+	if err := dt.BinCol("Val", "Level", []float64{0, 1, 1}, []string{"a", "b"}); err == nil {
+		t.Errorf("BinCol: no error for non-increasing edges\n")
+	}
+	if err := dt.BinCol("Val", "Val", []float64{0, 1}, []string{"a"}); err == nil {
+		t.Errorf("BinCol: no error for numeric destination column\n")
+	}
+}
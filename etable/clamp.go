@@ -0,0 +1,111 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/emer/etable/etensor"
+)
+
+// ClampCol clips the values of the numeric column of given name to the
+// min..max range, in place, using FloatVal1D / SetFloat1D, so it works for
+// any numeric type and for n-dimensional cells.  Null and NaN values are
+// left unchanged.  Returns an error if the column is not found, is a string
+// column, or min > max.
+func (dt *Table) ClampCol(name string, min, max float64) error {
+	col, err := dt.clampCol(name)
+	if err != nil {
+		return err
+	}
+	if min > max {
+		return fmt.Errorf("etable.ClampCol: min %v is greater than max %v", min, max)
+	}
+	clampTensor(col, min, max)
+	return nil
+}
+
+// WinsorizeCol clips the values of the numeric column of given name, in place,
+// to the lowPct and highPct percentiles (0-100, e.g., 5 and 95) of its own
+// non-Null, non-NaN values, with all the values in n-dimensional cells
+// taken together.  Percentiles use linear interpolation, as in QuantileSorted.
+// Null and NaN values are left unchanged.  Returns an error if the column is
+// not found, is a string column, or the percentiles are not
+// 0 <= lowPct <= highPct <= 100.
+func (dt *Table) WinsorizeCol(name string, lowPct, highPct float64) error {
+	col, err := dt.clampCol(name)
+	if err != nil {
+		return err
+	}
+	if !(lowPct >= 0 && lowPct <= highPct && highPct <= 100) {
+		return fmt.Errorf("etable.WinsorizeCol: percentiles %v, %v must be 0 <= low <= high <= 100", lowPct, highPct)
+	}
+	n := col.Len()
+	vals := make([]float64, 0, n)
+	for i := 0; i < n; i++ {
+		v := col.FloatVal1D(i)
+		if col.IsNull1D(i) || math.IsNaN(v) {
+			continue
+		}
+		vals = append(vals, v)
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+	sort.Float64s(vals)
+	clampTensor(col, QuantileSorted(vals, lowPct/100), QuantileSorted(vals, highPct/100))
+	return nil
+}
+
+// clampCol returns the numeric column of given name, for ClampCol, WinsorizeCol
+func (dt *Table) clampCol(name string) (etensor.Tensor, error) {
+	col, err := dt.ColByNameTry(name)
+	if err != nil {
+		return nil, err
+	}
+	if col.DataType() == etensor.STRING {
+		return nil, fmt.Errorf("etable.ClampCol: column %v is a string column and cannot be clamped", name)
+	}
+	return col, nil
+}
+
+// clampTensor clips all the non-Null, non-NaN values of the tensor to min..max
+func clampTensor(col etensor.Tensor, min, max float64) {
+	n := col.Len()
+	for i := 0; i < n; i++ {
+		v := col.FloatVal1D(i)
+		if col.IsNull1D(i) || math.IsNaN(v) {
+			continue
+		}
+		if v < min {
+			col.SetFloat1D(i, min)
+		} else if v > max {
+			col.SetFloat1D(i, max)
+		}
+	}
+}
+
+// QuantileSorted returns the q (0-1) quantile of given values, which must
+// already be sorted in ascending order, using linear interpolation between
+// the closest ranks.  Returns NaN if vals is empty.  This is the single
+// definition of quantiles used by WinsorizeCol, BootstrapCI and agg.Quantiles.
+func QuantileSorted(vals []float64, q float64) float64 {
+	if len(vals) == 0 {
+		return math.NaN()
+	}
+	sz := len(vals) - 1
+	qi := q * float64(sz)
+	lwi := math.Floor(qi)
+	lwii := int(lwi)
+	if lwii >= sz {
+		return vals[sz]
+	} else if lwii < 0 {
+		return vals[0]
+	}
+	phi := qi - lwi
+	return (1-phi)*vals[lwii] + phi*vals[lwii+1]
+}
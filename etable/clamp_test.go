@@ -0,0 +1,80 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestClampCol(t *testing.T) {
+	vals := []float64{-5, 0, 2, 7, 12, math.NaN()}
+	dt := New(Schema{{"Val", etensor.FLOAT64, nil, nil}}, len(vals)+1)
+	for i, v := range vals {
+		dt.SetCellFloat("Val", i, v)
+	}
+	dt.Cols[0].SetNull1D(len(vals), true)
+	dt.Cols[0].SetFloat1D(len(vals), 100)
+	if err := dt.ClampCol("Val", 0, 10); err != nil {
+		t.Fatal(err)
+	}
+	exp := []float64{0, 0, 2, 7, 10}
+	for i, e := range exp {
+		if v := dt.CellFloat("Val", i); v != e {
+			t.Errorf("ClampCol: row %v: %v, expected %v\n", i, v, e)
+		}
+	}
+	if v := dt.CellFloat("Val", 5); !math.IsNaN(v) {
+		t.Errorf("ClampCol: NaN changed to %v\n", v)
+	}
+	if v := dt.CellFloat("Val", 6); v != 100 || !dt.Cols[0].IsNull1D(6) {
+		t.Errorf("ClampCol: null changed to %v\n", v)
+	}
+	if err := dt.ClampCol("Val", 1, 0); err == nil {
+		t.Errorf("ClampCol: no error for min > max\n")
+	}
+	if err := dt.ClampCol("Nope", 0, 1); err == nil {
+		t.Errorf("ClampCol: no error for missing column\n")
+	}
+}
+
+func TestWinsorizeCol(t *testing.T) {
+	// 0..100 so the 5th / 95th percentiles are exactly 5 and 95
+	n := 101
+	dt := New(Schema{{"Val", etensor.FLOAT64, nil, nil}, {"Name", etensor.STRING, nil, nil}}, n)
+	for i := 0; i < n; i++ {
+		dt.SetCellFloat("Val", i, float64((i*37)%n)) // shuffled order
+	}
+	if err := dt.WinsorizeCol("Val", 5, 95); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		orig := float64((i * 37) % n)
+		e := math.Max(5, math.Min(95, orig))
+		if v := dt.CellFloat("Val", i); v != e {
+			t.Errorf("WinsorizeCol: row %v: %v, expected %v\n", i, v, e)
+		}
+	}
+	if err := dt.WinsorizeCol("Val", 95, 5); err == nil {
+		t.Errorf("WinsorizeCol: no error for low > high\n")
+	}
+	if err := dt.WinsorizeCol("Name", 5, 95); err == nil {
+		t.Errorf("WinsorizeCol: no error for string column\n")
+	}
+}
+
+func TestQuantileSorted(t *testing.T) {
+	vals := []float64{1, 2, 4, 8}
+	for _, c := range []struct{ q, exp float64 }{{0, 1}, {.5, 3}, {1, 8}, {-.1, 1}, {1.1, 8}, {.25, 1.75}} {
+		if v := QuantileSorted(vals, c.q); v != c.exp {
+			t.Errorf("QuantileSorted: q %v = %v, expected %v\n", c.q, v, c.exp)
+		}
+	}
+	if v := QuantileSorted(nil, .5); !math.IsNaN(v) {
+		t.Errorf("QuantileSorted: empty = %v, expected NaN\n", v)
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"log"
+	"math"
+
+	"github.com/emer/etable/etensor"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ColView is a read-only etensor.Tensor view of one column of an IdxView,
+// with rows in the order of the view indexes, which indexes through to the
+// underlying column without copying any values -- see IdxView.ColView.
+// It has the shape of the column with the outer-most (row) dimension set to
+// the number of indexes.  All of the methods that would write to the tensor
+// panic.  SubSpace returns a sub-space of the underlying column, which
+// shares its memory, as usual. Clone returns a new concrete tensor with
+// the values in view order.
+type ColView struct {
+	etensor.Shape
+	Col  etensor.Tensor `desc:"underlying column tensor"`
+	Idxs []int          `desc:"indexes of the rows of the underlying column -- shared with the IdxView"`
+	csz  int            `desc:"number of elements per cell (row)"`
+}
+
+// ColView returns a read-only etensor.Tensor view of given column, with
+// its rows in the current order of the view indexes, indexing through to the
+// underlying column without copying any values.  It uses the Idxs at the time
+// of the call, so it must be made again after the view is sorted, filtered
+// or otherwise changed.  Writing to the returned tensor panics.
+func (ix *IdxView) ColView(colIdx int) etensor.Tensor {
+	cl := ix.Table.Cols[colIdx]
+	cv := &ColView{Col: cl, Idxs: ix.Idxs}
+	_, cv.csz = cl.RowCellSize()
+	shp := etensor.CopyInts(cl.Shapes())
+	shp[0] = len(ix.Idxs)
+	cv.SetShape(shp, nil, cl.DimNames())
+	return cv
+}
+
+// idx returns the index into the underlying column for given 1D index
+func (cv *ColView) idx(i int) int {
+	return cv.Idxs[i/cv.csz]*cv.csz + i%cv.csz
+}
+
+// readOnly panics with the name of the write method that was called
+func (cv *ColView) readOnly(meth string) {
+	panic("etable.ColView " + meth + ": ColView is a read-only view")
+}
+
+func (cv *ColView) ShapeObj() *etensor.Shape { return &cv.Shape }
+func (cv *ColView) DataType() etensor.Type   { return cv.Col.DataType() }
+
+func (cv *ColView) IsNull(i []int) bool      { return cv.IsNull1D(cv.Offset(i)) }
+func (cv *ColView) IsNull1D(i int) bool      { return cv.Col.IsNull1D(cv.idx(i)) }
+func (cv *ColView) FloatVal(i []int) float64 { return cv.FloatVal1D(cv.Offset(i)) }
+func (cv *ColView) StringVal(i []int) string { return cv.StringVal1D(cv.Offset(i)) }
+func (cv *ColView) FloatVal1D(i int) float64 { return cv.Col.FloatVal1D(cv.idx(i)) }
+func (cv *ColView) StringVal1D(i int) string { return cv.Col.StringVal1D(cv.idx(i)) }
+
+func (cv *ColView) FloatValRowCell(row, cell int) float64 {
+	return cv.Col.FloatValRowCell(cv.Idxs[row], cell)
+}
+
+func (cv *ColView) StringValRowCell(row, cell int) string {
+	return cv.Col.StringValRowCell(cv.Idxs[row], cell)
+}
+
+// Floats sets []float64 slice of all elements in the view, in view order
+func (cv *ColView) Floats(flt *[]float64) {
+	ln := cv.Len()
+	if len(*flt) != ln {
+		*flt = make([]float64, ln)
+	}
+	for j := range *flt {
+		(*flt)[j] = cv.FloatVal1D(j)
+	}
+}
+
+// SubSpace returns the sub-space of the underlying column at given offsets,
+// with the first (row) offset mapped through the view indexes.
+// The sub-space shares memory with the underlying column.
+func (cv *ColView) SubSpace(offs []int) etensor.Tensor {
+	ss, _ := cv.SubSpaceTry(offs)
+	return ss
+}
+
+// SubSpaceTry returns the sub-space of the underlying column at given offsets,
+// with the first (row) offset mapped through the view indexes.
+func (cv *ColView) SubSpaceTry(offs []int) (etensor.Tensor, error) {
+	if len(offs) > 0 {
+		offs = etensor.CopyInts(offs)
+		offs[0] = cv.Idxs[offs[0]]
+	}
+	return cv.Col.SubSpaceTry(offs)
+}
+
+// Range returns the min, max (and associated indexes, -1 = no values) for the view.
+func (cv *ColView) Range() (min, max float64, minIdx, maxIdx int) {
+	minIdx = -1
+	maxIdx = -1
+	for j, n := 0, cv.Len(); j < n; j++ {
+		fv := cv.FloatVal1D(j)
+		if math.IsNaN(fv) {
+			continue
+		}
+		if fv < min || minIdx < 0 {
+			min = fv
+			minIdx = j
+		}
+		if fv > max || maxIdx < 0 {
+			max = fv
+			maxIdx = j
+		}
+	}
+	return
+}
+
+// Agg applies given aggregation function to each element in the view, in view
+// order (automatically skips IsNull and NaN elements).
+func (cv *ColView) Agg(ini float64, fun etensor.AggFunc) float64 {
+	ag := ini
+	for j, n := 0, cv.Len(); j < n; j++ {
+		val := cv.FloatVal1D(j)
+		if !cv.IsNull1D(j) && !math.IsNaN(val) {
+			ag = fun(j, val, ag)
+		}
+	}
+	return ag
+}
+
+// Visit calls given function on each element in the view, in view order
+// (automatically skips IsNull and NaN elements), stopping as soon as the
+// function returns false.
+func (cv *ColView) Visit(fun etensor.VisitFunc) {
+	for j, n := 0, cv.Len(); j < n; j++ {
+		val := cv.FloatVal1D(j)
+		if !cv.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
+// Eval applies given function to each element in the view, in view order
+// (automatically skips IsNull and NaN elements), putting the results into res.
+func (cv *ColView) Eval(res *[]float64, fun etensor.EvalFunc) {
+	ln := cv.Len()
+	if len(*res) != ln {
+		*res = make([]float64, ln)
+	}
+	for j := 0; j < ln; j++ {
+		val := cv.FloatVal1D(j)
+		if !cv.IsNull1D(j) && !math.IsNaN(val) {
+			(*res)[j] = fun(j, val)
+		}
+	}
+}
+
+// Clone returns a new tensor of the same type as the underlying column,
+// with the values, Null flags and meta data of the rows in view order.
+func (cv *ColView) Clone() etensor.Tensor {
+	cl := etensor.New(cv.DataType(), etensor.CopyInts(cv.Shapes()), nil, cv.DimNames()) // 0 rows ok
+	cl.CopyMetaData(cv.Col)
+	for i, srw := range cv.Idxs {
+		cl.CopyCellsFrom(cv.Col, i*cv.csz, srw*cv.csz, cv.csz)
+	}
+	return cl
+}
+
+func (cv *ColView) MetaData(key string) (string, bool) { return cv.Col.MetaData(key) }
+func (cv *ColView) MetaDataMap() map[string]string     { return cv.Col.MetaDataMap() }
+
+// Dims is the gonum/mat.Matrix interface method for returning the dimensionality of the
+// 2D Matrix.  Assumes Row-major ordering and logs an error if NumDims < 2.
+func (cv *ColView) Dims() (r, c int) {
+	nd := cv.NumDims()
+	if nd < 2 {
+		log.Println("etensor Dims gonum Matrix call made on Tensor with dims < 2")
+		return 0, 0
+	}
+	return cv.Dim(nd - 2), cv.Dim(nd - 1)
+}
+
+// At is the gonum/mat.Matrix interface method for returning 2D matrix element at given
+// row, column index.  Assumes Row-major ordering and logs an error if NumDims < 2.
+func (cv *ColView) At(i, j int) float64 {
+	nd := cv.NumDims()
+	if nd < 2 {
+		log.Println("etensor Dims gonum Matrix call made on Tensor with dims < 2")
+		return 0
+	}
+	ix := make([]int, nd)
+	ix[nd-2] = i
+	ix[nd-1] = j
+	return cv.FloatVal(ix)
+}
+
+// T is the gonum/mat.Matrix transpose method.
+// It performs an implicit transpose by returning the receiver inside a Transpose.
+func (cv *ColView) T() mat.Matrix {
+	return mat.Transpose{cv}
+}
+
+// write methods -- all panic
+
+func (cv *ColView) SetNull(i []int, nul bool)           { cv.readOnly("SetNull") }
+func (cv *ColView) SetNull1D(i int, nul bool)           { cv.readOnly("SetNull1D") }
+func (cv *ColView) SetFloat(i []int, val float64)       { cv.readOnly("SetFloat") }
+func (cv *ColView) SetString(i []int, val string)       { cv.readOnly("SetString") }
+func (cv *ColView) SetFloat1D(i int, val float64)       { cv.readOnly("SetFloat1D") }
+func (cv *ColView) SetString1D(i int, val string)       { cv.readOnly("SetString1D") }
+func (cv *ColView) SetFloatRowCell(r, c int, v float64) { cv.readOnly("SetFloatRowCell") }
+func (cv *ColView) SetStringRowCell(r, c int, v string) { cv.readOnly("SetStringRowCell") }
+func (cv *ColView) SetFloats(vals []float64)            { cv.readOnly("SetFloats") }
+func (cv *ColView) SetStrings(vals []string)            { cv.readOnly("SetStrings") }
+func (cv *ColView) SetFunc(fun etensor.EvalFunc)        { cv.readOnly("SetFunc") }
+func (cv *ColView) SetZeros()                           { cv.readOnly("SetZeros") }
+func (cv *ColView) CopyFrom(from etensor.Tensor)        { cv.readOnly("CopyFrom") }
+func (cv *ColView) CopyShapeFrom(from etensor.Tensor)   { cv.readOnly("CopyShapeFrom") }
+func (cv *ColView) SetNumRows(rows int)                 { cv.readOnly("SetNumRows") }
+func (cv *ColView) SetMetaData(key, val string)         { cv.readOnly("SetMetaData") }
+func (cv *ColView) CopyMetaData(from etensor.Tensor)    { cv.readOnly("CopyMetaData") }
+
+func (cv *ColView) CopyCellsFrom(from etensor.Tensor, to, start, n int) {
+	cv.readOnly("CopyCellsFrom")
+}
+
+// SetShape panics, except for internal use when making the view
+func (cv *ColView) SetShape(shape, strides []int, names []string) {
+	if cv.Shp != nil {
+		cv.readOnly("SetShape")
+	}
+	cv.Shape.SetShape(shape, strides, names)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/emer/etable/etensor"
+)
+
+// ConvertCol converts the column of given name to given data type, replacing
+// it with a new tensor of that type, with the same shape, dimension names and
+// meta data -- e.g., for a numeric column that was loaded as a String column.
+// String values are parsed as numbers (or as true / false for BOOL), ignoring
+// surrounding white space, and empty or unparseable strings are set as Null.
+// Numbers are formatted as strings, and Null values become empty strings.
+// Between numeric types, values are converted as in SetFloat1D (integer types
+// truncate fractional values) and Null values remain Null.
+// Returns error if the column is not found or the type is not supported.
+func (dt *Table) ConvertCol(name string, toType etensor.Type) error {
+	ci, err := dt.ColIdxTry(name)
+	if err != nil {
+		return err
+	}
+	cl := dt.Cols[ci]
+	if cl.DataType() == toType {
+		return nil
+	}
+	nc := etensor.New(toType, cl.Shapes(), nil, cl.DimNames())
+	if nc == nil {
+		return fmt.Errorf("etable.Table ConvertCol: type %v is not supported for column %v", toType, name)
+	}
+	nc.CopyMetaData(cl)
+	fromStr := cl.DataType() == etensor.STRING
+	toStr := toType == etensor.STRING
+	for i := 0; i < cl.Len(); i++ {
+		switch {
+		case fromStr:
+			str := strings.TrimSpace(cl.StringVal1D(i))
+			if fv, err := strconv.ParseFloat(str, 64); err == nil {
+				nc.SetFloat1D(i, fv)
+			} else if bv, err := strconv.ParseBool(str); err == nil && toType == etensor.BOOL {
+				nc.SetString1D(i, strconv.FormatBool(bv))
+			} else {
+				nc.SetNull1D(i, true)
+			}
+		case cl.IsNull1D(i):
+			if !toStr {
+				nc.SetNull1D(i, true)
+			}
+		case toStr:
+			nc.SetString1D(i, cl.StringVal1D(i))
+		default:
+			nc.SetFloat1D(i, cl.FloatVal1D(i))
+		}
+	}
+	dt.Cols[ci] = nc
+	return nil
+}
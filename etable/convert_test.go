@@ -0,0 +1,56 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestConvertCol(t *testing.T) {
+	dt := New(Schema{
+		{"Val", etensor.STRING, nil, nil},
+	}, 4)
+	for ri, s := range []string{"1.5", " 2", "n/a", "-3e2"} {
+		dt.SetCellString("Val", ri, s)
+	}
+	dt.ColByName("Val").SetMetaData("desc", "values")
+	if err := dt.ConvertCol("Val", etensor.FLOAT64); err != nil {
+		t.Fatal(err)
+	}
+	cl := dt.ColByName("Val")
+	if cl.DataType() != etensor.FLOAT64 || cl.Len() != 4 {
+		t.Fatalf("ConvertCol: type %v len %v, expected FLOAT64 4\n", cl.DataType(), cl.Len())
+	}
+	if d, _ := cl.MetaData("desc"); d != "values" {
+		t.Errorf("ConvertCol: meta data not copied: %v\n", d)
+	}
+	exp := []float64{1.5, 2, 0, -300}
+	for ri, e := range exp {
+		if ri == 2 {
+			if !cl.IsNull1D(ri) {
+				t.Errorf("ConvertCol: unparseable value not Null\n")
+			}
+			continue
+		}
+		if v := dt.CellFloat("Val", ri); v != e || cl.IsNull1D(ri) {
+			t.Errorf("ConvertCol: row %v: %v, expected %v\n", ri, v, e)
+		}
+	}
+
+	if err := dt.ConvertCol("Val", etensor.STRING); err != nil {
+		t.Fatal(err)
+	}
+	exps := []string{"1.5", "2", "", "-300"}
+	for ri, e := range exps {
+		if s := dt.CellString("Val", ri); s != e {
+			t.Errorf("ConvertCol: back to String: row %v: %q, expected %q\n", ri, s, e)
+		}
+	}
+	if err := dt.ConvertCol("Nope", etensor.FLOAT64); err == nil {
+		t.Errorf("ConvertCol: no error for bad column name\n")
+	}
+}
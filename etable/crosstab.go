@@ -0,0 +1,86 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/emer/etable/etensor"
+)
+
+// CrossTab returns a new contingency table with the number of rows in given
+// table having each combination of values in the two given categorical
+// columns, which must be 1-dimensional.  The first column of the result,
+// named rowCol, has the distinct values of rowCol, and it is followed by
+// an INT64 count column for each distinct value of colCol, named by that value.
+// Combinations that never occur have a count of 0.  The distinct values are
+// sorted in ascending order: numerically for numeric columns and
+// alphabetically for String columns.  Rows with a Null value in either
+// column are not counted.
+func CrossTab(dt *Table, rowCol, colCol string) (*Table, error) {
+	rc, err := dt.ColByNameTry(rowCol)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := dt.ColByNameTry(colCol)
+	if err != nil {
+		return nil, err
+	}
+	if rc.NumDims() > 1 || cc.NumDims() > 1 {
+		return nil, fmt.Errorf("etable.CrossTab: columns %v and %v must be 1-dimensional", rowCol, colCol)
+	}
+	var rows []int
+	for ri := 0; ri < dt.Rows; ri++ {
+		if !rc.IsNull1D(ri) && !cc.IsNull1D(ri) {
+			rows = append(rows, ri)
+		}
+	}
+	rvals := crossTabVals(rc, rows)
+	cvals := crossTabVals(cc, rows)
+	sc := Schema{{rowCol, etensor.STRING, nil, nil}}
+	for _, cv := range cvals {
+		if cv == rowCol {
+			return nil, fmt.Errorf("etable.CrossTab: value %v of column %v is the same as the row column name", cv, colCol)
+		}
+		sc = append(sc, Column{cv, etensor.INT64, nil, nil})
+	}
+	ct := New(sc, len(rvals))
+	rmap := make(map[string]int, len(rvals))
+	for i, rv := range rvals {
+		rmap[rv] = i
+		ct.Cols[0].SetString1D(i, rv)
+	}
+	cmap := make(map[string]int, len(cvals))
+	for i, cv := range cvals {
+		cmap[cv] = i + 1
+	}
+	for _, ri := range rows {
+		cl := ct.Cols[cmap[cc.StringVal1D(ri)]].(*etensor.Int64)
+		cl.Values[rmap[rc.StringVal1D(ri)]]++
+	}
+	return ct, nil
+}
+
+// crossTabVals returns the sorted distinct string values of given column
+// over given rows, sorted numerically for numeric columns
+func crossTabVals(col etensor.Tensor, rows []int) []string {
+	strs := make(map[string]float64)
+	for _, ri := range rows {
+		strs[col.StringVal1D(ri)] = col.FloatVal1D(ri)
+	}
+	vals := make([]string, 0, len(strs))
+	for s := range strs {
+		vals = append(vals, s)
+	}
+	if col.DataType() == etensor.STRING {
+		sort.Strings(vals)
+	} else {
+		sort.Slice(vals, func(i, j int) bool {
+			return strs[vals[i]] < strs[vals[j]]
+		})
+	}
+	return vals
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestCrossTab(t *testing.T) {
+	dt := New(Schema{
+		{"Cond", etensor.STRING, nil, nil},
+		{"Resp", etensor.INT64, nil, nil},
+	}, 7)
+	conds := []string{"b", "a", "a", "b", "a", "c", "b"}
+	resps := []float64{10, 2, 10, 10, 2, 2, 2}
+	for i := range conds {
+		dt.SetCellString("Cond", i, conds[i])
+		dt.SetCellFloat("Resp", i, resps[i])
+	}
+	dt.AddRows(1)
+	dt.SetCellString("Cond", 7, "c")
+	dt.ColByName("Resp").SetNull1D(7, true) // not counted
+	ct, err := CrossTab(dt, "Cond", "Resp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct.Rows != 3 || ct.NumCols() != 3 || ct.ColNames[1] != "2" || ct.ColNames[2] != "10" {
+		t.Fatalf("CrossTab: rows %v cols %v\n", ct.Rows, ct.ColNames)
+	}
+	exp := []struct {
+		cond    string
+		n2, n10 float64
+	}{{"a", 2, 1}, {"b", 1, 2}, {"c", 1, 0}}
+	for ri, e := range exp {
+		if ct.CellString("Cond", ri) != e.cond || ct.CellFloat("2", ri) != e.n2 || ct.CellFloat("10", ri) != e.n10 {
+			t.Errorf("CrossTab: row %v: %v %v %v, expected %v\n", ri, ct.CellString("Cond", ri), ct.CellFloat("2", ri), ct.CellFloat("10", ri), e)
+		}
+	}
+	if _, err := CrossTab(dt, "Cond", "NoSuchCol"); err == nil {
+		t.Errorf("CrossTab: no error for invalid column\n")
+	}
+}
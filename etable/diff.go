@@ -0,0 +1,114 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/emer/etable/etensor"
+	"github.com/goki/ki/ints"
+)
+
+// CellDiff records one cell value that differs between two tables, from DiffCells
+type CellDiff struct {
+	Col      string  `desc:"name of the column"`
+	Row      int     `desc:"row in the tables"`
+	Idx      int     `desc:"flat index of the value within the cell -- 0 for scalar columns"`
+	Val      float64 `desc:"value in this table -- NaN if Null, or for string columns"`
+	OtherVal float64 `desc:"value in the other table -- NaN if Null, or for string columns"`
+	Str      string  `desc:"value in this table as a string"`
+	OtherStr string  `desc:"value in the other table as a string"`
+}
+
+// String returns a one-line description of the difference
+func (cd *CellDiff) String() string {
+	return fmt.Sprintf("%s[%d][%d]: %s != %s", cd.Col, cd.Row, cd.Idx, cd.Str, cd.OtherStr)
+}
+
+// DiffCells returns the cells that differ between this table and the other
+// table, for building automated regression reports.  Columns are matched by name,
+// and only columns present in both tables with the same cell size are compared,
+// over the rows present in both -- differences in the columns or number of rows
+// must be checked separately.  Numeric values are equal if they differ by no
+// more than tol, or are both NaN.  A Null value only equals another Null value.
+// String columns are compared exactly, and are compared as strings if the
+// other column is numeric.  Returns nil if there are no differences.
+func (dt *Table) DiffCells(other *Table, tol float64) []CellDiff {
+	var diffs []CellDiff
+	rows := ints.MinInt(dt.Rows, other.Rows)
+	for ci, cl := range dt.Cols {
+		cn := dt.ColNames[ci]
+		ocl, err := other.ColByNameTry(cn)
+		if err != nil {
+			continue
+		}
+		_, csz := cl.RowCellSize()
+		if _, ocsz := ocl.RowCellSize(); ocsz != csz {
+			continue
+		}
+		isStr := cl.DataType() == etensor.STRING || ocl.DataType() == etensor.STRING
+		for ri := 0; ri < rows; ri++ {
+			for j := 0; j < csz; j++ {
+				i := ri*csz + j
+				nl, onl := cl.IsNull1D(i), ocl.IsNull1D(i)
+				var same bool
+				switch {
+				case nl || onl:
+					same = nl && onl
+				case isStr:
+					same = cl.StringVal1D(i) == ocl.StringVal1D(i)
+				default:
+					v, ov := cl.FloatVal1D(i), ocl.FloatVal1D(i)
+					same = math.Abs(v-ov) <= tol || (math.IsNaN(v) && math.IsNaN(ov))
+				}
+				if same {
+					continue
+				}
+				cd := CellDiff{Col: cn, Row: ri, Idx: j, Val: math.NaN(), OtherVal: math.NaN()}
+				if !nl {
+					cd.Str = cl.StringVal1D(i)
+					if !isStr {
+						cd.Val = cl.FloatVal1D(i)
+					}
+				}
+				if !onl {
+					cd.OtherStr = ocl.StringVal1D(i)
+					if !isStr {
+						cd.OtherVal = ocl.FloatVal1D(i)
+					}
+				}
+				diffs = append(diffs, cd)
+			}
+		}
+	}
+	return diffs
+}
+
+// Diff returns a human-readable description of the differences between this
+// table and the other table, with one line per differing cell as reported by
+// DiffCells, preceded by any differences in the number of rows or in the
+// columns present.  Returns an empty string if there are no differences.
+func (dt *Table) Diff(other *Table, tol float64) string {
+	var b strings.Builder
+	if dt.Rows != other.Rows {
+		fmt.Fprintf(&b, "rows: %d != %d\n", dt.Rows, other.Rows)
+	}
+	for _, cn := range dt.ColNames {
+		if other.ColIdx(cn) < 0 {
+			fmt.Fprintf(&b, "column: %s not in other table\n", cn)
+		}
+	}
+	for _, cn := range other.ColNames {
+		if dt.ColIdx(cn) < 0 {
+			fmt.Fprintf(&b, "column: %s only in other table\n", cn)
+		}
+	}
+	for _, cd := range dt.DiffCells(other, tol) {
+		b.WriteString(cd.String() + "\n")
+	}
+	return b.String()
+}
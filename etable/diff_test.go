@@ -0,0 +1,62 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestDiffCells(t *testing.T) {
+	sc := Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"Vec", etensor.FLOAT32, []int{3}, nil},
+	}
+	dt := New(sc, 3)
+	for ri := 0; ri < 3; ri++ {
+		dt.SetCellString("Name", ri, string(rune('a'+ri)))
+		dt.SetCellFloat("Val", ri, float64(ri))
+		for j := 0; j < 3; j++ {
+			dt.Cols[2].SetFloat1D(ri*3+j, float64(ri+j))
+		}
+	}
+	ot := dt.Clone()
+	ot.SetCellFloat("Val", 0, 1e-8) // within tol
+	if diffs := dt.DiffCells(ot, 1e-6); diffs != nil {
+		t.Errorf("DiffCells: equal tables within tol gave diffs: %v\n", diffs)
+	}
+	if d := dt.Diff(ot, 1e-6); d != "" {
+		t.Errorf("Diff: equal tables within tol gave: %v\n", d)
+	}
+
+	ot.SetCellString("Name", 1, "z")
+	ot.SetCellFloat("Val", 2, 2.5)
+	ot.Cols[2].SetFloat1D(1*3+2, 10)
+	ot.Cols[1].SetNull1D(1, true)
+	diffs := dt.DiffCells(ot, 1e-6)
+	exp := []CellDiff{
+		{Col: "Name", Row: 1, Idx: 0, Str: "b", OtherStr: "z"},
+		{Col: "Val", Row: 1, Idx: 0, Val: 1, Str: "1"},
+		{Col: "Val", Row: 2, Idx: 0, Val: 2, OtherVal: 2.5, Str: "2", OtherStr: "2.5"},
+		{Col: "Vec", Row: 1, Idx: 2, Val: 3, OtherVal: 10, Str: "3", OtherStr: "10"},
+	}
+	if len(diffs) != len(exp) {
+		t.Fatalf("DiffCells: got %d diffs, expected %d: %v\n", len(diffs), len(exp), diffs)
+	}
+	for i, e := range exp {
+		d := diffs[i]
+		if d.Col != e.Col || d.Row != e.Row || d.Idx != e.Idx || d.Str != e.Str || d.OtherStr != e.OtherStr {
+			t.Errorf("DiffCells %d: got %+v, expected %+v\n", i, d, e)
+		}
+		if e.Col != "Name" && d.Val != e.Val {
+			t.Errorf("DiffCells %d: Val %v, expected %v\n", i, d.Val, e.Val)
+		}
+	}
+	if e := exp[3]; diffs[3].OtherVal != e.OtherVal {
+		t.Errorf("DiffCells: OtherVal %v, expected %v\n", diffs[3].OtherVal, e.OtherVal)
+	}
+}
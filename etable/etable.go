@@ -20,9 +20,9 @@ import (
 // All tensors MUST have RowMajor stride layout!
 type Table struct {
 	Cols       []etensor.Tensor  `view:"no-inline" desc:"columns of data, as etensor.Tensor tensors"`
-	ColNames   []string          `desc:"the names of the columns"`
+	ColNames   []string          `desc:"the names of the columns, in the same order as Cols -- always use this (or Cols) to iterate over the columns in a stable order"`
 	Rows       int               `inactive:"+" desc:"number of rows, which is enforced to be the size of the outer-most dimension of the column tensors"`
-	ColNameMap map[string]int    `view:"-" desc:"the map of column names to column numbers"`
+	ColNameMap map[string]int    `view:"-" desc:"the map of column names to column numbers -- only for lookup, as its iteration order is random"`
 	MetaData   map[string]string `desc:"misc meta data for the table.  We use lower-case key names following the struct tag convention:  name = name of table; desc = description; read-only = gui is read-only; precision = n for precision to write out floats in csv.  For Column-specific data, we look for ColName: prefix, specifically ColName:desc = description of the column contents, which is shown as tooltip in the etview.TableView"`
 }
 
@@ -179,6 +179,37 @@ func (dt *Table) DeleteColIdx(idx int) {
 	dt.UpdateColNameMap()
 }
 
+// RenameCol renames the column of given name to the new name, keeping its
+// position in Cols and ColNames, and renaming any ColName: meta data keys.
+// Returns error if the column is not found or the new name is already used.
+func (dt *Table) RenameCol(name, newName string) error {
+	ci, err := dt.ColIdxTry(name)
+	if err != nil {
+		return err
+	}
+	if newName == name {
+		return nil
+	}
+	if _, has := dt.ColNameMap[newName]; has {
+		return fmt.Errorf("etable.RenameCol: column named: %v already exists", newName)
+	}
+	dt.ColNames[ci] = newName
+	dt.UpdateColNameMap()
+	var keys []string
+	for k := range dt.MetaData {
+		if cn, _, ok := splitColMetaKey(k); ok && cn == name {
+			keys = append(keys, k)
+		}
+	}
+	for _, k := range keys {
+		_, key, _ := splitColMetaKey(k)
+		v := dt.MetaData[k]
+		delete(dt.MetaData, k)
+		dt.MetaData[newName+":"+key] = v
+	}
+	return nil
+}
+
 // AddRows adds n rows to each of the columns
 func (dt *Table) AddRows(n int) {
 	dt.SetNumRows(dt.Rows + n)
@@ -260,7 +291,10 @@ func (dt *Table) Clone() *Table {
 	return cp
 }
 
-// AppendRows appends shared columns in both tables with input table rows
+// AppendRows appends shared columns in both tables with input table rows.
+// The meta data of the shared columns is merged, with the values in this
+// table taking precedence, and any keys only in the input table added
+// (see MergeColMetaData).
 func (dt *Table) AppendRows(dt2 *Table) {
 	shared := false
 	strow := dt.NumRows()
@@ -274,6 +308,52 @@ func (dt *Table) AppendRows(dt2 *Table) {
 			for iRow := 0; iRow < dt2.NumRows(); iRow++ {
 				dt.CopyCell(colName, iRow+strow, dt2, colName, iRow)
 			}
+			dt.MergeColMetaData(dt2, colName)
+		}
+	}
+}
+
+// AppendCols appends copies of the columns of input table that are not already
+// in this table (by name), which must have the same number of rows.
+// The column meta data of the appended columns is copied, merged as in
+// MergeColMetaData.  Returns an error if the number of rows differ.
+func (dt *Table) AppendCols(dt2 *Table) error {
+	if dt2.Rows != dt.Rows {
+		return fmt.Errorf("etable.AppendCols: number of rows %v in input table != %v in this table", dt2.Rows, dt.Rows)
+	}
+	for ci, cl := range dt2.Cols {
+		colName := dt2.ColNames[ci]
+		if dt.ColIdx(colName) != -1 {
+			continue
+		}
+		dt.AddCol(cl.Clone(), colName)
+		dt.MergeColMetaData(dt2, colName)
+	}
+	return nil
+}
+
+// MergeColMetaData merges the meta data for given column name from the input
+// table into this table: keys that are already set in this table keep their
+// values, and keys only set in the input table are added.  This applies both
+// to the ColName:key column meta data in the table MetaData and to the meta
+// data of the column tensors themselves (if the column is in both tables).
+func (dt *Table) MergeColMetaData(dt2 *Table, colName string) {
+	for k, v := range dt2.MetaData {
+		if cn, _, ok := splitColMetaKey(k); !ok || cn != colName {
+			continue
+		}
+		if _, has := dt.MetaData[k]; !has {
+			dt.SetMetaData(k, v)
+		}
+	}
+	cl := dt.ColByName(colName)
+	cl2 := dt2.ColByName(colName)
+	if cl == nil || cl2 == nil {
+		return
+	}
+	for k, v := range cl2.MetaDataMap() {
+		if _, has := cl.MetaData(k); !has {
+			cl.SetMetaData(k, v)
 		}
 	}
 }
@@ -285,6 +365,8 @@ func (dt *Table) AppendRows(dt2 *Table) {
 // * read-only  -- makes gui read-only (inactive edits) for etview.TableView
 // * ColName:* -- prefix for all column-specific meta-data
 //     + desc -- description of column
+//     + unit -- units of the column values (e.g., ms), shown in plot axis labels
+//     + format -- printf-style format for the column values (e.g., %.2f), used for plot tick labels, or time for Unix timestamps shown as dates / times on a plot X axis
 func (dt *Table) SetMetaData(key, val string) {
 	if dt.MetaData == nil {
 		dt.MetaData = make(map[string]string)
@@ -306,6 +388,51 @@ func (dt *Table) CopyMetaDataFrom(cp *Table) {
 	}
 }
 
+// ColMetaData returns the value of given column-specific meta-data key
+// for given column name, stored in MetaData as ColName:key, and whether it was set
+func (dt *Table) ColMetaData(colNm, key string) (string, bool) {
+	val, has := dt.MetaData[colNm+":"+key]
+	return val, has
+}
+
+// splitColMetaKey splits a ColName:key MetaData key into the column name
+// and the key, at the last ":" (so column names can contain ":", but keys
+// cannot), returning false if there is no ":".
+func splitColMetaKey(k string) (colNm, key string, ok bool) {
+	ki := strings.LastIndex(k, ":")
+	if ki < 0 {
+		return "", k, false
+	}
+	return k[:ki], k[ki+1:], true
+}
+
+// SetColMetaData sets given column-specific meta-data key to given value,
+// for given column name, stored in MetaData as ColName:key
+func (dt *Table) SetColMetaData(colNm, key, val string) {
+	dt.SetMetaData(colNm+":"+key, val)
+}
+
+// ColUnit returns the units of the values of given column name, from the
+// ColName:unit meta-data -- empty if not set
+func (dt *Table) ColUnit(colNm string) string {
+	val, _ := dt.ColMetaData(colNm, "unit")
+	return val
+}
+
+// ColFormat returns the printf-style format for the values of given column
+// name, from the ColName:format meta-data -- empty if not set
+func (dt *Table) ColFormat(colNm string) string {
+	val, _ := dt.ColMetaData(colNm, "format")
+	return val
+}
+
+// ColDesc returns the description of given column name, from the
+// ColName:desc meta-data -- empty if not set
+func (dt *Table) ColDesc(colNm string) string {
+	val, _ := dt.ColMetaData(colNm, "desc")
+	return val
+}
+
 // Named arg values for Contains, IgnoreCase
 const (
 	// Contains means the string only needs to contain the target string (see Equals)
@@ -370,6 +497,50 @@ func (dt *Table) RowsByStringTry(colNm string, str string, contains, ignoreCase
 	return dt.RowsByStringIdx(ci, str, contains, ignoreCase), nil
 }
 
+// FindRows returns the list of rows where the given predicate function
+// returns true for the float64 value in given column name, which must be
+// a 1-dimensional column.  Null values are skipped, without calling pred.
+// This is a lighter-weight alternative to filtering an IdxView when just
+// the row indexes are needed.  Returns error for an invalid column name.
+func (dt *Table) FindRows(colNm string, pred func(val float64) bool) ([]int, error) {
+	col, err := dt.ColByNameTry(colNm)
+	if err != nil {
+		return nil, err
+	}
+	if col.NumDims() > 1 {
+		return nil, fmt.Errorf("etable.Table FindRows: column %v is not 1-dimensional", colNm)
+	}
+	var idxs []int
+	for i := 0; i < dt.Rows; i++ {
+		if !col.IsNull1D(i) && pred(col.FloatVal1D(i)) {
+			idxs = append(idxs, i)
+		}
+	}
+	return idxs, nil
+}
+
+// FindRowsString returns the list of rows where the given predicate function
+// returns true for the string value in given column name, which must be
+// a 1-dimensional column.  Null values are skipped, without calling pred.
+// See RowsByString for standard string matching.
+// Returns error for an invalid column name.
+func (dt *Table) FindRowsString(colNm string, pred func(val string) bool) ([]int, error) {
+	col, err := dt.ColByNameTry(colNm)
+	if err != nil {
+		return nil, err
+	}
+	if col.NumDims() > 1 {
+		return nil, fmt.Errorf("etable.Table FindRowsString: column %v is not 1-dimensional", colNm)
+	}
+	var idxs []int
+	for i := 0; i < dt.Rows; i++ {
+		if !col.IsNull1D(i) && pred(col.StringVal1D(i)) {
+			idxs = append(idxs, i)
+		}
+	}
+	return idxs, nil
+}
+
 //////////////////////////////////////////////////////////////////////////////////////
 //  Cell convenience access methods
 
@@ -421,6 +592,28 @@ func (dt *Table) CellFloatTry(colNm string, row int) (float64, error) {
 	return ct.FloatVal1D(row), nil
 }
 
+// CellFloatNullTry returns the float64 value of cell at given column (by name), row index
+// for columns that have 1-dimensional tensors, along with whether the cell is flagged
+// as Null, in which case the value is not meaningful.  This is a null-aware version of
+// CellFloatTry (which returns the stored value of Null cells as if it were valid).
+// Returns an error if column not found, or column is not a 1-dimensional tensor, or row not valid.
+func (dt *Table) CellFloatNullTry(colNm string, row int) (val float64, isNull bool, err error) {
+	if err = dt.IsValidRowTry(row); err != nil {
+		return math.NaN(), false, err
+	}
+	ct, err := dt.ColByNameTry(colNm)
+	if err != nil {
+		return math.NaN(), false, err
+	}
+	if ct.NumDims() != 1 {
+		return math.NaN(), false, fmt.Errorf("etable.Table: CellFloatNullTry called on column named: %v which is not 1-dimensional", colNm)
+	}
+	if ct.IsNull1D(row) {
+		return math.NaN(), true, nil
+	}
+	return ct.FloatVal1D(row), false, nil
+}
+
 // CellStringIdx returns the string value of cell at given column, row index
 // for columns that have 1-dimensional tensors.
 // Returns "" if column is not a 1-dimensional tensor or row not valid.
@@ -469,6 +662,27 @@ func (dt *Table) CellStringTry(colNm string, row int) (string, error) {
 	return ct.StringVal1D(row), nil
 }
 
+// CellStringNullTry returns the string value of cell at given column (by name), row index
+// for columns that have 1-dimensional tensors, along with whether the cell is flagged
+// as Null, in which case the value is "".  This is a null-aware version of CellStringTry.
+// Returns an error if column not found, or column is not a 1-dimensional tensor, or row not valid.
+func (dt *Table) CellStringNullTry(colNm string, row int) (val string, isNull bool, err error) {
+	if err = dt.IsValidRowTry(row); err != nil {
+		return "", false, err
+	}
+	ct, err := dt.ColByNameTry(colNm)
+	if err != nil {
+		return "", false, err
+	}
+	if ct.NumDims() != 1 {
+		return "", false, fmt.Errorf("etable.Table: CellStringNullTry called on column named: %v which is not 1-dimensional", colNm)
+	}
+	if ct.IsNull1D(row) {
+		return "", true, nil
+	}
+	return ct.StringVal1D(row), false, nil
+}
+
 // CellTensorIdx returns the tensor SubSpace for given column, row index
 // for columns that have higher-dimensional tensors so each row is
 // represented by an n-1 dimensional tensor, with the outer dimension
@@ -673,6 +887,48 @@ func (dt *Table) SetCellStringTry(colNm string, row int, val string) error {
 	return nil
 }
 
+// SetCellFloatName sets the float64 value of cell at given column (by name), row index
+// for columns that have 1-dimensional tensors, adding a new row to the table if
+// row == Rows, for convenient incremental logging.
+// Returns an error if column not found, column is not a 1-dimensional tensor,
+// or row is otherwise out of range.
+func (dt *Table) SetCellFloatName(colNm string, row int, val float64) error {
+	if err := dt.growCellRow(colNm, row); err != nil {
+		return err
+	}
+	return dt.SetCellFloatTry(colNm, row, val)
+}
+
+// SetCellStringName sets the string value of cell at given column (by name), row index
+// for columns that have 1-dimensional tensors, adding a new row to the table if
+// row == Rows, for convenient incremental logging.
+// Returns an error if column not found, column is not a 1-dimensional tensor,
+// or row is otherwise out of range.
+func (dt *Table) SetCellStringName(colNm string, row int, val string) error {
+	if err := dt.growCellRow(colNm, row); err != nil {
+		return err
+	}
+	return dt.SetCellStringTry(colNm, row, val)
+}
+
+// growCellRow checks that given column exists and is 1-dimensional, and that
+// row is valid or == Rows, in which case it adds a row -- the table is left
+// unchanged if an error is returned.
+func (dt *Table) growCellRow(colNm string, row int) error {
+	ct, err := dt.ColByNameTry(colNm)
+	if err != nil {
+		return err
+	}
+	if ct.NumDims() != 1 {
+		return fmt.Errorf("etable.Table: SetCell*Name called on column named: %v which is not 1-dimensional", colNm)
+	}
+	if row == dt.Rows {
+		dt.AddRows(1)
+		return nil
+	}
+	return dt.IsValidRowTry(row)
+}
+
 // SetCellTensorIdx sets the tensor value of cell at given column, row index
 // for columns that have n-dimensional tensors.  Returns true if set.
 func (dt *Table) SetCellTensorIdx(col, row int, val etensor.Tensor) bool {
@@ -803,6 +1059,41 @@ func (dt *Table) CopyCell(colNm string, row int, cpt *Table, cpColNm string, cpR
 	return nil
 }
 
+// CopyCell copies the entire cell at srcCol, srcRow in the src table into the
+// cell at dstCol, dstRow in the dst table, using CopyCellsFrom, which converts
+// between types as needed and copies any Null flags.  Unlike the Table.CopyCell
+// method, the cell shapes must match exactly: returns an error if they do not,
+// or if the column names or rows are invalid.
+func CopyCell(dst *Table, dstCol string, dstRow int, src *Table, srcCol string, srcRow int) error {
+	dct, err := dst.ColByNameTry(dstCol)
+	if err != nil {
+		return err
+	}
+	sct, err := src.ColByNameTry(srcCol)
+	if err != nil {
+		return err
+	}
+	if err := dst.IsValidRowTry(dstRow); err != nil {
+		return err
+	}
+	if err := src.IsValidRowTry(srcRow); err != nil {
+		return err
+	}
+	dsh := dct.Shapes()[1:]
+	ssh := sct.Shapes()[1:]
+	if len(dsh) != len(ssh) {
+		return fmt.Errorf("etable.CopyCell: cell shape of %v: %v does not match shape of %v: %v", dstCol, dsh, srcCol, ssh)
+	}
+	for i := range dsh {
+		if dsh[i] != ssh[i] {
+			return fmt.Errorf("etable.CopyCell: cell shape of %v: %v does not match shape of %v: %v", dstCol, dsh, srcCol, ssh)
+		}
+	}
+	_, csz := dct.RowCellSize()
+	dct.CopyCellsFrom(sct, dstRow*csz, srcRow*csz, csz)
+	return nil
+}
+
 //////////////////////////////////////////////////////////////////////////////////////
 //  Table props for gui
 
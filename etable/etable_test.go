@@ -5,6 +5,7 @@
 package etable
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/emer/etable/etensor"
@@ -38,3 +39,230 @@ func TestAdd4DCol(t *testing.T) {
 		t.Errorf("Add4DCol: dim 0 len != 16, was: %v\n", col.Dim(3))
 	}
 }
+
+func TestSetCellName(t *testing.T) {
+	dt := New(Schema{{"Name", etensor.STRING, nil, nil}, {"Val", etensor.FLOAT64, nil, nil}}, 0)
+	for i := 0; i < 3; i++ {
+		if err := dt.SetCellStringName("Name", i, "r"); err != nil {
+			t.Fatal(err)
+		}
+		if err := dt.SetCellFloatName("Val", i, float64(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if dt.Rows != 3 || dt.CellFloat("Val", 2) != 2 || dt.CellString("Name", 1) != "r" {
+		t.Errorf("SetCellName: auto-grow failed: rows %v\n", dt.Rows)
+	}
+	if err := dt.SetCellFloatName("Val", 5, 1); err == nil || dt.Rows != 3 {
+		t.Errorf("SetCellFloatName: expected error for row beyond Rows\n")
+	}
+	if err := dt.SetCellFloatName("Nope", 3, 1); err == nil || dt.Rows != 3 {
+		t.Errorf("SetCellFloatName: expected error for unknown column, without growing\n")
+	}
+	dt.AddCol(etensor.NewFloat64([]int{3, 2}, nil, nil), "Vec")
+	if err := dt.SetCellFloatName("Vec", 3, 1); err == nil || dt.Rows != 3 {
+		t.Errorf("SetCellFloatName: expected error for tensor column, without growing: rows %v\n", dt.Rows)
+	}
+	if err := dt.SetCellStringName("Vec", 3, "x"); err == nil || dt.Rows != 3 {
+		t.Errorf("SetCellStringName: expected error for tensor column, without growing: rows %v\n", dt.Rows)
+	}
+}
+
+func TestCopyCell(t *testing.T) {
+	src := New(Schema{{"Pat", etensor.FLOAT32, []int{2, 2}, nil}, {"Bad", etensor.FLOAT32, []int{4}, nil}}, 3)
+	for i := 0; i < 4; i++ {
+		src.SetCellTensorFloat1D("Pat", 1, i, float64(i+1))
+	}
+	src.ColByName("Pat").SetNull1D(1*4+2, true)
+	dst := New(Schema{{"Res", etensor.FLOAT64, []int{2, 2}, nil}}, 2)
+	if err := CopyCell(dst, "Res", 0, src, "Pat", 1); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		if v := dst.CellTensorFloat1D("Res", 0, i); v != float64(i+1) {
+			t.Errorf("CopyCell: value %v: %v != %v\n", i, v, i+1)
+		}
+		if dst.ColByName("Res").IsNull1D(i) != (i == 2) {
+			t.Errorf("CopyCell: null flag %v not copied\n", i)
+		}
+	}
+	if dst.CellTensorFloat1D("Res", 1, 0) != 0 {
+		t.Errorf("CopyCell: copied into wrong row\n")
+	}
+	if err := CopyCell(dst, "Res", 1, src, "Bad", 0); err == nil {
+		t.Errorf("CopyCell: expected error for shape mismatch\n")
+	}
+	if err := CopyCell(dst, "Res", 2, src, "Pat", 0); err == nil {
+		t.Errorf("CopyCell: expected error for invalid row\n")
+	}
+}
+
+func TestFindRows(t *testing.T) {
+	dt := New(Schema{
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"Name", etensor.STRING, nil, nil},
+	}, 6)
+	for ri := 0; ri < 6; ri++ {
+		dt.SetCellFloat("Val", ri, float64(ri*ri))
+		dt.SetCellString("Name", ri, []string{"apple", "bean", "avocado", "corn", "apricot", "date"}[ri])
+	}
+	dt.Cols[0].SetNull1D(4, true)
+	rows, err := dt.FindRows("Val", func(v float64) bool { return v > 3 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !etensor.EqualInts(rows, []int{2, 3, 5}) {
+		t.Errorf("FindRows: %v, expected [2 3 5]\n", rows)
+	}
+	rows, err = dt.FindRowsString("Name", func(s string) bool { return s[0] == 'a' })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !etensor.EqualInts(rows, []int{0, 2, 4}) {
+		t.Errorf("FindRowsString: %v, expected [0 2 4]\n", rows)
+	}
+	if _, err := dt.FindRows("Nope", func(v float64) bool { return true }); err == nil {
+		t.Errorf("FindRows: no error for bad column name\n")
+	}
+}
+
+func TestColMetaData(t *testing.T) {
+	dt := New(Schema{{"Time", etensor.FLOAT64, nil, nil}}, 1)
+	if dt.ColUnit("Time") != "" || dt.ColFormat("Time") != "" || dt.ColDesc("Time") != "" {
+		t.Errorf("ColMetaData: not empty when not set\n")
+	}
+	dt.SetColMetaData("Time", "unit", "ms")
+	dt.SetColMetaData("Time", "format", "%.1f")
+	dt.SetColMetaData("Time", "desc", "trial time")
+	if dt.ColUnit("Time") != "ms" || dt.ColFormat("Time") != "%.1f" || dt.ColDesc("Time") != "trial time" {
+		t.Errorf("ColMetaData: %v %v %v\n", dt.ColUnit("Time"), dt.ColFormat("Time"), dt.ColDesc("Time"))
+	}
+	if dt.MetaData["Time:unit"] != "ms" {
+		t.Errorf("ColMetaData: not stored as ColName:key: %v\n", dt.MetaData)
+	}
+}
+
+func TestCellNullTry(t *testing.T) {
+	dt := New(Schema{
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"Name", etensor.STRING, nil, nil},
+	}, 2)
+	dt.SetCellFloat("Val", 0, 1.5)
+	dt.SetCellString("Name", 0, "a")
+	dt.Cols[0].SetNull1D(1, true)
+	dt.Cols[1].SetNull1D(1, true)
+	if v, isNull, err := dt.CellFloatNullTry("Val", 0); err != nil || isNull || v != 1.5 {
+		t.Errorf("CellFloatNullTry: present: %v %v %v\n", v, isNull, err)
+	}
+	if _, isNull, err := dt.CellFloatNullTry("Val", 1); err != nil || !isNull {
+		t.Errorf("CellFloatNullTry: null: %v %v\n", isNull, err)
+	}
+	if s, isNull, err := dt.CellStringNullTry("Name", 0); err != nil || isNull || s != "a" {
+		t.Errorf("CellStringNullTry: present: %v %v %v\n", s, isNull, err)
+	}
+	if s, isNull, err := dt.CellStringNullTry("Name", 1); err != nil || !isNull || s != "" {
+		t.Errorf("CellStringNullTry: null: %v %v %v\n", s, isNull, err)
+	}
+	if _, _, err := dt.CellFloatNullTry("Val", 2); err == nil {
+		t.Errorf("CellFloatNullTry: no error for out-of-range row\n")
+	}
+	if _, _, err := dt.CellStringNullTry("Name", -1); err == nil {
+		t.Errorf("CellStringNullTry: no error for out-of-range row\n")
+	}
+	if _, _, err := dt.CellFloatNullTry("Nope", 0); err == nil {
+		t.Errorf("CellFloatNullTry: no error for unknown column\n")
+	}
+	if _, _, err := dt.CellStringNullTry("Nope", 0); err == nil {
+		t.Errorf("CellStringNullTry: no error for unknown column\n")
+	}
+}
+
+func TestAppendMetaData(t *testing.T) {
+	sc := Schema{{"Val", etensor.FLOAT64, nil, nil}}
+	dt := New(sc, 2)
+	dt.SetColMetaData("Val", "unit", "ms")
+	dt.Cols[0].SetMetaData("min", "0")
+	dt2 := New(sc, 3)
+	dt2.SetColMetaData("Val", "unit", "s")
+	dt2.SetColMetaData("Val", "format", "%.2f")
+	dt2.Cols[0].SetMetaData("min", "-1")
+	dt2.Cols[0].SetMetaData("max", "1")
+	dt2.AddCol(etensor.NewString([]int{3}, nil, nil), "Name")
+	dt2.SetColMetaData("Name", "desc", "item name")
+	dt.AppendRows(dt2)
+	if dt.Rows != 5 || dt.ColUnit("Val") != "ms" || dt.ColFormat("Val") != "%.2f" {
+		t.Errorf("AppendRows: rows %v unit %q format %q, expected 5 ms %%.2f\n", dt.Rows, dt.ColUnit("Val"), dt.ColFormat("Val"))
+	}
+	if mn, _ := dt.Cols[0].MetaData("min"); mn != "0" {
+		t.Errorf("AppendRows: tensor meta data min %q, expected 0\n", mn)
+	}
+	if mx, _ := dt.Cols[0].MetaData("max"); mx != "1" {
+		t.Errorf("AppendRows: tensor meta data max %q, expected 1\n", mx)
+	}
+	if dt.ColDesc("Name") != "" {
+		t.Errorf("AppendRows: meta data added for column not in table\n")
+	}
+
+	dt3 := New(sc, 3)
+	if err := dt3.AppendCols(dt2); err != nil {
+		t.Fatal(err)
+	}
+	if dt3.NumCols() != 2 || dt3.ColDesc("Name") != "item name" || dt3.ColUnit("Val") != "" {
+		t.Errorf("AppendCols: cols %v desc %q unit %q\n", dt3.NumCols(), dt3.ColDesc("Name"), dt3.ColUnit("Val"))
+	}
+	if err := dt.AppendCols(dt2); err == nil {
+		t.Errorf("AppendCols: no error for different number of rows\n")
+	}
+}
+
+func TestColOrder(t *testing.T) {
+	dt := New(Schema{{"A", etensor.FLOAT64, nil, nil}, {"B", etensor.STRING, nil, nil}}, 3)
+	checkOrder := func(exp []string) {
+		t.Helper()
+		if !reflect.DeepEqual(dt.ColNames, exp) {
+			t.Errorf("ColNames: %v, expected %v\n", dt.ColNames, exp)
+		}
+		for ci, cl := range dt.Cols {
+			if dt.ColIdx(dt.ColNames[ci]) != ci || dt.ColByName(dt.ColNames[ci]) != cl {
+				t.Errorf("column %v: %v does not map to its position in Cols\n", ci, dt.ColNames[ci])
+			}
+		}
+	}
+	for _, nm := range []string{"C", "D", "E"} {
+		dt.AddCol(etensor.NewFloat64([]int{3}, nil, nil), nm)
+	}
+	checkOrder([]string{"A", "B", "C", "D", "E"})
+	dt.DeleteColName("B")
+	checkOrder([]string{"A", "C", "D", "E"})
+	dt.SetMetaData("D:desc", "dee")
+	if err := dt.RenameCol("D", "Z"); err != nil {
+		t.Fatal(err)
+	}
+	checkOrder([]string{"A", "C", "Z", "E"})
+	if dt.MetaData["Z:desc"] != "dee" || dt.MetaData["D:desc"] != "" {
+		t.Errorf("RenameCol: meta data not renamed: %v\n", dt.MetaData)
+	}
+	if err := dt.RenameCol("A", "E"); err == nil {
+		t.Errorf("RenameCol: no error for existing name\n")
+	}
+	if err := dt.RenameCol("Q", "R"); err == nil {
+		t.Errorf("RenameCol: no error for missing column\n")
+	}
+	dt.AddCol(etensor.NewFloat64([]int{3}, nil, nil), "D")
+	checkOrder([]string{"A", "C", "Z", "E", "D"})
+
+	// a column name that is a prefix of another
+	dt.AddCol(etensor.NewFloat64([]int{3}, nil, nil), "A:B")
+	dt.SetMetaData("A:desc", "ay")
+	dt.SetMetaData("A:B:desc", "ay bee")
+	if err := dt.RenameCol("A", "New"); err != nil {
+		t.Fatal(err)
+	}
+	if dt.MetaData["New:desc"] != "ay" || dt.MetaData["A:B:desc"] != "ay bee" {
+		t.Errorf("RenameCol: other column meta data changed: %v\n", dt.MetaData)
+	}
+	if _, has := dt.MetaData["New:B:desc"]; has {
+		t.Errorf("RenameCol: renamed meta data of column A:B\n")
+	}
+	checkOrder([]string{"New", "C", "Z", "E", "D", "A:B"})
+}
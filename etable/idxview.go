@@ -11,8 +11,10 @@ import (
 	"math/rand"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/emer/etable/etensor"
+	"github.com/goki/ki/ints"
 	"github.com/goki/ki/ki"
 	"github.com/goki/ki/kit"
 	"github.com/goki/ki/sliceclone"
@@ -38,9 +40,17 @@ type FilterFunc func(et *Table, row int) bool
 // IdxView views on a table can also be organized together as Splits
 // of the table rows, e.g., by grouping values along a given column.
 type IdxView struct {
-	Table    *Table   `desc:"Table that we are an indexed view onto"`
-	Idxs     []int    `desc:"current indexes into Table"`
-	lessFunc LessFunc `copy:"-" view:"-" xml:"-" json:"-" desc:"current Less function used in sorting"`
+	Table    *Table                 `desc:"Table that we are an indexed view onto"`
+	Idxs     []int                  `desc:"current indexes into Table"`
+	lessFunc LessFunc               `copy:"-" view:"-" xml:"-" json:"-" desc:"current Less function used in sorting"`
+	gen      int                    `copy:"-" view:"-" xml:"-" json:"-" desc:"generation counter, incremented whenever the indexes change, used to invalidate aggCache"`
+	aggCache map[string]aggCacheVal `copy:"-" view:"-" xml:"-" json:"-" desc:"cached AggColCached results"`
+}
+
+// aggCacheVal is a cached aggregation result, valid for generation gen of the indexes
+type aggCacheVal struct {
+	gen int
+	agg []float64
 }
 
 var KiT_IdxView = kit.Types.AddType(&IdxView{}, IdxViewProps)
@@ -61,6 +71,7 @@ func (ix *IdxView) SetTable(et *Table) {
 // DeleteInvalid deletes all invalid indexes from the list.
 // Call this if rows (could) have been deleted from table.
 func (ix *IdxView) DeleteInvalid() {
+	defer ix.IdxsChanged()
 	if ix.Table == nil || ix.Table.Rows <= 0 {
 		ix.Idxs = nil
 		return
@@ -75,6 +86,7 @@ func (ix *IdxView) DeleteInvalid() {
 
 // Sequential sets indexes to sequential row-wise indexes into table
 func (ix *IdxView) Sequential() {
+	defer ix.IdxsChanged()
 	if ix.Table == nil || ix.Table.Rows <= 0 {
 		ix.Idxs = nil
 		return
@@ -89,6 +101,7 @@ func (ix *IdxView) Sequential() {
 // then existing list of indexes is permuted, otherwise a new set of
 // permuted indexes are generated
 func (ix *IdxView) Permuted() {
+	defer ix.IdxsChanged()
 	if ix.Table == nil || ix.Table.Rows <= 0 {
 		ix.Idxs = nil
 		return
@@ -102,8 +115,158 @@ func (ix *IdxView) Permuted() {
 	}
 }
 
+// PermuteTables applies the same random permutation to the indexes of all of
+// the given views, e.g., to shuffle a data table along with a paired table of
+// labels, so the rows of the views remain in correspondence.  All of the views
+// must have the same number of indexes -- returns an error otherwise, without
+// changing any of them.  Uses given random number source, or the global one if nil.
+func PermuteTables(rnd *rand.Rand, views ...*IdxView) error {
+	if len(views) == 0 {
+		return nil
+	}
+	n := len(views[0].Idxs)
+	for vi, ix := range views {
+		if len(ix.Idxs) != n {
+			return fmt.Errorf("etable.PermuteTables: view %d has %d indexes, not %d as in view 0", vi, len(ix.Idxs), n)
+		}
+	}
+	var perm []int
+	if rnd != nil {
+		perm = rnd.Perm(n)
+	} else {
+		perm = rand.Perm(n)
+	}
+	nidx := make([]int, n)
+	for _, ix := range views {
+		for i, pi := range perm {
+			nidx[i] = ix.Idxs[pi]
+		}
+		copy(ix.Idxs, nidx)
+		ix.IdxsChanged()
+	}
+	return nil
+}
+
+// SampleWeighted returns a new view with n rows sampled from the rows of this
+// view, with probability proportional to the values in the given (1D) weight
+// column -- e.g., for importance sampling or bootstrapping.  If replace is true,
+// rows are sampled with replacement, so rows can be drawn multiple times,
+// and otherwise each row is drawn at most once, sampling successively from the
+// remaining rows, in which case fewer than n rows are returned if there are not
+// enough rows with positive weights.  Negative, Null or NaN weights are treated
+// as zero, so those rows are never drawn.  Uses given random number source,
+// or the global one if nil.
+func (ix *IdxView) SampleWeighted(n int, weightIdx int, replace bool, rnd *rand.Rand) *IdxView {
+	nix := &IdxView{Table: ix.Table}
+	wcl := ix.Table.Cols[weightIdx]
+	ufun := rand.Float64
+	if rnd != nil {
+		ufun = rnd.Float64
+	}
+	wts := make([]float64, len(ix.Idxs))
+	for i, row := range ix.Idxs {
+		if w := wcl.FloatVal1D(row); !wcl.IsNull1D(row) && w > 0 {
+			wts[i] = w
+		}
+	}
+	if replace {
+		cum := make([]float64, len(wts))
+		sum := 0.0
+		for i, w := range wts {
+			sum += w
+			cum[i] = sum
+		}
+		if sum == 0 {
+			return nix
+		}
+		nix.Idxs = make([]int, n)
+		for i := range nix.Idxs {
+			si := sort.SearchFloat64s(cum, ufun()*sum)
+			for si < len(cum)-1 && wts[si] == 0 { // only if u*sum landed exactly on a boundary
+				si++
+			}
+			nix.Idxs[i] = ix.Idxs[si]
+		}
+		return nix
+	}
+	// exponential keys -log(u) / w: the n smallest are a weighted sample without replacement
+	type rowKey struct {
+		row int
+		key float64
+	}
+	rks := make([]rowKey, 0, len(wts))
+	for i, w := range wts {
+		if w > 0 {
+			rks = append(rks, rowKey{ix.Idxs[i], -math.Log(1-ufun()) / w})
+		}
+	}
+	sort.Slice(rks, func(i, j int) bool { return rks[i].key < rks[j].key })
+	if n > len(rks) {
+		n = len(rks)
+	}
+	nix.Idxs = make([]int, n)
+	for i := range nix.Idxs {
+		nix.Idxs[i] = rks[i].row
+	}
+	return nix
+}
+
+// BootstrapCI returns the mean of the values in the given (1D) column over the
+// rows of this view, with a bootstrap percentile confidence interval [lo, hi] on
+// the mean, at confidence level ci (e.g., .95).  The rows are resampled with
+// replacement nResamples times, and lo and hi are the (1-ci)/2 and (1+ci)/2
+// quantiles of the resample means.  Null and NaN values are excluded from
+// the mean and from each resample's mean (resamples with no valid values are
+// skipped).  Returns NaN for all if there are no valid values.  Uses given
+// random number source, or the global one if nil.
+func (ix *IdxView) BootstrapCI(colIdx int, nResamples int, ci float64, rnd *rand.Rand) (mean, lo, hi float64) {
+	cl := ix.Table.Cols[colIdx]
+	ifun := rand.Intn
+	if rnd != nil {
+		ifun = rnd.Intn
+	}
+	n := len(ix.Idxs)
+	vals := make([]float64, n)
+	valid := make([]bool, n)
+	sum, nv := 0.0, 0
+	for i, row := range ix.Idxs {
+		v := cl.FloatVal1D(row)
+		if !cl.IsNull1D(row) && !math.IsNaN(v) {
+			vals[i] = v
+			valid[i] = true
+			sum += v
+			nv++
+		}
+	}
+	if nv == 0 {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	mean = sum / float64(nv)
+	means := make([]float64, 0, nResamples)
+	for r := 0; r < nResamples; r++ {
+		rsum, rn := 0.0, 0
+		for i := 0; i < n; i++ {
+			if si := ifun(n); valid[si] {
+				rsum += vals[si]
+				rn++
+			}
+		}
+		if rn > 0 {
+			means = append(means, rsum/float64(rn))
+		}
+	}
+	if len(means) == 0 {
+		return mean, mean, mean
+	}
+	sort.Float64s(means)
+	lo = QuantileSorted(means, (1-ci)/2)
+	hi = QuantileSorted(means, (1+ci)/2)
+	return
+}
+
 // AddIndex adds a new index to the list
 func (ix *IdxView) AddIndex(idx int) {
+	defer ix.IdxsChanged()
 	ix.Idxs = append(ix.Idxs, idx)
 }
 
@@ -111,6 +274,7 @@ func (ix *IdxView) AddIndex(idx int) {
 // The Less function operates directly on row numbers into the Table
 // as these row numbers have already been projected through the indexes.
 func (ix *IdxView) Sort(lessFunc func(et *Table, i, j int) bool) {
+	defer ix.IdxsChanged()
 	ix.lessFunc = lessFunc
 	sort.Sort(ix)
 }
@@ -119,6 +283,7 @@ func (ix *IdxView) Sort(lessFunc func(et *Table, i, j int) bool) {
 // numerical order, producing the native ordering, while preserving
 // any filtering that might have occurred.
 func (ix *IdxView) SortIdxs() {
+	defer ix.IdxsChanged()
 	sort.Ints(ix.Idxs)
 }
 
@@ -130,6 +295,27 @@ const (
 	Descending = false
 )
 
+// CompareFloats compares a and b for sorting in given direction, returning -1
+// if a sorts before b, 1 if a sorts after b, and 0 if they are equal.
+// NaN values always sort after all other values in either direction, so that
+// columns with NaN values have a deterministic sorted order.
+func CompareFloats(a, b float64, ascending bool) int {
+	an, bn := math.IsNaN(a), math.IsNaN(b)
+	switch {
+	case an && bn:
+		return 0
+	case an:
+		return 1
+	case bn:
+		return -1
+	case a == b:
+		return 0
+	case (a < b) == ascending:
+		return -1
+	}
+	return 1
+}
+
 // SortColName sorts the indexes into our Table according to values in
 // given column name, using either ascending or descending order.
 // Only valid for 1-dimensional columns.
@@ -146,7 +332,7 @@ func (ix *IdxView) SortColName(colNm string, ascending bool) error {
 
 // SortCol sorts the indexes into our Table according to values in
 // given column index, using either ascending or descending order.
-// Only valid for 1-dimensional columns.
+// Only valid for 1-dimensional columns.  NaN values sort last in either direction.
 func (ix *IdxView) SortCol(colIdx int, ascending bool) {
 	cl := ix.Table.Cols[colIdx]
 	if cl.DataType() == etensor.STRING {
@@ -159,11 +345,7 @@ func (ix *IdxView) SortCol(colIdx int, ascending bool) {
 		})
 	} else {
 		ix.Sort(func(et *Table, i, j int) bool {
-			if ascending {
-				return cl.FloatVal1D(i) < cl.FloatVal1D(j)
-			} else {
-				return cl.FloatVal1D(i) > cl.FloatVal1D(j)
-			}
+			return CompareFloats(cl.FloatVal1D(i), cl.FloatVal1D(j), ascending) < 0
 		})
 	}
 }
@@ -193,6 +375,7 @@ func (ix *IdxView) SortColNames(colNms []string, ascending bool) error {
 // SortCols sorts the indexes into our Table according to values in
 // given list of column indexes, using either ascending or descending order for
 // all of the columns.  Only valid for 1-dimensional columns.
+// NaN values sort last in either direction.
 func (ix *IdxView) SortCols(colIdxs []int, ascending bool) {
 	ix.Sort(func(et *Table, i, j int) bool {
 		for _, ci := range colIdxs {
@@ -212,25 +395,91 @@ func (ix *IdxView) SortCols(colIdxs []int, ascending bool) {
 					} // if equal, fallthrough to next col
 				}
 			} else {
-				if ascending {
-					if cl.FloatVal1D(i) < cl.FloatVal1D(j) {
-						return true
-					} else if cl.FloatVal1D(i) < cl.FloatVal1D(j) {
-						return false
-					} // if equal, fallthrough to next col
-				} else {
-					if cl.FloatVal1D(i) > cl.FloatVal1D(j) {
-						return true
-					} else if cl.FloatVal1D(i) < cl.FloatVal1D(j) {
-						return false
-					} // if equal, fallthrough to next col
-				}
+				if cmp := CompareFloats(cl.FloatVal1D(i), cl.FloatVal1D(j), ascending); cmp != 0 {
+					return cmp < 0
+				} // if equal, fallthrough to next col
 			}
 		}
 		return false
 	})
 }
 
+// SortSpec specifies one sort key for SortByNames
+type SortSpec struct {
+	Col       string `desc:"name of the column to sort by -- must be a 1-dimensional column"`
+	Ascending bool   `desc:"sort in ascending order -- otherwise descending"`
+	Stable    bool   `desc:"if true for any of the specs, a stable sort is used, so rows that are equal on all of the keys keep their existing relative order"`
+}
+
+// SortByNames sorts the indexes into our Table according to the given list of
+// sort specs, in priority order: rows are ordered by the column of the first spec,
+// rows that are equal on that column are ordered by the next one, and so on,
+// with each column in its own direction, and NaN values last.  All of the
+// column names are validated first, and an error is returned without sorting
+// if any is not found or is not a 1-dimensional column.
+func (ix *IdxView) SortByNames(specs []SortSpec) error {
+	if len(specs) == 0 {
+		return fmt.Errorf("etable.IdxView.SortByNames: no sort specs provided")
+	}
+	cls := make([]etensor.Tensor, len(specs))
+	stable := false
+	for i, sp := range specs {
+		cl, err := ix.Table.ColByNameTry(sp.Col)
+		if err != nil {
+			return err
+		}
+		if cl.NumDims() > 1 {
+			return fmt.Errorf("etable.IdxView.SortByNames: column %v is not 1-dimensional", sp.Col)
+		}
+		cls[i] = cl
+		stable = stable || sp.Stable
+	}
+	less := func(et *Table, i, j int) bool {
+		for si, cl := range cls {
+			var cmp int
+			if cl.DataType() == etensor.STRING {
+				cmp = strings.Compare(cl.StringVal1D(i), cl.StringVal1D(j))
+				if !specs[si].Ascending {
+					cmp = -cmp
+				}
+			} else {
+				cmp = CompareFloats(cl.FloatVal1D(i), cl.FloatVal1D(j), specs[si].Ascending)
+			}
+			if cmp != 0 {
+				return cmp < 0
+			} // if equal, fallthrough to next col
+		}
+		return false
+	}
+	if stable {
+		ix.SortStable(less)
+	} else {
+		ix.Sort(less)
+	}
+	return nil
+}
+
+// SortKey sorts the indexes into our Table according to the values returned by
+// given key function for each row (e.g., a value computed from multiple columns),
+// using either ascending or descending order.  The key function is called
+// exactly once per index, and the keys are cached for the sort comparisons,
+// so it is efficient even for expensive key functions.  NaN keys sort last.
+func (ix *IdxView) SortKey(keyFunc func(et *Table, row int) float64, ascending bool) {
+	defer ix.IdxsChanged()
+	type rowKey struct {
+		row int
+		key float64
+	}
+	rks := make([]rowKey, len(ix.Idxs))
+	for i, ri := range ix.Idxs {
+		rks[i] = rowKey{ri, keyFunc(ix.Table, ri)}
+	}
+	sort.SliceStable(rks, func(i, j int) bool { return CompareFloats(rks[i].key, rks[j].key, ascending) < 0 })
+	for i := range rks {
+		ix.Idxs[i] = rks[i].row
+	}
+}
+
 /////////////////////////////////////////////////////////////////////////
 //  Stable sorts -- sometimes essential..
 
@@ -238,6 +487,7 @@ func (ix *IdxView) SortCols(colIdxs []int, ascending bool) {
 // The Less function operates directly on row numbers into the Table
 // as these row numbers have already been projected through the indexes.
 func (ix *IdxView) SortStable(lessFunc func(et *Table, i, j int) bool) {
+	defer ix.IdxsChanged()
 	ix.lessFunc = lessFunc
 	sort.Stable(ix)
 }
@@ -258,7 +508,7 @@ func (ix *IdxView) SortStableColName(colNm string, ascending bool) error {
 
 // SortStableCol sorts the indexes into our Table according to values in
 // given column index, using either ascending or descending order.
-// Only valid for 1-dimensional columns.
+// Only valid for 1-dimensional columns.  NaN values sort last in either direction.
 func (ix *IdxView) SortStableCol(colIdx int, ascending bool) {
 	cl := ix.Table.Cols[colIdx]
 	if cl.DataType() == etensor.STRING {
@@ -271,11 +521,7 @@ func (ix *IdxView) SortStableCol(colIdx int, ascending bool) {
 		})
 	} else {
 		ix.SortStable(func(et *Table, i, j int) bool {
-			if ascending {
-				return cl.FloatVal1D(i) < cl.FloatVal1D(j)
-			} else {
-				return cl.FloatVal1D(i) > cl.FloatVal1D(j)
-			}
+			return CompareFloats(cl.FloatVal1D(i), cl.FloatVal1D(j), ascending) < 0
 		})
 	}
 }
@@ -305,6 +551,7 @@ func (ix *IdxView) SortStableColNames(colNms []string, ascending bool) error {
 // SortStableCols sorts the indexes into our Table according to values in
 // given list of column indexes, using either ascending or descending order for
 // all of the columns.  Only valid for 1-dimensional columns.
+// NaN values sort last in either direction.
 func (ix *IdxView) SortStableCols(colIdxs []int, ascending bool) {
 	ix.SortStable(func(et *Table, i, j int) bool {
 		for _, ci := range colIdxs {
@@ -324,19 +571,9 @@ func (ix *IdxView) SortStableCols(colIdxs []int, ascending bool) {
 					} // if equal, fallthrough to next col
 				}
 			} else {
-				if ascending {
-					if cl.FloatVal1D(i) < cl.FloatVal1D(j) {
-						return true
-					} else if cl.FloatVal1D(i) < cl.FloatVal1D(j) {
-						return false
-					} // if equal, fallthrough to next col
-				} else {
-					if cl.FloatVal1D(i) > cl.FloatVal1D(j) {
-						return true
-					} else if cl.FloatVal1D(i) < cl.FloatVal1D(j) {
-						return false
-					} // if equal, fallthrough to next col
-				}
+				if cmp := CompareFloats(cl.FloatVal1D(i), cl.FloatVal1D(j), ascending); cmp != 0 {
+					return cmp < 0
+				} // if equal, fallthrough to next col
 			}
 		}
 		return false
@@ -347,12 +584,15 @@ func (ix *IdxView) SortStableCols(colIdxs []int, ascending bool) {
 // The Filter function operates directly on row numbers into the Table
 // as these row numbers have already been projected through the indexes.
 func (ix *IdxView) Filter(filterFunc func(et *Table, row int) bool) {
-	sz := len(ix.Idxs)
-	for i := sz - 1; i >= 0; i-- { // always go in reverse for filtering
-		if !filterFunc(ix.Table, ix.Idxs[i]) { // delete
-			ix.Idxs = append(ix.Idxs[:i], ix.Idxs[i+1:]...)
+	defer ix.IdxsChanged()
+	ni := 0
+	for _, row := range ix.Idxs { // compact kept indexes in place, in one pass
+		if filterFunc(ix.Table, row) {
+			ix.Idxs[ni] = row
+			ni++
 		}
 	}
+	ix.Idxs = ix.Idxs[:ni]
 }
 
 // FilterColName filters the indexes into our Table according to values in
@@ -401,16 +641,123 @@ func (ix *IdxView) FilterCol(colIdx int, str string, exclude, contains, ignoreCa
 	})
 }
 
+// FilterUnique filters the indexes into our Table to keep only one row for each
+// distinct combination of values in given column names (using the string
+// representation of all values in each cell), preserving the current order.
+// If keepLast is false, the first row in the current order is kept for each
+// combination, and otherwise the last one is -- e.g., after sorting by time,
+// keepLast keeps the most recent row for each key.
+// Returns error if a column name is not found.
+func (ix *IdxView) FilterUnique(colNms []string, keepLast bool) error {
+	if len(colNms) == 0 {
+		return fmt.Errorf("etable.IdxView.FilterUnique: no column names provided")
+	}
+	cls := make([]etensor.Tensor, len(colNms))
+	for i, cn := range colNms {
+		cl, err := ix.Table.ColByNameTry(cn)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+		cls[i] = cl
+	}
+	var sb strings.Builder
+	key := func(row int) string {
+		sb.Reset()
+		for _, cl := range cls {
+			_, csz := cl.RowCellSize()
+			for j := 0; j < csz; j++ {
+				sb.WriteString(cl.StringVal1D(row*csz + j))
+				sb.WriteByte(0)
+			}
+		}
+		return sb.String()
+	}
+	if keepLast {
+		last := make(map[string]int, len(ix.Idxs))
+		for _, row := range ix.Idxs {
+			last[key(row)] = row
+		}
+		ix.Filter(func(et *Table, row int) bool {
+			return last[key(row)] == row
+		})
+		return nil
+	}
+	seen := make(map[string]bool, len(ix.Idxs))
+	ix.Filter(func(et *Table, row int) bool {
+		k := key(row)
+		if seen[k] {
+			return false
+		}
+		seen[k] = true
+		return true
+	})
+	return nil
+}
+
+// FilterIdxs filters the indexes to only those that are also in the given
+// list of row indexes into the Table, preserving the current order of the
+// indexes.  This is a lower-level alternative to Filter when the rows to
+// keep are already known.
+func (ix *IdxView) FilterIdxs(rows []int) {
+	defer ix.IdxsChanged()
+	keep := make(map[int]bool, len(rows))
+	for _, row := range rows {
+		keep[row] = true
+	}
+	ni := 0
+	for _, row := range ix.Idxs {
+		if keep[row] {
+			ix.Idxs[ni] = row
+			ni++
+		}
+	}
+	ix.Idxs = ix.Idxs[:ni]
+}
+
+// SetIdxs replaces the indexes with a copy of the given list of row indexes
+// into the Table, in the given order.  Returns an error, leaving the indexes
+// unchanged, if any row index is out of range for the Table.
+func (ix *IdxView) SetIdxs(rows []int) error {
+	for _, row := range rows {
+		if row < 0 || row >= ix.Table.Rows {
+			return fmt.Errorf("etable.IdxView SetIdxs: row index %v out of range for Table with %v rows", row, ix.Table.Rows)
+		}
+	}
+	defer ix.IdxsChanged()
+	ix.Idxs = make([]int, len(rows))
+	copy(ix.Idxs, rows)
+	return nil
+}
+
+// NewTableParallelThr is the threshold total number of values (rows * summed
+// cell sizes across columns) above which NewTable copies each column in its own
+// goroutine, to speed up materializing views of large, wide tables.
+var NewTableParallelThr = 100000
+
 // NewTable returns a new table with column data organized according to
-// the indexes
+// the indexes.  For tables with more than NewTableParallelThr values, the
+// columns are copied in parallel.
 func (ix *IdxView) NewTable() *Table {
+	rows := len(ix.Idxs)
+	nvals := 0
+	for _, cl := range ix.Table.Cols {
+		_, csz := cl.RowCellSize()
+		nvals += rows * csz
+	}
+	return ix.newTable(ix.Table.NumCols() > 1 && nvals > NewTableParallelThr)
+}
+
+// newTable returns a new table with column data organized according to
+// the indexes, copying columns in parallel goroutines if parallel is true.
+func (ix *IdxView) newTable(parallel bool) *Table {
 	rows := len(ix.Idxs)
 	sc := ix.Table.Schema()
 	nt := New(sc, rows)
 	if rows == 0 {
 		return nt
 	}
-	for ci := range nt.Cols {
+	cpCol := func(ci int) {
 		scl := ix.Table.Cols[ci]
 		tcl := nt.Cols[ci]
 		_, csz := tcl.RowCellSize()
@@ -418,9 +765,72 @@ func (ix *IdxView) NewTable() *Table {
 			tcl.CopyCellsFrom(scl, i*csz, srw*csz, csz)
 		}
 	}
+	if !parallel {
+		for ci := range nt.Cols {
+			cpCol(ci)
+		}
+		return nt
+	}
+	var wg sync.WaitGroup
+	for ci := range nt.Cols {
+		wg.Add(1)
+		go func(ci int) {
+			cpCol(ci)
+			wg.Done()
+		}(ci)
+	}
+	wg.Wait()
 	return nt
 }
 
+// NewTableCols returns a new table with only the given columns (by index, in
+// the given order), with column data organized according to the indexes.
+// Column tensor meta data is copied, along with the table meta data, except
+// for column-specific ColName: entries for columns that are not selected.
+func (ix *IdxView) NewTableCols(colIdxs []int) *Table {
+	rows := len(ix.Idxs)
+	fsc := ix.Table.Schema()
+	sc := make(Schema, len(colIdxs))
+	for i, ci := range colIdxs {
+		sc[i] = fsc[ci]
+	}
+	nt := New(sc, rows)
+	for k, v := range ix.Table.MetaData {
+		if cn, _, ok := splitColMetaKey(k); ok && cn != "" {
+			if _, iscol := ix.Table.ColNameMap[cn]; iscol {
+				if _, sel := nt.ColNameMap[cn]; !sel {
+					continue
+				}
+			}
+		}
+		nt.SetMetaData(k, v)
+	}
+	for i, ci := range colIdxs {
+		scl := ix.Table.Cols[ci]
+		tcl := nt.Cols[i]
+		tcl.CopyMetaData(scl)
+		if rows == 0 {
+			continue
+		}
+		_, csz := tcl.RowCellSize()
+		for ri, srw := range ix.Idxs {
+			tcl.CopyCellsFrom(scl, ri*csz, srw*csz, csz)
+		}
+	}
+	return nt
+}
+
+// NewTableColNames returns a new table with only the given columns (by name,
+// in the given order), with column data organized according to the indexes
+// -- see NewTableCols.  Returns error if a column name is not found.
+func (ix *IdxView) NewTableColNames(colNms []string) (*Table, error) {
+	cis, err := ix.Table.ColIdxsByNamesTry(colNms)
+	if err != nil {
+		return nil, err
+	}
+	return ix.NewTableCols(cis), nil
+}
+
 // AggCol applies given aggregation function to each element in the given column, using float64
 // conversions of the values.  init is the initial value for the agg variable.
 // Operates independently over each cell on n-dimensional columns and returns the result as a slice
@@ -454,6 +864,150 @@ func (ix *IdxView) AggCol(colIdx int, ini float64, fun etensor.AggFunc) []float6
 	return ag
 }
 
+// AggColByGroup applies given aggregation function to each element in the given
+// column, as in AggCol, separately for each group of rows having the same values
+// in the given list of group columns, which must be 1-dimensional.  Returns the
+// key values and the per-cell aggregate results for each group, aligned by index.
+// Groups are in the same order as split.GroupBy: ascending by key values,
+// compared column by column, with strings lexical and numbers numeric (NaN last).
+// This is a lower-level alternative to split.AggTry for use of the raw values.
+func (ix *IdxView) AggColByGroup(groupCols []int, aggCol int, ini float64, fun etensor.AggFunc) (keys [][]string, vals [][]float64) {
+	if len(groupCols) == 0 {
+		return [][]string{{}}, [][]float64{ix.AggCol(aggCol, ini, fun)}
+	}
+	spl := &Splits{}
+	spl.SetGroups(ix, groupCols...)
+	ag := spl.AggCol("", aggCol, ini, fun)
+	return spl.Values, ag.Aggs
+}
+
+// AggCellCol applies given aggregation function across all of the elements
+// within the cell of each row of the given column, using float64 conversions
+// of the values.  init is the initial value for the agg variable.
+// This is the complement of AggCol, which aggregates across rows: it returns
+// the result as a slice of values per row in the view.  Null and NaN
+// elements are skipped.
+func (ix *IdxView) AggCellCol(colIdx int, ini float64, fun etensor.AggFunc) []float64 {
+	cl := ix.Table.Cols[colIdx]
+	_, csz := cl.RowCellSize()
+
+	ag := make([]float64, len(ix.Idxs))
+	for i, srw := range ix.Idxs {
+		ag[i] = ini
+		si := srw * csz
+		for j := si; j < si+csz; j++ {
+			val := cl.FloatVal1D(j)
+			if !cl.IsNull1D(j) && !math.IsNaN(val) {
+				ag[i] = fun(j, val, ag[i])
+			}
+		}
+	}
+	return ag
+}
+
+// WeightedAggFunc is an aggregation function that incrementally updates agg value
+// from each element val, weighted by wt, in a tensor -- see etensor.AggFunc
+type WeightedAggFunc func(idx int, val, wt float64, agg float64) float64
+
+// AggColWeighted applies given weighted aggregation function to each element in
+// the given column, passing the value in the weights column wtIdx for the
+// same row as the weight, using float64 conversions of the values.
+// init is the initial value for the agg variable.  The weights column must
+// be a scalar (1D) column, with the same weight applying to all cells of an
+// n-dimensional value column.  Elements where either the value or the weight
+// is Null or NaN are skipped.  Returns the result as a slice of values per cell.
+func (ix *IdxView) AggColWeighted(colIdx, wtIdx int, ini float64, fun WeightedAggFunc) []float64 {
+	cl := ix.Table.Cols[colIdx]
+	wcl := ix.Table.Cols[wtIdx]
+	_, csz := cl.RowCellSize()
+
+	ag := make([]float64, csz)
+	for i := range ag {
+		ag[i] = ini
+	}
+	for _, srw := range ix.Idxs {
+		wt := wcl.FloatVal1D(srw)
+		if wcl.IsNull1D(srw) || math.IsNaN(wt) {
+			continue
+		}
+		si := srw * csz
+		for j := range ag {
+			val := cl.FloatVal1D(si + j)
+			if !cl.IsNull1D(si+j) && !math.IsNaN(val) {
+				ag[j] = fun(si+j, val, wt, ag[j])
+			}
+		}
+	}
+	return ag
+}
+
+// RollingAgg applies given aggregation function over a trailing sliding window
+// of window rows, in the current order of the indexes, for each row of the given
+// column, using float64 conversions of the values.  init is the initial value for
+// the agg variable in each window.  The windows at the start shrink to include
+// only the rows available (i.e., the first result aggregates just the first row),
+// so callers wanting only full windows should skip the first window-1 results.
+// Null and NaN values are skipped as in AggCol.
+// Operates independently over each cell on n-dimensional columns, and returns
+// the result as a slice of len(Idxs) * cell size values, in row-major order.
+func (ix *IdxView) RollingAgg(colIdx, window int, ini float64, fun etensor.AggFunc) []float64 {
+	cl := ix.Table.Cols[colIdx]
+	_, csz := cl.RowCellSize()
+	n := len(ix.Idxs)
+	ag := make([]float64, n*csz)
+	if window < 1 {
+		window = 1
+	}
+	for i := 0; i < n; i++ {
+		st := ints.MaxInt(0, i-window+1)
+		for j := 0; j < csz; j++ {
+			av := ini
+			for k := st; k <= i; k++ {
+				ci := ix.Idxs[k]*csz + j
+				val := cl.FloatVal1D(ci)
+				if !cl.IsNull1D(ci) && !math.IsNaN(val) {
+					av = fun(ci, val, av)
+				}
+			}
+			ag[i*csz+j] = av
+		}
+	}
+	return ag
+}
+
+// IdxsChanged must be called whenever the Idxs are modified directly, to
+// invalidate any cached aggregation results (see AggColCached) -- all of the
+// IdxView methods that modify the indexes call this automatically.
+func (ix *IdxView) IdxsChanged() {
+	ix.gen++
+}
+
+// AggColCached returns the results of AggCol for given args, caching the result
+// under the given key (along with the column index), so that repeated calls
+// with the same key return the cached result without recomputing, until the
+// indexes change (e.g., from Sort, Filter, etc -- see IdxsChanged).
+// The key must uniquely identify the ini and fun args, e.g., "Sum".
+// Changes to the Table data itself are not tracked -- call ClearAggCache
+// (or IdxsChanged) after modifying the column values.
+// The returned slice is shared with the cache and should not be modified.
+func (ix *IdxView) AggColCached(colIdx int, ini float64, fun etensor.AggFunc, key string) []float64 {
+	ck := fmt.Sprintf("%d:%s", colIdx, key)
+	if cv, has := ix.aggCache[ck]; has && cv.gen == ix.gen {
+		return cv.agg
+	}
+	if ix.aggCache == nil {
+		ix.aggCache = make(map[string]aggCacheVal)
+	}
+	ag := ix.AggCol(colIdx, ini, fun)
+	ix.aggCache[ck] = aggCacheVal{gen: ix.gen, agg: ag}
+	return ag
+}
+
+// ClearAggCache clears any cached aggregation results from AggColCached
+func (ix *IdxView) ClearAggCache() {
+	ix.aggCache = nil
+}
+
 // Clone returns a copy of the current index view with its own index memory
 func (ix *IdxView) Clone() *IdxView {
 	nix := &IdxView{}
@@ -463,12 +1017,14 @@ func (ix *IdxView) Clone() *IdxView {
 
 // CopyFrom copies from given other IdxView (we have our own unique copy of indexes)
 func (ix *IdxView) CopyFrom(oix *IdxView) {
+	defer ix.IdxsChanged()
 	ix.Table = oix.Table
 	ix.Idxs = sliceclone.Int(oix.Idxs)
 }
 
 // AddRows adds n rows to end of underlying Table, and to the indexes in this view
 func (ix *IdxView) AddRows(n int) {
+	defer ix.IdxsChanged()
 	stidx := ix.Table.Rows
 	ix.Table.SetNumRows(stidx + n)
 	for i := stidx; i < stidx+n; i++ {
@@ -479,6 +1035,7 @@ func (ix *IdxView) AddRows(n int) {
 // InsertRows adds n rows to end of underlying Table, and to the indexes starting at
 // given index in this view
 func (ix *IdxView) InsertRows(at, n int) {
+	defer ix.IdxsChanged()
 	stidx := ix.Table.Rows
 	ix.Table.SetNumRows(stidx + n)
 	nw := make([]int, n, n+len(ix.Idxs)-at)
@@ -490,6 +1047,7 @@ func (ix *IdxView) InsertRows(at, n int) {
 
 // DeleteRows deletes n rows of indexes starting at given index in the list of indexes
 func (ix *IdxView) DeleteRows(at, n int) {
+	defer ix.IdxsChanged()
 	ix.Idxs = append(ix.Idxs[:at], ix.Idxs[at+n:]...)
 }
 
@@ -550,6 +1108,39 @@ func (ix *IdxView) RowsByStringTry(colNm string, str string, contains, ignoreCas
 	return ix.RowsByStringIdx(ci, str, contains, ignoreCase), nil
 }
 
+// IdxsEq returns true if the indexes of this view are exactly the given
+// expected row indexes, in the same order -- e.g., for testing the results
+// of sorting and filtering.
+func (ix *IdxView) IdxsEq(expected []int) bool {
+	if len(ix.Idxs) != len(expected) {
+		return false
+	}
+	for i, row := range ix.Idxs {
+		if row != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RowValsEq returns true if the float64 values of the given (1D) column,
+// read through the indexes of this view in order, are exactly the given
+// expected values (with NaN matching NaN) -- e.g., for testing the results
+// of sorting and filtering.
+func (ix *IdxView) RowValsEq(colIdx int, expected []float64) bool {
+	if len(ix.Idxs) != len(expected) {
+		return false
+	}
+	cl := ix.Table.Cols[colIdx]
+	for i, row := range ix.Idxs {
+		v := cl.FloatVal1D(row)
+		if v != expected[i] && !(math.IsNaN(v) && math.IsNaN(expected[i])) {
+			return false
+		}
+	}
+	return true
+}
+
 // Len returns the length of the index list
 func (ix *IdxView) Len() int {
 	return len(ix.Idxs)
@@ -0,0 +1,672 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestRollingAgg(t *testing.T) {
+	dt := New(Schema{{"Val", etensor.FLOAT64, nil, nil}}, 5)
+	for i, v := range []float64{3, 1, 5, 2, 4} {
+		dt.SetCellFloat("Val", i, v)
+	}
+	ix := NewIdxView(dt)
+	ix.SortColName("Val", Ascending)
+	sum := func(idx int, val float64, agg float64) float64 { return agg + val }
+	rs := ix.RollingAgg(0, 3, 0, sum)
+	exp := []float64{1, 3, 6, 9, 12}
+	if len(rs) != len(exp) {
+		t.Fatalf("RollingAgg: len %v != %v\n", len(rs), len(exp))
+	}
+	for i, v := range exp {
+		if rs[i] != v {
+			t.Errorf("RollingAgg: row %v: %v != %v\n", i, rs[i], v)
+		}
+	}
+}
+
+// wideTable returns a table with given number of rows and a mix of
+// string, scalar and n-dim float columns, with some null values
+func wideTable(rows, ncols int) *Table {
+	sc := Schema{{"Name", etensor.STRING, nil, nil}}
+	for i := 0; i < ncols; i++ {
+		sc = append(sc, Column{fmt.Sprintf("S%d", i), etensor.FLOAT64, nil, nil})
+		sc = append(sc, Column{fmt.Sprintf("P%d", i), etensor.FLOAT32, []int{4, 4}, nil})
+	}
+	dt := New(sc, rows)
+	for ci, cl := range dt.Cols {
+		for i := 0; i < cl.Len(); i++ {
+			if ci == 0 {
+				cl.SetString1D(i, fmt.Sprintf("r%d", i))
+			} else {
+				cl.SetFloat1D(i, float64(ci*1000+i))
+			}
+			if i%7 == ci%7 {
+				cl.SetNull1D(i, true)
+			}
+		}
+	}
+	return dt
+}
+
+func TestNewTableParallel(t *testing.T) {
+	dt := wideTable(100, 10)
+	ix := NewIdxView(dt)
+	ix.Permuted()
+	ix.Filter(func(et *Table, row int) bool { return row%3 != 0 })
+	st := ix.newTable(false)
+	pt := ix.newTable(true)
+	if st.Rows != pt.Rows || st.NumCols() != pt.NumCols() {
+		t.Fatalf("NewTable parallel: rows %v cols %v != %v %v\n", pt.Rows, pt.NumCols(), st.Rows, st.NumCols())
+	}
+	for ci, scl := range st.Cols {
+		pcl := pt.Cols[ci]
+		for i := 0; i < scl.Len(); i++ {
+			if scl.StringVal1D(i) != pcl.StringVal1D(i) || scl.IsNull1D(i) != pcl.IsNull1D(i) {
+				t.Fatalf("NewTable parallel: col %v idx %v differs\n", ci, i)
+			}
+		}
+	}
+}
+
+func BenchmarkNewTableSerial(b *testing.B) {
+	ix := NewIdxView(wideTable(2000, 50))
+	ix.Permuted()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ix.newTable(false)
+	}
+}
+
+func BenchmarkNewTableParallel(b *testing.B) {
+	ix := NewIdxView(wideTable(2000, 50))
+	ix.Permuted()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ix.newTable(true)
+	}
+}
+
+func TestAggColCached(t *testing.T) {
+	dt := New(Schema{{"Val", etensor.FLOAT64, nil, nil}}, 5)
+	for i := 0; i < 5; i++ {
+		dt.SetCellFloat("Val", i, float64(i+1))
+	}
+	ix := NewIdxView(dt)
+	ncalls := 0
+	sum := func(idx int, val float64, agg float64) float64 {
+		ncalls++
+		return agg + val
+	}
+	ag := ix.AggColCached(0, 0, sum, "Sum")
+	if ag[0] != 15 || ncalls != 5 {
+		t.Errorf("AggColCached: sum %v calls %v\n", ag[0], ncalls)
+	}
+	ag = ix.AggColCached(0, 0, sum, "Sum")
+	if ag[0] != 15 || ncalls != 5 {
+		t.Errorf("AggColCached: not cached: sum %v calls %v\n", ag[0], ncalls)
+	}
+	ix.Filter(func(et *Table, row int) bool { return et.CellFloat("Val", row) > 2 })
+	ag = ix.AggColCached(0, 0, sum, "Sum")
+	if ag[0] != 12 || ncalls != 8 {
+		t.Errorf("AggColCached: not invalidated by Filter: sum %v calls %v\n", ag[0], ncalls)
+	}
+}
+
+func TestNewTableCols(t *testing.T) {
+	dt := wideTable(10, 3)
+	dt.SetMetaData("name", "wide")
+	dt.SetMetaData("S1:desc", "scalar one")
+	dt.SetMetaData("S2:desc", "scalar two")
+	dt.ColByName("P0").SetMetaData("grid-fill", ".9")
+	ix := NewIdxView(dt)
+	ix.Filter(func(et *Table, row int) bool { return row%2 == 0 })
+	nt, err := ix.NewTableColNames([]string{"P0", "S1", "Name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nt.Rows != 5 || nt.NumCols() != 3 {
+		t.Fatalf("NewTableCols: rows %v cols %v\n", nt.Rows, nt.NumCols())
+	}
+	for i, cn := range []string{"P0", "S1", "Name"} {
+		if nt.ColNames[i] != cn {
+			t.Errorf("NewTableCols: col %v name %v != %v\n", i, nt.ColNames[i], cn)
+		}
+	}
+	for _, cn := range []string{"S0", "S2", "P1", "P2"} {
+		if nt.ColByName(cn) != nil {
+			t.Errorf("NewTableCols: unselected column %v present\n", cn)
+		}
+	}
+	if nt.CellFloat("S1", 2) != dt.CellFloat("S1", 4) || nt.CellString("Name", 1) != "r2" {
+		t.Errorf("NewTableCols: values not copied by index\n")
+	}
+	if nt.MetaData["name"] != "wide" || nt.MetaData["S1:desc"] != "scalar one" {
+		t.Errorf("NewTableCols: meta data not copied: %v\n", nt.MetaData)
+	}
+	if _, has := nt.MetaData["S2:desc"]; has {
+		t.Errorf("NewTableCols: unselected column meta data copied\n")
+	}
+	if gf, _ := nt.ColByName("P0").MetaData("grid-fill"); gf != ".9" {
+		t.Errorf("NewTableCols: column tensor meta data not copied\n")
+	}
+	if _, err := ix.NewTableColNames([]string{"Nope"}); err == nil {
+		t.Errorf("NewTableColNames: expected error for unknown column\n")
+	}
+
+	// column names containing ":" are split at the last ":", as in MergeColMetaData
+	dt.AddCol(etensor.NewFloat64([]int{dt.Rows}, nil, nil), "S1:a")
+	dt.SetMetaData("S1:a:desc", "sub a")
+	nt, _ = NewIdxView(dt).NewTableColNames([]string{"S1:a"})
+	if nt.MetaData["S1:a:desc"] != "sub a" {
+		t.Errorf("NewTableCols: meta data of column with ':' in name not copied: %v\n", nt.MetaData)
+	}
+	if _, has := nt.MetaData["S1:desc"]; has {
+		t.Errorf("NewTableCols: unselected column meta data copied for column with ':' in name\n")
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	sc := Schema{{"Num", etensor.FLOAT64, nil, nil}, {"Den", etensor.FLOAT64, nil, nil}}
+	n := 50
+	dt := New(sc, n)
+	perm := rand.Perm(n)
+	for i := 0; i < n; i++ {
+		dt.SetCellFloat("Num", i, float64(perm[i]+1))
+		dt.SetCellFloat("Den", i, 2)
+	}
+	ix := NewIdxView(dt)
+	ncalls := 0
+	ratio := func(et *Table, row int) float64 {
+		ncalls++
+		return et.CellFloat("Num", row) / et.CellFloat("Den", row)
+	}
+	ix.SortKey(ratio, Descending)
+	if ncalls != n {
+		t.Errorf("SortKey: key function called %v times, not %v\n", ncalls, n)
+	}
+	for i, ri := range ix.Idxs {
+		if r := ratio(dt, ri); r != float64(n-i)/2 {
+			t.Errorf("SortKey: index %v ratio %v != %v\n", i, r, float64(n-i)/2)
+		}
+	}
+	ix.SortKey(ratio, Ascending)
+	if ratio(dt, ix.Idxs[0]) != .5 || ratio(dt, ix.Idxs[n-1]) != float64(n)/2 {
+		t.Errorf("SortKey: ascending order wrong\n")
+	}
+}
+
+func TestNewTableNulls(t *testing.T) {
+	sc := Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"N", etensor.INT64, nil, nil},
+		{"Pat", etensor.FLOAT32, []int{2}, nil},
+	}
+	dt := New(sc, 6)
+	for ci := range dt.Cols {
+		dt.Cols[ci].SetNull1D(ci, true) // a different row null in each column
+	}
+	dt.Cols[3].SetNull1D(4*2+1, true) // second value of row 4 cell
+	ix := NewIdxView(dt)
+	ix.Idxs = []int{5, 4, 3, 2, 1, 0}
+	nt := ix.NewTable()
+	for ri := 0; ri < 6; ri++ {
+		srw := 5 - ri
+		for ci := 0; ci < 3; ci++ {
+			if nt.Cols[ci].IsNull1D(ri) != dt.Cols[ci].IsNull1D(srw) {
+				t.Errorf("NewTable: col %v row %v null %v != source row %v\n", ci, ri, nt.Cols[ci].IsNull1D(ri), srw)
+			}
+		}
+		for j := 0; j < 2; j++ {
+			if nt.Cols[3].IsNull1D(ri*2+j) != dt.Cols[3].IsNull1D(srw*2+j) {
+				t.Errorf("NewTable: Pat row %v idx %v null not copied\n", ri, j)
+			}
+		}
+	}
+	// copying non-null values over null ones must clear the nulls
+	nt.Cols[1].CopyCellsFrom(dt.Cols[1], 0, 0, 6)
+	for ri := 0; ri < 6; ri++ {
+		if nt.Cols[1].IsNull1D(ri) != dt.Cols[1].IsNull1D(ri) {
+			t.Errorf("CopyCellsFrom: row %v null %v != %v\n", ri, nt.Cols[1].IsNull1D(ri), dt.Cols[1].IsNull1D(ri))
+		}
+	}
+}
+
+// filterRev is the prior reverse-deletion implementation of Filter, for comparison
+func filterRev(ix *IdxView, filterFunc func(et *Table, row int) bool) {
+	sz := len(ix.Idxs)
+	for i := sz - 1; i >= 0; i-- {
+		if !filterFunc(ix.Table, ix.Idxs[i]) {
+			ix.Idxs = append(ix.Idxs[:i], ix.Idxs[i+1:]...)
+		}
+	}
+}
+
+func TestFilterOrder(t *testing.T) {
+	dt := New(Schema{{"Val", etensor.FLOAT64, nil, nil}}, 1000)
+	for i := 0; i < dt.Rows; i++ {
+		dt.SetCellFloat("Val", i, float64(rand.Intn(10)))
+	}
+	keep := func(et *Table, row int) bool { return et.CellFloat("Val", row) > 3 }
+	ix := NewIdxView(dt)
+	ix.Permuted()
+	rix := ix.Clone()
+	ix.Filter(keep)
+	filterRev(rix, keep)
+	if len(ix.Idxs) != len(rix.Idxs) {
+		t.Fatalf("Filter: len %v != %v\n", len(ix.Idxs), len(rix.Idxs))
+	}
+	for i := range ix.Idxs {
+		if ix.Idxs[i] != rix.Idxs[i] {
+			t.Fatalf("Filter: index %v: %v != %v\n", i, ix.Idxs[i], rix.Idxs[i])
+		}
+	}
+}
+
+func benchFilterTable() *Table {
+	dt := New(Schema{{"Val", etensor.FLOAT64, nil, nil}}, 100000)
+	for i := 0; i < dt.Rows; i++ {
+		dt.SetCellFloat("Val", i, float64(i%2))
+	}
+	return dt
+}
+
+func BenchmarkFilter(b *testing.B) {
+	dt := benchFilterTable()
+	keep := func(et *Table, row int) bool { return et.CellFloat("Val", row) > 0 }
+	for i := 0; i < b.N; i++ {
+		ix := NewIdxView(dt)
+		ix.Filter(keep)
+	}
+}
+
+func BenchmarkFilterRev(b *testing.B) {
+	dt := benchFilterTable()
+	keep := func(et *Table, row int) bool { return et.CellFloat("Val", row) > 0 }
+	for i := 0; i < b.N; i++ {
+		ix := NewIdxView(dt)
+		filterRev(ix, keep)
+	}
+}
+
+func TestSortByNames(t *testing.T) {
+	dt := New(Schema{
+		{"Group", etensor.STRING, nil, nil},
+		{"Score", etensor.FLOAT64, nil, nil},
+		{"Name", etensor.STRING, nil, nil},
+		{"Vec", etensor.FLOAT32, []int{2}, nil},
+	}, 6)
+	rows := []struct {
+		grp   string
+		score float64
+		name  string
+	}{
+		{"b", 1, "u"}, {"a", 2, "v"}, {"b", 3, "w"}, {"a", 2, "x"}, {"a", 5, "y"}, {"b", 1, "z"},
+	}
+	for ri, r := range rows {
+		dt.SetCellString("Group", ri, r.grp)
+		dt.SetCellFloat("Score", ri, r.score)
+		dt.SetCellString("Name", ri, r.name)
+	}
+	ix := NewIdxView(dt)
+	err := ix.SortByNames([]SortSpec{{Col: "Group", Ascending: true}, {Col: "Score"}, {Col: "Name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{"y", "x", "v", "w", "z", "u"}
+	for i, ri := range ix.Idxs {
+		if nm := dt.CellString("Name", ri); nm != exp[i] {
+			t.Errorf("SortByNames: position %v: %v, expected %v\n", i, nm, exp[i])
+		}
+	}
+	before := append([]int{}, ix.Idxs...)
+	for _, bad := range []string{"Nope", "Vec"} {
+		if err := ix.SortByNames([]SortSpec{{Col: "Group", Ascending: true}, {Col: bad}}); err == nil {
+			t.Errorf("SortByNames: no error for column %v\n", bad)
+		}
+	}
+	for i := range before {
+		if ix.Idxs[i] != before[i] {
+			t.Fatalf("SortByNames: indexes changed on error: %v, were %v\n", ix.Idxs, before)
+		}
+	}
+}
+
+func TestFilterUnique(t *testing.T) {
+	dt := New(Schema{
+		{"Key", etensor.STRING, nil, nil},
+		{"Time", etensor.FLOAT64, nil, nil},
+	}, 5)
+	for ri, k := range []string{"a", "b", "a", "a", "b"} {
+		dt.SetCellString("Key", ri, k)
+		dt.SetCellFloat("Time", ri, float64(ri))
+	}
+	ix := NewIdxView(dt)
+	if err := ix.FilterUnique([]string{"Key"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(ix.Idxs) != 2 || ix.Idxs[0] != 3 || ix.Idxs[1] != 4 {
+		t.Errorf("FilterUnique keepLast: %v, expected [3 4]\n", ix.Idxs)
+	}
+	ix = NewIdxView(dt)
+	ix.FilterUnique([]string{"Key"}, false)
+	if len(ix.Idxs) != 2 || ix.Idxs[0] != 0 || ix.Idxs[1] != 1 {
+		t.Errorf("FilterUnique keep first: %v, expected [0 1]\n", ix.Idxs)
+	}
+	ix = NewIdxView(dt)
+	ix.FilterUnique([]string{"Key", "Time"}, false)
+	if len(ix.Idxs) != 5 {
+		t.Errorf("FilterUnique two keys: %v, expected all 5 rows\n", ix.Idxs)
+	}
+	if err := ix.FilterUnique([]string{"Nope"}, false); err == nil {
+		t.Errorf("FilterUnique: no error for bad column name\n")
+	}
+}
+
+func TestSortNaN(t *testing.T) {
+	dt := New(Schema{
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"Key", etensor.FLOAT64, nil, nil},
+	}, 7)
+	nan := math.NaN()
+	for ri, v := range []float64{3, nan, 1, nan, 2, 5, nan} {
+		dt.SetCellFloat("Val", ri, v)
+		dt.SetCellFloat("Key", ri, float64(ri%2))
+	}
+	check := func(nm string, ix *IdxView, ascending bool) {
+		t.Helper()
+		prev := 0.0
+		for i, ri := range ix.Idxs {
+			v := dt.CellFloat("Val", ri)
+			if i >= 4 {
+				if !math.IsNaN(v) {
+					t.Errorf("%v: position %v: %v, expected NaN: %v\n", nm, i, v, ix.Idxs)
+				}
+				continue
+			}
+			if math.IsNaN(v) || (i > 0 && (v > prev) != ascending) {
+				t.Errorf("%v: position %v: %v out of order: %v\n", nm, i, v, ix.Idxs)
+			}
+			prev = v
+		}
+	}
+	for _, asc := range []bool{Ascending, Descending} {
+		ix := NewIdxView(dt)
+		ix.SortCol(0, asc)
+		check("SortCol", ix, asc)
+		ix = NewIdxView(dt)
+		ix.SortStableCol(0, asc)
+		check("SortStableCol", ix, asc)
+		ix = NewIdxView(dt)
+		ix.SortCols([]int{0, 1}, asc)
+		check("SortCols", ix, asc)
+		ix = NewIdxView(dt)
+		ix.SortStableCols([]int{0, 1}, asc)
+		check("SortStableCols", ix, asc)
+		ix = NewIdxView(dt)
+		ix.SortByNames([]SortSpec{{Col: "Val", Ascending: asc}})
+		check("SortByNames", ix, asc)
+	}
+}
+
+func TestSampleWeighted(t *testing.T) {
+	wts := []float64{1, 2, 0, -1, 5, 7}
+	dt := New(Schema{{"Wt", etensor.FLOAT64, nil, nil}}, len(wts))
+	for ri, w := range wts {
+		dt.SetCellFloat("Wt", ri, w)
+	}
+	dt.Cols[0].SetNull1D(4, true) // treated as zero
+	exp := []float64{0.1, 0.2, 0, 0, 0, 0.7}
+	rnd := rand.New(rand.NewSource(1))
+	ix := NewIdxView(dt)
+	ndraw := 100000
+	sv := ix.SampleWeighted(ndraw, 0, true, rnd)
+	if sv.Len() != ndraw {
+		t.Fatalf("SampleWeighted: %v rows, expected %v\n", sv.Len(), ndraw)
+	}
+	cnts := make([]float64, len(wts))
+	for _, ri := range sv.Idxs {
+		cnts[ri]++
+	}
+	for ri, c := range cnts {
+		if f := c / float64(ndraw); math.Abs(f-exp[ri]) > 0.01 {
+			t.Errorf("SampleWeighted replace: row %v frequency %v, expected %v\n", ri, f, exp[ri])
+		}
+	}
+
+	cnts = make([]float64, len(wts))
+	ntrial := 20000
+	for i := 0; i < ntrial; i++ {
+		sv = ix.SampleWeighted(5, 0, false, rnd)
+		if sv.Len() != 3 {
+			t.Fatalf("SampleWeighted no replace: %v rows, expected only the 3 rows with positive weights\n", sv.Len())
+		}
+		cnts[sv.Idxs[0]]++
+	}
+	for ri, c := range cnts {
+		if f := c / float64(ntrial); math.Abs(f-exp[ri]) > 0.015 {
+			t.Errorf("SampleWeighted no replace: row %v first frequency %v, expected %v\n", ri, f, exp[ri])
+		}
+	}
+}
+
+func TestColView(t *testing.T) {
+	dt := wideTable(20, 1)
+	ix := NewIdxView(dt)
+	ix.Filter(func(et *Table, row int) bool { return row%3 != 0 })
+	ix.SortCol(1, Descending)
+	for _, ci := range []int{0, 1, 2} {
+		cl := dt.Cols[ci]
+		cv := ix.ColView(ci)
+		_, csz := cl.RowCellSize()
+		if cv.Dim(0) != ix.Len() || cv.Len() != ix.Len()*csz || cv.DataType() != cl.DataType() {
+			t.Fatalf("ColView %d: shape %v type %v\n", ci, cv.Shapes(), cv.DataType())
+		}
+		for i, row := range ix.Idxs {
+			for c := 0; c < csz; c++ {
+				j := row*csz + c
+				k := i*csz + c
+				if cv.StringVal1D(k) != cl.StringVal1D(j) || cv.IsNull1D(k) != cl.IsNull1D(j) {
+					t.Errorf("ColView %d: view row %d cell %d: %v != %v\n", ci, i, c, cv.StringVal1D(k), cl.StringVal1D(j))
+				}
+				if cv.FloatValRowCell(i, c) != cl.FloatVal1D(j) {
+					t.Errorf("ColView %d: view row %d cell %d: %v != %v\n", ci, i, c, cv.FloatValRowCell(i, c), cl.FloatVal1D(j))
+				}
+			}
+		}
+		cc := cv.Clone()
+		if cc.Len() != cv.Len() || cc.StringVal1D(cv.Len()-1) != cv.StringVal1D(cv.Len()-1) || cc.IsNull1D(csz) != cv.IsNull1D(csz) {
+			t.Errorf("ColView %d: Clone does not match view\n", ci)
+		}
+	}
+	cv := ix.ColView(1)
+	last := math.Inf(1)
+	for i := 0; i < cv.Len(); i++ {
+		if cv.IsNull1D(i) {
+			continue
+		}
+		if v := cv.FloatVal1D(i); v > last {
+			t.Errorf("ColView: not in sorted order at %d: %v > %v\n", i, v, last)
+		} else {
+			last = v
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ColView: no panic on write\n")
+		}
+	}()
+	cv.SetFloat1D(0, 1)
+}
+
+func TestColViewEmpty(t *testing.T) {
+	dt := wideTable(5, 1)
+	dt.Cols[1].SetMetaData("name", "orig")
+	ix := NewIdxView(dt)
+	ix.Filter(func(et *Table, row int) bool { return false })
+	for ci := range dt.Cols {
+		cc := ix.ColView(ci).Clone()
+		if cc.Dim(0) != 0 || cc.Len() != 0 || cc.DataType() != dt.Cols[ci].DataType() {
+			t.Errorf("ColView %d: empty Clone shape %v type %v, expected 0 rows\n", ci, cc.Shapes(), cc.DataType())
+		}
+	}
+	if nm, _ := ix.ColView(1).Clone().MetaData("name"); nm != "orig" {
+		t.Errorf("ColView: empty Clone meta data %q, expected %q\n", nm, "orig")
+	}
+}
+
+func TestPermuteTables(t *testing.T) {
+	dt := wideTable(30, 1)
+	lt := New(Schema{{"Label", etensor.STRING, nil, nil}}, 30)
+	for i := 0; i < 30; i++ {
+		lt.SetCellString("Label", i, dt.CellString("Name", i))
+	}
+	dix := NewIdxView(dt)
+	lix := NewIdxView(lt)
+	if err := PermuteTables(rand.New(rand.NewSource(1)), dix, lix); err != nil {
+		t.Fatal(err)
+	}
+	inOrder := true
+	for i := range dix.Idxs {
+		if dix.Idxs[i] != lix.Idxs[i] {
+			t.Fatalf("PermuteTables: index %d: %d != %d\n", i, dix.Idxs[i], lix.Idxs[i])
+		}
+		if dix.Idxs[i] != i {
+			inOrder = false
+		}
+		if dt.CellString("Name", dix.Idxs[i]) != lt.CellString("Label", lix.Idxs[i]) {
+			t.Errorf("PermuteTables: row %d labels do not correspond\n", i)
+		}
+	}
+	if inOrder {
+		t.Errorf("PermuteTables: indexes not permuted\n")
+	}
+	lix.Filter(func(et *Table, row int) bool { return row > 0 })
+	if err := PermuteTables(nil, dix, lix); err == nil {
+		t.Errorf("PermuteTables: no error for different lengths\n")
+	}
+}
+
+func TestFilterIdxs(t *testing.T) {
+	dt := wideTable(10, 1)
+	ix := NewIdxView(dt)
+	ix.Idxs = []int{7, 2, 9, 4, 0, 5}
+	ix.FilterIdxs([]int{0, 1, 4, 7, 8, 9})
+	exp := []int{7, 9, 4, 0}
+	if fmt.Sprint(ix.Idxs) != fmt.Sprint(exp) {
+		t.Errorf("FilterIdxs: %v != %v\n", ix.Idxs, exp)
+	}
+	rows := []int{3, 1}
+	if err := ix.SetIdxs(rows); err != nil {
+		t.Fatal(err)
+	}
+	rows[0] = 8
+	if fmt.Sprint(ix.Idxs) != "[3 1]" {
+		t.Errorf("SetIdxs: %v != [3 1]\n", ix.Idxs)
+	}
+	if err := ix.SetIdxs([]int{2, 10}); err == nil || ix.Len() != 2 {
+		t.Errorf("SetIdxs: no error or changed indexes for out-of-range row\n")
+	}
+}
+
+func TestAggCellCol(t *testing.T) {
+	dt := New(Schema{{"Vec", etensor.FLOAT64, []int{4}, nil}}, 3)
+	vals := [][]float64{{1, 2, 3, 4}, {-2, 0, 8, 10}, {5, 5, 7, 99}}
+	for ri, rv := range vals {
+		for j, v := range rv {
+			dt.SetCellTensorFloat1D("Vec", ri, j, v)
+		}
+	}
+	dt.Cols[0].SetNull1D(2*4+3, true) // 99 is skipped
+	ix := NewIdxView(dt)
+	ix.Idxs = []int{2, 0, 1}
+	sum := ix.AggCellCol(0, 0, func(idx int, val float64, agg float64) float64 { return agg + val })
+	cnt := ix.AggCellCol(0, 0, func(idx int, val float64, agg float64) float64 { return agg + 1 })
+	exp := []float64{17.0 / 3.0, 10.0 / 4.0, 16.0 / 4.0}
+	for i, e := range exp {
+		if mean := sum[i] / cnt[i]; math.Abs(mean-e) > 1e-12 {
+			t.Errorf("AggCellCol: row %v mean %v != %v\n", i, mean, e)
+		}
+	}
+}
+
+func TestAggColByGroup(t *testing.T) {
+	dt := New(Schema{
+		{"Cond", etensor.STRING, nil, nil},
+		{"Run", etensor.INT64, nil, nil},
+		{"Vec", etensor.FLOAT64, []int{2}, nil},
+	}, 6)
+	conds := []string{"b", "a", "b", "a", "a", "b"}
+	runs := []float64{1, 2, 1, 1, 2, 0}
+	for ri := range conds {
+		dt.SetCellString("Cond", ri, conds[ri])
+		dt.SetCellFloat("Run", ri, runs[ri])
+		dt.SetCellTensorFloat1D("Vec", ri, 0, float64(ri))
+		dt.SetCellTensorFloat1D("Vec", ri, 1, float64(10*ri))
+	}
+	ix := NewIdxView(dt)
+	ix.Filter(func(et *Table, row int) bool { return row != 3 })
+	sum := func(idx int, val float64, agg float64) float64 { return agg + val }
+	keys, vals := ix.AggColByGroup([]int{0, 1}, 2, 0, sum)
+	// rows: a 2: 1, 4; b 0: 5; b 1: 0, 2 (a 1 is filtered out)
+	expKeys := "[[a 2] [b 0] [b 1]]"
+	expVals := "[[5 50] [5 50] [2 20]]"
+	if ks, vs := fmt.Sprint(keys), fmt.Sprint(vals); ks != expKeys || vs != expVals {
+		t.Errorf("AggColByGroup: keys %v vals %v, expected %v %v\n", ks, vs, expKeys, expVals)
+	}
+}
+
+func TestBootstrapCI(t *testing.T) {
+	dt := New(Schema{{"Val", etensor.FLOAT64, nil, nil}}, 50)
+	for i := 0; i < dt.Rows; i++ {
+		dt.SetCellFloat("Val", i, float64(i%10))
+	}
+	dt.Cols[0].SetNull1D(3, true)
+	dt.SetCellFloat("Val", 7, math.NaN())
+	ix := NewIdxView(dt)
+	mean, lo, hi := ix.BootstrapCI(0, 1000, .95, rand.New(rand.NewSource(1)))
+	exp := (225.0 - 3 - 7) / 48
+	if math.Abs(mean-exp) > 1e-12 {
+		t.Errorf("BootstrapCI: mean %v != %v\n", mean, exp)
+	}
+	if !(lo < mean && mean < hi) || hi-lo > 3 {
+		t.Errorf("BootstrapCI: interval [%v, %v] does not bracket mean %v\n", lo, hi, mean)
+	}
+	m2, lo2, hi2 := ix.BootstrapCI(0, 1000, .95, rand.New(rand.NewSource(1)))
+	if m2 != mean || lo2 != lo || hi2 != hi {
+		t.Errorf("BootstrapCI: not reproducible with same seed: [%v, %v] vs [%v, %v]\n", lo, hi, lo2, hi2)
+	}
+	if _, nlo, nhi := ix.BootstrapCI(0, 1000, .5, rand.New(rand.NewSource(1))); nlo < lo || nhi > hi {
+		t.Errorf("BootstrapCI: 50%% interval [%v, %v] wider than 95%% [%v, %v]\n", nlo, nhi, lo, hi)
+	}
+}
+
+func TestIdxsEq(t *testing.T) {
+	dt := New(Schema{{"Val", etensor.FLOAT64, nil, nil}}, 5)
+	for i, v := range []float64{3, math.NaN(), 1, 5, 2} {
+		dt.SetCellFloat("Val", i, v)
+	}
+	ix := NewIdxView(dt)
+	ix.SortCol(0, Ascending)
+	if !ix.IdxsEq([]int{2, 4, 0, 3, 1}) {
+		t.Errorf("IdxsEq: false for sorted indexes %v\n", ix.Idxs)
+	}
+	if ix.IdxsEq([]int{2, 4, 0, 3}) || ix.IdxsEq([]int{4, 2, 0, 3, 1}) {
+		t.Errorf("IdxsEq: true for wrong indexes\n")
+	}
+	if !ix.RowValsEq(0, []float64{1, 2, 3, 5, math.NaN()}) {
+		t.Errorf("RowValsEq: false for sorted values\n")
+	}
+	if ix.RowValsEq(0, []float64{3, 1, 5, 2, math.NaN()}) {
+		t.Errorf("RowValsEq: true for values in table order instead of view order\n")
+	}
+}
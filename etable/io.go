@@ -5,6 +5,7 @@
 package etable
 
 import (
+	"compress/gzip"
 	"encoding/csv"
 	"fmt"
 	"io"
@@ -105,16 +106,30 @@ func (ix *IdxView) SaveCSV(filename gi.FileName, delim Delims, headers bool) err
 // information for tensor dimensionality.
 // If the table DOES have existing columns, then those are used robustly
 // for whatever information fits from each row of the file.
+// Gzip-compressed files (e.g., .csv.gz) are decompressed automatically.
 func (dt *Table) OpenCSV(filename gi.FileName, delim Delims) error {
-	fp, err := os.Open(string(filename))
-	defer fp.Close()
+	fp, err := OpenCSVFile(filename)
 	if err != nil {
 		log.Println(err)
 		return err
 	}
+	defer fp.Close()
 	return dt.ReadCSV(fp, delim)
 }
 
+// OpenCSVOpts reads a table from a comma-separated-values (CSV) file as in
+// OpenCSV, using the given options for the delimiter, comment lines,
+// and strings marking missing values -- see CSVOpts.
+func (dt *Table) OpenCSVOpts(filename gi.FileName, opts *CSVOpts) error {
+	fp, err := OpenCSVFile(filename)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer fp.Close()
+	return dt.ReadCSVOpts(fp, opts)
+}
+
 // OpenCSV reads a table idx view from a comma-separated-values (CSV) file
 // (where comma = any delimiter, specified in the delim arg),
 // using the Go standard encoding/csv reader conforming to the official CSV standard.
@@ -124,18 +139,75 @@ func (dt *Table) OpenCSV(filename gi.FileName, delim Delims) error {
 // information for tensor dimensionality.
 // If the table DOES have existing columns, then those are used robustly
 // for whatever information fits from each row of the file.
+// Gzip-compressed files (e.g., .csv.gz) are decompressed automatically.
 func (ix *IdxView) OpenCSV(filename gi.FileName, delim Delims) error {
-	fp, err := os.Open(string(filename))
-	defer fp.Close()
+	fp, err := OpenCSVFile(filename)
 	if err != nil {
 		log.Println(err)
 		return err
 	}
+	defer fp.Close()
 	err = ix.Table.ReadCSV(fp, delim)
 	ix.Sequential()
 	return err
 }
 
+// OpenCSVOpts reads a table idx view from a comma-separated-values (CSV) file
+// as in OpenCSV, using the given options for the delimiter, comment lines,
+// and strings marking missing values -- see CSVOpts.
+func (ix *IdxView) OpenCSVOpts(filename gi.FileName, opts *CSVOpts) error {
+	fp, err := OpenCSVFile(filename)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer fp.Close()
+	err = ix.Table.ReadCSVOpts(fp, opts)
+	ix.Sequential()
+	return err
+}
+
+// gzipReadCloser closes both the gzip reader and the underlying file
+type gzipReadCloser struct {
+	*gzip.Reader
+	fp *os.File
+}
+
+func (gz *gzipReadCloser) Close() error {
+	err := gz.Reader.Close()
+	if ferr := gz.fp.Close(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// OpenCSVFile opens given file for reading CSV data, transparently
+// decompressing gzip-compressed files -- these are detected from the gzip
+// magic bytes at the start of the file, so the .gz extension is not required.
+// Use this with csv.NewReader for reading rows incrementally with
+// ReadCSVRowReader.  Close must be called when done, which closes the file.
+func OpenCSVFile(filename gi.FileName) (io.ReadCloser, error) {
+	fp, err := os.Open(string(filename))
+	if err != nil {
+		return nil, err
+	}
+	var magic [2]byte
+	n, _ := io.ReadFull(fp, magic[:])
+	if _, err := fp.Seek(0, io.SeekStart); err != nil {
+		fp.Close()
+		return nil, err
+	}
+	if n < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		return fp, nil
+	}
+	gz, err := gzip.NewReader(fp)
+	if err != nil {
+		fp.Close()
+		return nil, fmt.Errorf("etable.OpenCSVFile: file %v: %v", filename, err)
+	}
+	return &gzipReadCloser{Reader: gz, fp: fp}, nil
+}
+
 // ReadCSV reads a table from a comma-separated-values (CSV) file
 // (where comma = any delimiter, specified in the delim arg),
 // using the Go standard encoding/csv reader conforming to the official CSV standard.
@@ -146,8 +218,36 @@ func (ix *IdxView) OpenCSV(filename gi.FileName, delim Delims) error {
 // If the table DOES have existing columns, then those are used robustly
 // for whatever information fits from each row of the file.
 func (dt *Table) ReadCSV(r io.Reader, delim Delims) error {
+	return dt.ReadCSVOpts(r, &CSVOpts{Delim: delim})
+}
+
+// CSVOpts are options for reading CSV files, for ReadCSVOpts and OpenCSVOpts
+type CSVOpts struct {
+	Delim     Delims   `desc:"delimiter between values in each row"`
+	Comment   rune     `desc:"if non-zero, lines starting with this character (e.g., '#') are skipped as comments"`
+	NAStrings []string `desc:"strings marking missing values (e.g., NA, null), which are set as Null in numeric columns, and ignored when inferring the types of plain header columns -- empty strings and NaN, Inf values are always missing values in numeric columns"`
+}
+
+// naMap returns a map of the NAStrings, nil if there are none
+func (co *CSVOpts) naMap() map[string]bool {
+	if len(co.NAStrings) == 0 {
+		return nil
+	}
+	na := make(map[string]bool, len(co.NAStrings))
+	for _, s := range co.NAStrings {
+		na[s] = true
+	}
+	return na
+}
+
+// ReadCSVOpts reads a table from a comma-separated-values (CSV) file as in
+// ReadCSV, using the given options for the delimiter, comment lines,
+// and strings marking missing values -- see CSVOpts.
+func (dt *Table) ReadCSVOpts(r io.Reader, opts *CSVOpts) error {
 	cr := csv.NewReader(r)
-	cr.Comma = delim.Rune()
+	cr.Comma = opts.Delim.Rune()
+	cr.Comment = opts.Comment
+	na := opts.naMap()
 	rec, err := cr.ReadAll() // todo: lazy, avoid resizing
 	if err != nil || len(rec) == 0 {
 		return err
@@ -156,7 +256,12 @@ func (dt *Table) ReadCSV(r io.Reader, delim Delims) error {
 	// cols := len(rec[0])
 	strow := 0
 	if dt.NumCols() == 0 || DetectEmerHeaders(rec[0]) {
-		sc, err := SchemaFromHeaders(rec[0], rec)
+		var sc Schema
+		if DetectEmerHeaders(rec[0]) {
+			sc, err = SchemaFromEmerHeaders(rec[0])
+		} else {
+			sc, err = schemaFromPlainHeaders(rec[0], rec, na)
+		}
 		if err != nil {
 			log.Println(err.Error())
 			return err
@@ -167,13 +272,54 @@ func (dt *Table) ReadCSV(r io.Reader, delim Delims) error {
 	}
 	dt.SetNumRows(rows)
 	for ri := 0; ri < rows; ri++ {
-		dt.ReadCSVRow(rec[ri+strow], ri)
+		dt.readCSVRow(rec[ri+strow], ri, na)
 	}
 	return nil
 }
 
+// SchemaCSV returns the Schema of the table in given comma-separated-values
+// (CSV) file (where comma = any delimiter, specified in the delim arg),
+// without reading the full table, to decide how to configure loading it.
+// The C++ emergent column headers are parsed if present, and otherwise the
+// column types for the plain headers are inferred from the first sampleRows
+// rows of data -- if sampleRows <= 0, all rows are used.
+// Gzip-compressed files (e.g., .csv.gz) are decompressed automatically.
+func SchemaCSV(filename gi.FileName, delim Delims, sampleRows int) (Schema, error) {
+	fp, err := OpenCSVFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	cr := csv.NewReader(fp)
+	cr.Comma = delim.Rune()
+	var rec [][]string
+	for sampleRows <= 0 || len(rec) <= sampleRows {
+		r, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("etable.SchemaCSV: file %v: %v", filename, err)
+		}
+		rec = append(rec, r)
+		if len(rec) == 1 && DetectEmerHeaders(r) {
+			return SchemaFromEmerHeaders(r)
+		}
+	}
+	if len(rec) == 0 {
+		return nil, fmt.Errorf("etable.SchemaCSV: file %v is empty", filename)
+	}
+	return SchemaFromPlainHeaders(rec[0], rec)
+}
+
 // ReadCSVRow reads a record of CSV data into given row in table
 func (dt *Table) ReadCSVRow(rec []string, row int) {
+	dt.readCSVRow(rec, row, nil)
+}
+
+// readCSVRow reads a record of CSV data into given row in table,
+// with any additional strings marking missing values in given na map
+func (dt *Table) readCSVRow(rec []string, row int, na map[string]bool) {
 	tc := dt.NumCols()
 	ci := 0
 	if rec[0] == "_D:" { // emergent data row
@@ -186,7 +332,7 @@ func (dt *Table) ReadCSVRow(rec []string, row int) {
 		for cc := 0; cc < csz; cc++ {
 			str := rec[ci]
 			if tsr.DataType() != etensor.STRING {
-				if str == "" || str == "NaN" || str == "-NaN" || str == "Inf" || str == "-Inf" {
+				if str == "" || str == "NaN" || str == "-NaN" || str == "Inf" || str == "-Inf" || na[str] {
 					tsr.SetNull1D(stoff+cc, true) // empty = missing
 				} else {
 					tsr.SetString1D(stoff+cc, str)
@@ -202,6 +348,31 @@ func (dt *Table) ReadCSVRow(rec []string, row int) {
 	}
 }
 
+// ReadCSVRowReader reads the next record of CSV data from given csv.Reader
+// and appends it as a new row at the end of the table, which must already
+// have been configured with the appropriate columns (e.g., via SetFromSchema).
+// This allows large files to be read incrementally one row at a time,
+// e.g., in a loop until io.EOF is returned, which is returned at the end of the data.
+// Any emergent header records (starting with _H:) are skipped.
+func (dt *Table) ReadCSVRowReader(cr *csv.Reader) error {
+	if dt.NumCols() == 0 {
+		return fmt.Errorf("etable.Table ReadCSVRowReader: table has no columns -- must be configured first")
+	}
+	for {
+		rec, err := cr.Read()
+		if err != nil {
+			return err
+		}
+		if len(rec) == 0 || rec[0] == "_H:" {
+			continue
+		}
+		row := dt.Rows
+		dt.AddRows(1)
+		dt.ReadCSVRow(rec, row)
+		return nil
+	}
+}
+
 // SchemaFromHeaders attempts to configure a Table Schema based on the headers
 // for non-Emergent headers, data is examined to
 func SchemaFromHeaders(hdrs []string, rec [][]string) (Schema, error) {
@@ -318,6 +489,12 @@ func ShapeFromString(dims string) []int {
 // All columns are of type String and must be converted later to numerical types
 // as appropriate.
 func SchemaFromPlainHeaders(hdrs []string, rec [][]string) (Schema, error) {
+	return schemaFromPlainHeaders(hdrs, rec, nil)
+}
+
+// schemaFromPlainHeaders configures a Table Schema based on plain headers,
+// ignoring any values in given na map of strings marking missing values.
+func schemaFromPlainHeaders(hdrs []string, rec [][]string, na map[string]bool) (Schema, error) {
 	sc := Schema{}
 	nr := len(rec)
 	for ci, hd := range hdrs {
@@ -328,7 +505,7 @@ func SchemaFromPlainHeaders(hdrs []string, rec [][]string) (Schema, error) {
 		nmatch := 0
 		for ri := 1; ri < nr; ri++ {
 			rv := rec[ri][ci]
-			if rv == "" {
+			if rv == "" || na[rv] {
 				continue
 			}
 			cdt := InferDataType(rv)
@@ -5,11 +5,17 @@
 package etable
 
 import (
+	"compress/gzip"
+	"encoding/csv"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/emer/etable/etensor"
+	"github.com/goki/gi/gi"
 )
 
 func TestEmerHeaders(t *testing.T) {
@@ -105,3 +111,184 @@ func TestReadEmerDat(t *testing.T) {
 		dt.WriteCSV(fo, '\t', Headers)
 	}
 }
+
+func TestReadCSVRowReader(t *testing.T) {
+	fp, err := os.Open("testdata/emer_simple_lines_5x5.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fp.Close()
+	bdt := &Table{}
+	err = bdt.ReadCSV(fp, Tab)
+	if err != nil {
+		t.Error(err)
+	}
+	fp.Seek(0, 0)
+	dt := New(bdt.Schema(), 0)
+	cr := csv.NewReader(fp)
+	cr.Comma = Tab.Rune()
+	for {
+		err = dt.ReadCSVRowReader(cr)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if dt.Rows != bdt.Rows {
+		t.Fatalf("ReadCSVRowReader: rows %v != %v\n", dt.Rows, bdt.Rows)
+	}
+	for ci, bc := range bdt.Cols {
+		sc := dt.Cols[ci]
+		if sc.Len() != bc.Len() {
+			t.Errorf("ReadCSVRowReader: col %v len %v != %v\n", ci, sc.Len(), bc.Len())
+			continue
+		}
+		for i := 0; i < bc.Len(); i++ {
+			if sc.StringVal1D(i) != bc.StringVal1D(i) {
+				t.Errorf("ReadCSVRowReader: col %v idx %v: %v != %v\n", ci, i, sc.StringVal1D(i), bc.StringVal1D(i))
+			}
+		}
+	}
+
+	// nulls must be tracked as the table grows
+	dt = New(Schema{{"Name", etensor.STRING, nil, nil}, {"Val", etensor.FLOAT64, nil, nil}}, 0)
+	cr = csv.NewReader(strings.NewReader("a,1\nb,\nc,3\nd,4\ne,\n"))
+	for dt.ReadCSVRowReader(cr) == nil {
+	}
+	if dt.Rows != 5 {
+		t.Errorf("ReadCSVRowReader: rows %v != 5\n", dt.Rows)
+	}
+	vc := dt.ColByName("Val")
+	for ri, nl := range []bool{false, true, false, false, true} {
+		if vc.IsNull1D(ri) != nl {
+			t.Errorf("ReadCSVRowReader: row %v null %v != %v\n", ri, vc.IsNull1D(ri), nl)
+		}
+	}
+	if dt.CellFloat("Val", 3) != 4 || dt.CellString("Name", 4) != "e" {
+		t.Errorf("ReadCSVRowReader: bad values: %v\n", vc)
+	}
+}
+
+func TestOpenCSVGzip(t *testing.T) {
+	src := "testdata/emer_simple_lines_5x5.dat"
+	dir, err := ioutil.TempDir("", "etable_gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	raw, err := ioutil.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gzfn := filepath.Join(dir, "lines.dat.gz")
+	fp, err := os.Create(gzfn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gw := gzip.NewWriter(fp)
+	gw.Write(raw)
+	gw.Close()
+	fp.Close()
+
+	pdt := &Table{}
+	if err := pdt.OpenCSV(gi.FileName(src), Tab); err != nil {
+		t.Fatal(err)
+	}
+	gdt := &Table{}
+	if err := gdt.OpenCSV(gi.FileName(gzfn), Tab); err != nil {
+		t.Fatal(err)
+	}
+	// streaming reader on the gzipped file
+	sdt := New(pdt.Schema(), 0)
+	rc, err := OpenCSVFile(gi.FileName(gzfn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr := csv.NewReader(rc)
+	cr.Comma = Tab.Rune()
+	for sdt.ReadCSVRowReader(cr) == nil {
+	}
+	if err := rc.Close(); err != nil {
+		t.Error(err)
+	}
+	for _, dt := range []*Table{gdt, sdt} {
+		if dt.Rows != pdt.Rows || dt.NumCols() != pdt.NumCols() {
+			t.Fatalf("OpenCSV gzip: rows %v cols %v != %v %v\n", dt.Rows, dt.NumCols(), pdt.Rows, pdt.NumCols())
+		}
+		for ci, pc := range pdt.Cols {
+			for i := 0; i < pc.Len(); i++ {
+				if dt.Cols[ci].StringVal1D(i) != pc.StringVal1D(i) {
+					t.Fatalf("OpenCSV gzip: col %v idx %v: %v != %v\n", ci, i, dt.Cols[ci].StringVal1D(i), pc.StringVal1D(i))
+				}
+			}
+		}
+	}
+}
+
+func TestReadCSVOpts(t *testing.T) {
+	csvs := "# results of run 1\nname,score\n# header done\nalpha,1.5\nbeta,NA\ngamma,null\n#delta,4\nepsilon,2\n"
+	dt := &Table{}
+	err := dt.ReadCSVOpts(strings.NewReader(csvs), &CSVOpts{Delim: Comma, Comment: '#', NAStrings: []string{"NA", "null"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dt.Rows != 4 || dt.NumCols() != 2 {
+		t.Fatalf("ReadCSVOpts: rows %v cols %v, expected 4 2\n", dt.Rows, dt.NumCols())
+	}
+	sc := dt.ColByName("score")
+	if sc.DataType() != etensor.FLOAT64 {
+		t.Errorf("ReadCSVOpts: score type %v, expected FLOAT64\n", sc.DataType())
+	}
+	nulls := []bool{false, true, true, false}
+	for ri, nl := range nulls {
+		if sc.IsNull1D(ri) != nl {
+			t.Errorf("ReadCSVOpts: score row %v null: %v, expected %v\n", ri, sc.IsNull1D(ri), nl)
+		}
+	}
+	if dt.CellString("name", 3) != "epsilon" || dt.CellFloat("score", 3) != 2 {
+		t.Errorf("ReadCSVOpts: row 3: %v %v\n", dt.CellString("name", 3), dt.CellFloat("score", 3))
+	}
+}
+
+func TestSchemaCSV(t *testing.T) {
+	dir, err := ioutil.TempDir("", "etable_schema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fn := filepath.Join(dir, "sample.csv")
+	csvs := "epoch,err,name\n1,0.5,alpha\n2,0.25,beta\n3,0.125,gamma\n4,0.1,delta\nbad,bad,10\n"
+	if err := ioutil.WriteFile(fn, []byte(csvs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sc, err := SchemaCSV(gi.FileName(fn), Comma, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := []etensor.Type{etensor.INT64, etensor.FLOAT64, etensor.STRING}
+	nms := []string{"epoch", "err", "name"}
+	if len(sc) != len(types) {
+		t.Fatalf("SchemaCSV: %d columns, not %d\n", len(sc), len(types))
+	}
+	for ci, typ := range types {
+		if sc[ci].Name != nms[ci] || sc[ci].Type != typ {
+			t.Errorf("SchemaCSV: col %d: %v %v, expected %v %v\n", ci, sc[ci].Name, sc[ci].Type, nms[ci], typ)
+		}
+	}
+	sc, err = SchemaCSV(gi.FileName(fn), Comma, 0) // all rows: last row makes epoch and err strings
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc[0].Type != etensor.STRING || sc[1].Type != etensor.STRING {
+		t.Errorf("SchemaCSV: all rows: types %v %v, expected STRING\n", sc[0].Type, sc[1].Type)
+	}
+	sc, err = SchemaCSV("testdata/emer_simple_lines_5x5.dat", Tab, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sc) == 0 || sc[len(sc)-1].CellShape == nil {
+		t.Errorf("SchemaCSV: emergent headers not parsed: %v\n", sc)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The join functions combine the rows of two tables that have the same values
+// in a set of key columns, which must be present in both tables with
+// 1-dimensional cells.  The result has the key columns first (with the column
+// types of the left table), followed by the other columns of the left table,
+// and then the other columns of the right table -- the non-key column names
+// must be unique across the two tables (returns an error otherwise).
+// Keys are compared as strings (using StringVal1D), so numeric key columns of
+// different types can be joined, and a key with a Null value matches nothing.
+// When a key value occurs in multiple rows of either table, all combinations
+// of those rows are included.  Rows are in the order of the left table, with
+// the matching rows for each left row in the order of the right table.
+
+// joinTypes are the types of join, determining which rows without a match
+// in the other table are kept
+type joinTypes int
+
+const (
+	innerJoin joinTypes = iota
+	leftJoin
+	outerJoin
+)
+
+// InnerJoin returns a new table with the combination of the rows of the left and
+// right tables that have the same values in the given key columns, dropping any
+// rows that do not have a match in the other table.  See the join functions notes
+// above for column layout, key matching and ordering details.
+func InnerJoin(left, right *Table, keyCols []string) (*Table, error) {
+	return join(innerJoin, left, right, keyCols)
+}
+
+// LeftJoin returns a new table with the combination of the rows of the left and
+// right tables that have the same values in the given key columns, as in
+// InnerJoin, also keeping the rows of the left table that have no match in the
+// right table, with all the right table columns set to Null.
+func LeftJoin(left, right *Table, keyCols []string) (*Table, error) {
+	return join(leftJoin, left, right, keyCols)
+}
+
+// OuterJoin returns a new table with the combination of the rows of the left and
+// right tables that have the same values in the given key columns, as in
+// InnerJoin, also keeping the rows of either table that have no match in the
+// other table, with all the columns of the other table set to Null.
+// The unmatched rows of the right table come last, in their original order,
+// with key values from the right table.
+func OuterJoin(left, right *Table, keyCols []string) (*Table, error) {
+	return join(outerJoin, left, right, keyCols)
+}
+
+// join implements the join functions
+func join(jt joinTypes, left, right *Table, keyCols []string) (*Table, error) {
+	if len(keyCols) == 0 {
+		return nil, fmt.Errorf("etable.Join: no key columns")
+	}
+	lkis, err := left.ColIdxsByNamesTry(keyCols)
+	if err != nil {
+		return nil, err
+	}
+	rkis, err := right.ColIdxsByNamesTry(keyCols)
+	if err != nil {
+		return nil, err
+	}
+	iskey := make(map[string]bool, len(keyCols))
+	for i, kc := range keyCols {
+		if left.Cols[lkis[i]].NumDims() > 1 || right.Cols[rkis[i]].NumDims() > 1 {
+			return nil, fmt.Errorf("etable.Join: key column %v is not 1-dimensional", kc)
+		}
+		iskey[kc] = true
+	}
+	lsc := left.Schema()
+	rsc := right.Schema()
+	sc := make(Schema, len(keyCols))
+	for i, ki := range lkis {
+		sc[i] = lsc[ki]
+	}
+	var lcis, rcis []int // non-key columns of each table
+	for ci, cl := range lsc {
+		if !iskey[cl.Name] {
+			lcis = append(lcis, ci)
+			sc = append(sc, cl)
+		}
+	}
+	for ci, cl := range rsc {
+		if iskey[cl.Name] {
+			continue
+		}
+		if _, has := left.ColNameMap[cl.Name]; has {
+			return nil, fmt.Errorf("etable.Join: non-key column %v is in both tables -- rename one of them first", cl.Name)
+		}
+		rcis = append(rcis, ci)
+		sc = append(sc, cl)
+	}
+
+	rmap := make(map[string][]int)
+	for ri := 0; ri < right.Rows; ri++ {
+		if key, ok := joinKey(right, rkis, ri); ok {
+			rmap[key] = append(rmap[key], ri)
+		}
+	}
+	type rowPair struct{ l, r int } // -1 = no row
+	var pairs []rowPair
+	rmatch := make([]bool, right.Rows)
+	for li := 0; li < left.Rows; li++ {
+		key, ok := joinKey(left, lkis, li)
+		rrows := rmap[key]
+		if !ok || len(rrows) == 0 {
+			if jt != innerJoin {
+				pairs = append(pairs, rowPair{li, -1})
+			}
+			continue
+		}
+		for _, ri := range rrows {
+			pairs = append(pairs, rowPair{li, ri})
+			rmatch[ri] = true
+		}
+	}
+	if jt == outerJoin {
+		for ri, m := range rmatch {
+			if !m {
+				pairs = append(pairs, rowPair{-1, ri})
+			}
+		}
+	}
+
+	dt := New(sc, len(pairs))
+	nk := len(keyCols)
+	for row, pr := range pairs {
+		for i := range keyCols {
+			if pr.l >= 0 {
+				dt.Cols[i].CopyCellsFrom(left.Cols[lkis[i]], row, pr.l, 1)
+			} else {
+				dt.Cols[i].CopyCellsFrom(right.Cols[rkis[i]], row, pr.r, 1)
+			}
+		}
+		joinCopyRow(dt, nk, left, lcis, row, pr.l)
+		joinCopyRow(dt, nk+len(lcis), right, rcis, row, pr.r)
+	}
+	return dt, nil
+}
+
+// joinKey returns the key string for given row of table with given key
+// column indexes, and false if any of the key values is Null
+func joinKey(dt *Table, kis []int, row int) (string, bool) {
+	strs := make([]string, len(kis))
+	for i, ki := range kis {
+		cl := dt.Cols[ki]
+		if cl.IsNull1D(row) {
+			return "", false
+		}
+		strs[i] = cl.StringVal1D(row)
+	}
+	return strings.Join(strs, "\x00"), true
+}
+
+// joinCopyRow copies the cells of given source row of given columns of src
+// table into the columns of dt table starting at stCol, for given row.
+// If srow < 0, the cells are all set to Null.
+func joinCopyRow(dt *Table, stCol int, src *Table, cis []int, row, srow int) {
+	for i, ci := range cis {
+		dc := dt.Cols[stCol+i]
+		_, csz := dc.RowCellSize()
+		if srow < 0 {
+			for j := row * csz; j < (row+1)*csz; j++ {
+				dc.SetNull1D(j, true)
+			}
+			continue
+		}
+		dc.CopyCellsFrom(src.Cols[ci], row*csz, srow*csz, csz)
+	}
+}
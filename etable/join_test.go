@@ -0,0 +1,81 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func testJoinTables() (left, right *Table) {
+	left = New(Schema{
+		{"ID", etensor.INT64, nil, nil},
+		{"Name", etensor.STRING, nil, nil},
+	}, 4)
+	right = New(Schema{
+		{"ID", etensor.INT64, nil, nil},
+		{"Score", etensor.FLOAT64, nil, nil},
+	}, 4)
+	for i, id := range []float64{1, 2, 3, 4} {
+		left.SetCellFloat("ID", i, id)
+		left.SetCellString("Name", i, string(rune('a'+i)))
+	}
+	for i, id := range []float64{2, 4, 4, 5} {
+		right.SetCellFloat("ID", i, id)
+		right.SetCellFloat("Score", i, float64(10*(i+1)))
+	}
+	return
+}
+
+func TestJoin(t *testing.T) {
+	left, right := testJoinTables()
+	dt, err := LeftJoin(left, right, []string{"ID"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1 and 3 have no match, 4 matches two rows
+	ids := []float64{1, 2, 3, 4, 4}
+	names := []string{"a", "b", "c", "d", "d"}
+	scores := []float64{0, 10, 0, 20, 30}
+	nulls := []bool{true, false, true, false, false}
+	if dt.Rows != len(ids) || dt.NumCols() != 3 {
+		t.Fatalf("LeftJoin: rows %v cols %v\n%v", dt.Rows, dt.NumCols(), dt)
+	}
+	sc := dt.ColByName("Score")
+	for i := range ids {
+		if dt.CellFloat("ID", i) != ids[i] || dt.CellString("Name", i) != names[i] {
+			t.Errorf("LeftJoin: row %v bad left values\n%v", i, dt)
+		}
+		if sc.IsNull1D(i) != nulls[i] || (!nulls[i] && sc.FloatVal1D(i) != scores[i]) {
+			t.Errorf("LeftJoin: row %v bad Score: %v null: %v\n", i, sc.FloatVal1D(i), sc.IsNull1D(i))
+		}
+	}
+
+	dt, err = InnerJoin(left, right, []string{"ID"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dt.Rows != 3 || dt.ColByName("Score").IsNull1D(0) {
+		t.Errorf("InnerJoin: bad result:\n%v", dt)
+	}
+
+	dt, err = OuterJoin(left, right, []string{"ID"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lr := dt.Rows - 1
+	if dt.Rows != 6 || dt.CellFloat("ID", lr) != 5 || !dt.ColByName("Name").IsNull1D(lr) || dt.CellFloat("Score", lr) != 40 {
+		t.Errorf("OuterJoin: bad result:\n%v", dt)
+	}
+
+	left.AddCol(etensor.NewFloat64([]int{4}, nil, nil), "Score")
+	if _, err := LeftJoin(left, right, []string{"ID"}); err == nil {
+		t.Errorf("LeftJoin: expected error for duplicate non-key column\n")
+	}
+	if _, err := LeftJoin(left, right, []string{"Nope"}); err == nil {
+		t.Errorf("LeftJoin: expected error for missing key column\n")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"math"
+
+	"github.com/emer/etable/etensor"
+)
+
+// ToMaps returns the table as a slice of maps, one per row, keyed by column
+// name, e.g., for encoding with json.Marshal as an array of row objects.
+// Values are string for STRING columns, bool for BOOL, int64 for integer
+// types, and float64 for float types.  Null values (and NaN and +/-Inf,
+// which JSON cannot represent) are nil, and n-dimensional cells are nested
+// []interface{} slices following the cell shape (outermost dimension first).
+func (dt *Table) ToMaps() []map[string]interface{} {
+	rows := make([]map[string]interface{}, dt.Rows)
+	for row := 0; row < dt.Rows; row++ {
+		rm := make(map[string]interface{}, len(dt.Cols))
+		for ci, cl := range dt.Cols {
+			_, csz := cl.RowCellSize()
+			if cl.NumDims() == 1 {
+				rm[dt.ColNames[ci]] = mapVal(cl, row)
+			} else {
+				rm[dt.ColNames[ci]] = mapCell(cl, cl.Shapes()[1:], row*csz)
+			}
+		}
+		rows[row] = rm
+	}
+	return rows
+}
+
+// mapCell returns the nested slice of values for the sub-cell of given shape
+// starting at given 1D index in the tensor
+func mapCell(cl etensor.Tensor, shp []int, off int) []interface{} {
+	vs := make([]interface{}, shp[0])
+	if len(shp) == 1 {
+		for i := range vs {
+			vs[i] = mapVal(cl, off+i)
+		}
+		return vs
+	}
+	sz := 1
+	for _, s := range shp[1:] {
+		sz *= s
+	}
+	for i := range vs {
+		vs[i] = mapCell(cl, shp[1:], off+i*sz)
+	}
+	return vs
+}
+
+// mapVal returns the value at given 1D index in the tensor, for ToMaps
+func mapVal(cl etensor.Tensor, i int) interface{} {
+	if cl.IsNull1D(i) {
+		return nil
+	}
+	switch cl.DataType() {
+	case etensor.STRING:
+		return cl.StringVal1D(i)
+	case etensor.BOOL:
+		return cl.FloatVal1D(i) != 0
+	case etensor.FLOAT16, etensor.FLOAT32, etensor.FLOAT64:
+		v := cl.FloatVal1D(i)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil
+		}
+		return v
+	case etensor.INT64:
+		if ic, ok := cl.(*etensor.Int64); ok {
+			return ic.Values[i] // avoid float64 precision loss
+		}
+		return int64(cl.FloatVal1D(i))
+	default:
+		return int64(cl.FloatVal1D(i))
+	}
+}
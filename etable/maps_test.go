@@ -0,0 +1,78 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestToMaps(t *testing.T) {
+	dt := New(Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Num", etensor.INT64, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"Pat", etensor.FLOAT32, []int{2, 3}, nil},
+	}, 2)
+	dt.SetCellString("Name", 0, "a")
+	dt.SetCellString("Name", 1, "b")
+	dt.SetCellFloat("Num", 0, 3)
+	dt.SetCellFloat("Val", 0, 1.5)
+	dt.ColByName("Val").SetNull1D(1, true)
+	pat := dt.ColByName("Pat")
+	for i := 0; i < 6; i++ {
+		pat.SetFloat1D(i, float64(i))
+	}
+	pat.SetNull1D(5, true)
+	ms := dt.ToMaps()
+	if len(ms) != 2 {
+		t.Fatalf("ToMaps: %v rows, expected 2\n", len(ms))
+	}
+	if ms[1]["Val"] != nil {
+		t.Errorf("ToMaps: null value is %v, expected nil\n", ms[1]["Val"])
+	}
+	if ms[0]["Num"] != int64(3) {
+		t.Errorf("ToMaps: int value is %#v, expected int64(3)\n", ms[0]["Num"])
+	}
+	b, err := json.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `[{"Name":"a","Num":3,"Pat":[[0,1,2],[3,4,null]],"Val":1.5},{"Name":"b","Num":0,"Pat":[[0,0,0],[0,0,0]],"Val":null}]`
+	if string(b) != exp {
+		t.Errorf("ToMaps JSON:\n%s\nexpected:\n%s\n", b, exp)
+	}
+}
+
+func TestToMapsInfInt64(t *testing.T) {
+	dt := New(Schema{
+		{"Num", etensor.INT64, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+	}, 2)
+	big := int64(1<<60 + 1) // beyond float64 precision
+	dt.ColByName("Num").(*etensor.Int64).Values[0] = big
+	dt.SetCellFloat("Val", 0, math.Inf(1))
+	dt.SetCellFloat("Val", 1, math.Inf(-1))
+	ms := dt.ToMaps()
+	if ms[0]["Num"] != big {
+		t.Errorf("ToMaps: int64 value is %#v, expected %v\n", ms[0]["Num"], big)
+	}
+	for r := range ms {
+		if ms[r]["Val"] != nil {
+			t.Errorf("ToMaps: Inf value at row %v is %v, expected nil\n", r, ms[r]["Val"])
+		}
+	}
+	b, err := json.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `[{"Num":1152921504606846977,"Val":null},{"Num":0,"Val":null}]`
+	if string(b) != exp {
+		t.Errorf("ToMaps JSON:\n%s\nexpected:\n%s\n", b, exp)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/emer/etable/etensor"
+	"github.com/goki/ki/ints"
+)
+
+// ToMatrix returns a new 2D row-major Float64 tensor of shape [Rows, len(colNames)]
+// with the values of the named columns, in order, as the columns of the matrix,
+// e.g., for use in PCA, clustering, or gonum matrix operations.
+// If colNames is empty, all of the 1D numeric columns in the table are used.
+// Null values are set to NaN.  Returns an error if a column is not found,
+// or is a string or n-dimensional column.
+func (dt *Table) ToMatrix(colNames []string) (*etensor.Float64, error) {
+	var cols []etensor.Tensor
+	if len(colNames) == 0 {
+		for _, cl := range dt.Cols {
+			if cl.NumDims() == 1 && cl.DataType() != etensor.STRING {
+				cols = append(cols, cl)
+			}
+		}
+	} else {
+		for _, cn := range colNames {
+			cl, err := dt.ColByNameTry(cn)
+			if err != nil {
+				return nil, err
+			}
+			if cl.DataType() == etensor.STRING {
+				return nil, fmt.Errorf("etable.Table ToMatrix: column %v is a string column", cn)
+			}
+			if cl.NumDims() > 1 {
+				return nil, fmt.Errorf("etable.Table ToMatrix: column %v has n-dimensional cells -- only 1D columns are supported", cn)
+			}
+			cols = append(cols, cl)
+		}
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("etable.Table ToMatrix: no numeric columns")
+	}
+	nc := len(cols)
+	mat := etensor.NewFloat64([]int{dt.Rows, nc}, nil, []string{"row", "col"})
+	for ri := 0; ri < dt.Rows; ri++ {
+		for ci, cl := range cols {
+			if cl.IsNull1D(ri) {
+				mat.Values[ri*nc+ci] = math.NaN()
+			} else {
+				mat.Values[ri*nc+ci] = cl.FloatVal1D(ri)
+			}
+		}
+	}
+	return mat, nil
+}
+
+// CorrelMatrix returns the symmetric matrix of Pearson correlations among the
+// named 1D numeric columns (all of them if colNames is empty -- see ToMatrix),
+// as a Float64 tensor of shape [len(colNames), len(colNames)].
+// Rows where any of the columns is Null or NaN are excluded (listwise deletion),
+// so all correlations are computed over the same set of rows.
+// The diagonal is always 1.  Correlations involving a column with zero
+// variance (e.g., a constant column) are undefined and set to 0, consistent
+// with metric.Correlation64.  Returns an error if a column is not valid
+// for ToMatrix.  For more than CorrelMatrixParallelThr values (rows * columns
+// * columns), the per-column sums are computed in parallel, with results
+// identical to the serial computation.
+func (dt *Table) CorrelMatrix(colNames []string) (*etensor.Float64, error) {
+	return dt.correlMatrix(colNames, -1)
+}
+
+// CorrelMatrixParallelThr is the threshold number of values (rows * columns
+// * columns) above which CorrelMatrix computes the sums for different columns
+// in parallel goroutines, to speed up wide tables.
+var CorrelMatrixParallelThr = 1000000
+
+// correlMatrix computes CorrelMatrix, in parallel if parallel > 0, serially
+// if parallel == 0, and according to CorrelMatrixParallelThr if < 0.
+func (dt *Table) correlMatrix(colNames []string, parallel int) (*etensor.Float64, error) {
+	mat, err := dt.ToMatrix(colNames)
+	if err != nil {
+		return nil, err
+	}
+	nc := mat.Dim(1)
+	var rows []int
+	for ri := 0; ri < dt.Rows; ri++ {
+		ok := true
+		for ci := 0; ci < nc; ci++ {
+			if math.IsNaN(mat.Values[ri*nc+ci]) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			rows = append(rows, ri)
+		}
+	}
+	means := make([]float64, nc)
+	for _, ri := range rows {
+		for ci := 0; ci < nc; ci++ {
+			means[ci] += mat.Values[ri*nc+ci]
+		}
+	}
+	if len(rows) > 0 {
+		for ci := range means {
+			means[ci] /= float64(len(rows))
+		}
+	}
+	cov := make([]float64, nc*nc) // sums of co-deviations
+	// each cov element sums over rows in order, so the order in which columns
+	// are computed does not affect the results
+	covCol := func(i int) {
+		for _, ri := range rows {
+			di := mat.Values[ri*nc+i] - means[i]
+			for j := i; j < nc; j++ {
+				cov[i*nc+j] += di * (mat.Values[ri*nc+j] - means[j])
+			}
+		}
+	}
+	if parallel < 0 {
+		parallel = 0
+		if nc > 1 && len(rows)*nc*nc > CorrelMatrixParallelThr {
+			parallel = 1
+		}
+	}
+	if parallel == 0 {
+		for i := 0; i < nc; i++ {
+			covCol(i)
+		}
+	} else {
+		nw := ints.MinInt(runtime.NumCPU(), nc)
+		var wg sync.WaitGroup
+		for w := 0; w < nw; w++ {
+			wg.Add(1)
+			go func(w int) {
+				for i := w; i < nc; i += nw { // interleaved, as earlier columns have more work
+					covCol(i)
+				}
+				wg.Done()
+			}(w)
+		}
+		wg.Wait()
+	}
+	cm := etensor.NewFloat64([]int{nc, nc}, nil, []string{"col", "col"})
+	for i := 0; i < nc; i++ {
+		cm.Values[i*nc+i] = 1
+		for j := i + 1; j < nc; j++ {
+			vp := math.Sqrt(cov[i*nc+i] * cov[j*nc+j])
+			r := 0.0
+			if vp > 0 {
+				r = cov[i*nc+j] / vp
+			}
+			cm.Values[i*nc+j] = r
+			cm.Values[j*nc+i] = r
+		}
+	}
+	return cm, nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestToMatrix(t *testing.T) {
+	sc := Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"A", etensor.FLOAT64, nil, nil},
+		{"B", etensor.INT64, nil, nil},
+		{"C", etensor.FLOAT32, nil, nil},
+		{"Pat", etensor.FLOAT32, []int{2, 2}, nil},
+	}
+	dt := New(sc, 4)
+	for ri := 0; ri < 4; ri++ {
+		dt.SetCellFloat("A", ri, float64(ri))
+		dt.SetCellFloat("B", ri, float64(10*ri))
+		dt.SetCellFloat("C", ri, float64(100*ri))
+	}
+	dt.ColByName("B").SetNull1D(2, true)
+	mat, err := dt.ToMatrix([]string{"C", "A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mat.Dim(0) != 4 || mat.Dim(1) != 3 {
+		t.Fatalf("ToMatrix: shape %v\n", mat.Shapes())
+	}
+	for ri := 0; ri < 4; ri++ {
+		if mat.Value([]int{ri, 0}) != float64(100*ri) || mat.Value([]int{ri, 1}) != float64(ri) {
+			t.Errorf("ToMatrix: row %v: %v\n", ri, mat.Values[ri*3:ri*3+3])
+		}
+		bv := mat.Value([]int{ri, 2})
+		if ri == 2 {
+			if !math.IsNaN(bv) {
+				t.Errorf("ToMatrix: null not NaN: %v\n", bv)
+			}
+		} else if bv != float64(10*ri) {
+			t.Errorf("ToMatrix: row %v col B: %v\n", ri, bv)
+		}
+	}
+	all, err := dt.ToMatrix(nil)
+	if err != nil || all.Dim(1) != 3 || all.Value([]int{1, 0}) != 1 {
+		t.Errorf("ToMatrix(nil): %v %v\n", err, all)
+	}
+	if _, err := dt.ToMatrix([]string{"A", "Name"}); err == nil {
+		t.Errorf("ToMatrix: expected error for string column\n")
+	}
+	if _, err := dt.ToMatrix([]string{"Pat"}); err == nil {
+		t.Errorf("ToMatrix: expected error for n-dim column\n")
+	}
+}
+
+func TestCorrelMatrix(t *testing.T) {
+	sc := Schema{
+		{"A", etensor.FLOAT64, nil, nil},
+		{"B", etensor.FLOAT64, nil, nil},
+		{"C", etensor.FLOAT64, nil, nil},
+		{"K", etensor.FLOAT64, nil, nil},
+	}
+	dt := New(sc, 6)
+	for ri := 0; ri < 6; ri++ {
+		dt.SetCellFloat("A", ri, float64(ri))
+		dt.SetCellFloat("B", ri, 3*float64(ri)+2)
+		dt.SetCellFloat("C", ri, -.5*float64(ri))
+		dt.SetCellFloat("K", ri, 7)
+	}
+	dt.SetCellFloat("B", 5, 100) // null row is excluded, so this does not count
+	dt.ColByName("B").SetNull1D(5, true)
+	cm, err := dt.CorrelMatrix([]string{"A", "B", "C", "K"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Dim(0) != 4 || cm.Dim(1) != 4 {
+		t.Fatalf("CorrelMatrix: shape %v\n", cm.Shapes())
+	}
+	exp := [][]float64{
+		{1, 1, -1, 0},
+		{1, 1, -1, 0},
+		{-1, -1, 1, 0},
+		{0, 0, 0, 1},
+	}
+	for i := range exp {
+		for j, ev := range exp[i] {
+			if v := cm.Value([]int{i, j}); math.Abs(v-ev) > 1.0e-12 {
+				t.Errorf("CorrelMatrix: [%v, %v] %v != %v\n", i, j, v, ev)
+			}
+		}
+	}
+}
+
+// correlTable returns a table with given number of rows and float columns
+// with varied, partially correlated values, and a few null values
+func correlTable(rows, ncols int) *Table {
+	var sc Schema
+	for ci := 0; ci < ncols; ci++ {
+		sc = append(sc, Column{fmt.Sprintf("C%d", ci), etensor.FLOAT64, nil, nil})
+	}
+	dt := New(sc, rows)
+	for ci, cl := range dt.Cols {
+		for i := 0; i < rows; i++ {
+			cl.SetFloat1D(i, float64((i*(ci+3))%17)+0.1*float64(i))
+		}
+	}
+	dt.Cols[1].SetNull1D(3, true)
+	return dt
+}
+
+func TestCorrelMatrixParallel(t *testing.T) {
+	dt := correlTable(200, 40)
+	sm, err := dt.correlMatrix(nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pm, err := dt.correlMatrix(nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range sm.Values {
+		if pm.Values[i] != v {
+			t.Fatalf("CorrelMatrix parallel: value %v: %v != serial %v\n", i, pm.Values[i], v)
+		}
+	}
+}
+
+func BenchmarkCorrelMatrixSerial(b *testing.B) {
+	dt := correlTable(2000, 200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dt.correlMatrix(nil, 0)
+	}
+}
+
+func BenchmarkCorrelMatrixParallel(b *testing.B) {
+	dt := correlTable(2000, 200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dt.correlMatrix(nil, 1)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/emer/etable/etensor"
+)
+
+// MeanTables returns a new table with the element-wise mean of the values
+// in the given tables, e.g., across multiple runs of an experiment.
+// The tables must all have the same columns (names, types and cell shapes)
+// and number of rows.  Each numeric column in the result is a FLOAT64 column
+// with the mean of each value across the tables, excluding any Null or NaN
+// values from that mean -- values that are Null in all tables are Null.
+// String columns are copied from the first table, as are its meta data.
+func MeanTables(tables []*Table) (*Table, error) {
+	return aggTables(tables, "MeanTables", func(vals []float64) float64 {
+		sum := 0.0
+		for _, v := range vals {
+			sum += v
+		}
+		return sum / float64(len(vals))
+	})
+}
+
+// StdTables returns a new table with the element-wise sample standard deviation
+// (normalized by 1/(n-1)) of the values in the given tables, e.g., for error bars
+// on the MeanTables of multiple runs.  The same requirements and handling of Null
+// values and String columns apply as in MeanTables.  Values that are valid in
+// only one table have a standard deviation of 0.
+func StdTables(tables []*Table) (*Table, error) {
+	return aggTables(tables, "StdTables", func(vals []float64) float64 {
+		n := len(vals)
+		if n < 2 {
+			return 0
+		}
+		mean := 0.0
+		for _, v := range vals {
+			mean += v
+		}
+		mean /= float64(n)
+		ss := 0.0
+		for _, v := range vals {
+			ss += (v - mean) * (v - mean)
+		}
+		return math.Sqrt(ss / float64(n-1))
+	})
+}
+
+// aggTables returns a new table with each numeric value computed by fun from
+// the non-Null, non-NaN values at that position in the given tables,
+// for MeanTables and StdTables.  fnm is the name used in error messages.
+func aggTables(tables []*Table, fnm string, fun func(vals []float64) float64) (*Table, error) {
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("etable.%s: no tables", fnm)
+	}
+	ft := tables[0]
+	sc := ft.Schema()
+	for ti, dt := range tables[1:] {
+		if dt.Rows != ft.Rows {
+			return nil, fmt.Errorf("etable.%s: table %d has %d rows, not %d", fnm, ti+1, dt.Rows, ft.Rows)
+		}
+		if err := sameSchema(sc, dt.Schema()); err != nil {
+			return nil, fmt.Errorf("etable.%s: table %d: %v", fnm, ti+1, err)
+		}
+	}
+	for i := range sc {
+		if sc[i].Type != etensor.STRING {
+			sc[i].Type = etensor.FLOAT64
+		}
+	}
+	rt := New(sc, ft.Rows)
+	rt.CopyMetaDataFrom(ft)
+	vals := make([]float64, 0, len(tables))
+	for ci, rc := range rt.Cols {
+		if rc.DataType() == etensor.STRING {
+			rc.CopyFrom(ft.Cols[ci])
+			continue
+		}
+		_, csz := rc.RowCellSize()
+		for i := 0; i < ft.Rows*csz; i++ {
+			vals = vals[:0]
+			for _, dt := range tables {
+				cl := dt.Cols[ci]
+				if v := cl.FloatVal1D(i); !cl.IsNull1D(i) && !math.IsNaN(v) {
+					vals = append(vals, v)
+				}
+			}
+			if len(vals) == 0 {
+				rc.SetNull1D(i, true)
+				continue
+			}
+			rc.SetFloat1D(i, fun(vals))
+		}
+	}
+	return rt, nil
+}
+
+// sameSchema returns an error if the two schemas differ in the names,
+// types or cell shapes of their columns
+func sameSchema(sc, osc Schema) error {
+	if len(sc) != len(osc) {
+		return fmt.Errorf("has %d columns, not %d", len(osc), len(sc))
+	}
+	for i := range sc {
+		c, oc := &sc[i], &osc[i]
+		if c.Name != oc.Name || c.Type != oc.Type || !etensor.EqualInts(c.CellShape, oc.CellShape) {
+			return fmt.Errorf("column %d is %v %v %v, not %v %v %v", i, oc.Name, oc.Type, oc.CellShape, c.Name, c.Type, c.CellShape)
+		}
+	}
+	return nil
+}
+
+// Accum accumulates the element-wise mean and standard deviation of the values
+// in a sequence of tables with the same columns and number of rows, one at a
+// time without storing them, e.g., over a long training loop, with the same
+// results as MeanTables and StdTables on all of the tables.  It uses Welford's
+// online algorithm for each value.  Null and NaN values are excluded, and do
+// not count toward the number of values at that position.
+type Accum struct {
+	First *Table      `desc:"copy of the first table added, which determines the columns, and provides the String column values and meta data of the results"`
+	N     [][]float64 `desc:"number of valid values at each position of each column"`
+	Mean  [][]float64 `desc:"running mean at each position of each column"`
+	M2    [][]float64 `desc:"running sum of squared differences from the mean at each position of each column"`
+}
+
+// Reset resets the accumulator to start over with no tables
+func (ac *Accum) Reset() {
+	*ac = Accum{}
+}
+
+// Add adds the values of given table to the accumulated statistics.
+// Returns error if the table does not have the same columns and number
+// of rows as the first table added.
+func (ac *Accum) Add(dt *Table) error {
+	if ac.First == nil {
+		ac.First = dt.Clone()
+		nc := dt.NumCols()
+		ac.N = make([][]float64, nc)
+		ac.Mean = make([][]float64, nc)
+		ac.M2 = make([][]float64, nc)
+		for ci, cl := range dt.Cols {
+			if cl.DataType() == etensor.STRING {
+				continue
+			}
+			_, csz := cl.RowCellSize()
+			n := dt.Rows * csz
+			ac.N[ci] = make([]float64, n)
+			ac.Mean[ci] = make([]float64, n)
+			ac.M2[ci] = make([]float64, n)
+		}
+	} else {
+		if dt.Rows != ac.First.Rows {
+			return fmt.Errorf("etable.Accum Add: table has %d rows, not %d", dt.Rows, ac.First.Rows)
+		}
+		if err := sameSchema(ac.First.Schema(), dt.Schema()); err != nil {
+			return fmt.Errorf("etable.Accum Add: %v", err)
+		}
+	}
+	for ci, cl := range dt.Cols {
+		ns, means, m2s := ac.N[ci], ac.Mean[ci], ac.M2[ci]
+		for i := range ns {
+			v := cl.FloatVal1D(i)
+			if cl.IsNull1D(i) || math.IsNaN(v) {
+				continue
+			}
+			ns[i]++
+			d := v - means[i]
+			means[i] += d / ns[i]
+			m2s[i] += d * (v - means[i])
+		}
+	}
+	return nil
+}
+
+// Result returns new tables with the element-wise mean and sample standard
+// deviation (normalized by 1/(n-1)) of the tables added so far, in the same
+// form as MeanTables and StdTables.  Returns nil tables if none have been added.
+func (ac *Accum) Result() (mean, std *Table) {
+	if ac.First == nil {
+		return nil, nil
+	}
+	sc := ac.First.Schema()
+	for i := range sc {
+		if sc[i].Type != etensor.STRING {
+			sc[i].Type = etensor.FLOAT64
+		}
+	}
+	mean = New(sc, ac.First.Rows)
+	std = New(sc, ac.First.Rows)
+	mean.CopyMetaDataFrom(ac.First)
+	std.CopyMetaDataFrom(ac.First)
+	for ci, fc := range ac.First.Cols {
+		mc, stc := mean.Cols[ci], std.Cols[ci]
+		if fc.DataType() == etensor.STRING {
+			mc.CopyFrom(fc)
+			stc.CopyFrom(fc)
+			continue
+		}
+		for i, n := range ac.N[ci] {
+			switch {
+			case n == 0:
+				mc.SetNull1D(i, true)
+				stc.SetNull1D(i, true)
+			case n == 1:
+				mc.SetFloat1D(i, ac.Mean[ci][i])
+			default:
+				mc.SetFloat1D(i, ac.Mean[ci][i])
+				stc.SetFloat1D(i, math.Sqrt(ac.M2[ci][i]/(n-1)))
+			}
+		}
+	}
+	return
+}
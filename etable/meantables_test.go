@@ -0,0 +1,107 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestMeanTables(t *testing.T) {
+	sc := Schema{
+		{"Run", etensor.STRING, nil, nil},
+		{"Err", etensor.INT64, nil, nil},
+		{"Vec", etensor.FLOAT32, []int{2}, nil},
+	}
+	var tables []*Table
+	for ti := 0; ti < 3; ti++ {
+		dt := New(sc, 2)
+		for ri := 0; ri < 2; ri++ {
+			dt.SetCellString("Run", ri, "r")
+			dt.SetCellFloat("Err", ri, float64(ti+ri))
+			dt.Cols[2].SetFloat1D(ri*2, float64(ti))
+			dt.Cols[2].SetFloat1D(ri*2+1, float64(ti*2))
+		}
+		tables = append(tables, dt)
+	}
+	tables[2].ColByName("Err").SetNull1D(0, true) // Err row 0 values: 0, 1, null
+	mt, err := MeanTables(tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mt.NumCols() != 3 || mt.Rows != 2 || mt.ColByName("Err").DataType() != etensor.FLOAT64 {
+		t.Fatalf("MeanTables: bad result schema: %v\n", mt.Schema())
+	}
+	if v := mt.CellFloat("Err", 0); v != 0.5 {
+		t.Errorf("MeanTables: Err row 0: %v, expected 0.5 excluding null\n", v)
+	}
+	if v := mt.CellFloat("Err", 1); v != 2 {
+		t.Errorf("MeanTables: Err row 1: %v, expected 2\n", v)
+	}
+	if v0, v1 := mt.Cols[2].FloatVal1D(2), mt.Cols[2].FloatVal1D(3); v0 != 1 || v1 != 2 {
+		t.Errorf("MeanTables: Vec row 1: %v %v, expected 1 2\n", v0, v1)
+	}
+	if s := mt.CellString("Run", 1); s != "r" {
+		t.Errorf("MeanTables: Run row 1: %v, expected r\n", s)
+	}
+	st, err := StdTables(tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := st.CellFloat("Err", 0); math.Abs(v-math.Sqrt(0.5)) > 1e-12 {
+		t.Errorf("StdTables: Err row 0: %v, expected %v\n", v, math.Sqrt(0.5))
+	}
+	if v := st.CellFloat("Err", 1); v != 1 {
+		t.Errorf("StdTables: Err row 1: %v, expected 1\n", v)
+	}
+	tables[1].AddRows(1)
+	if _, err := MeanTables(tables); err == nil {
+		t.Errorf("MeanTables: no error for different row counts\n")
+	}
+}
+
+func TestAccum(t *testing.T) {
+	sc := Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"Vec", etensor.FLOAT32, []int{3}, nil},
+	}
+	var tables []*Table
+	ac := &Accum{}
+	for ti := 0; ti < 5; ti++ {
+		dt := New(sc, 4)
+		for ri := 0; ri < 4; ri++ {
+			dt.SetCellString("Name", ri, "n")
+			dt.SetCellFloat("Val", ri, math.Sin(float64(ti*4+ri))*100)
+			for j := 0; j < 3; j++ {
+				dt.Cols[2].SetFloat1D(ri*3+j, float64(ti*j)+0.1*float64(ri))
+			}
+		}
+		if ti == 1 {
+			dt.Cols[1].SetNull1D(2, true)
+		}
+		if ti > 0 {
+			dt.Cols[1].SetNull1D(3, true) // only one valid value
+		}
+		tables = append(tables, dt)
+		if err := ac.Add(dt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	am, as := ac.Result()
+	mt, _ := MeanTables(tables)
+	st, _ := StdTables(tables)
+	if d := mt.DiffCells(am, 1e-10); d != nil {
+		t.Errorf("Accum: mean differs from MeanTables: %v\n", d)
+	}
+	if d := st.DiffCells(as, 1e-10); d != nil {
+		t.Errorf("Accum: std differs from StdTables: %v\n", d)
+	}
+	if err := ac.Add(New(sc, 3)); err == nil {
+		t.Errorf("Accum: no error for different row count\n")
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import "github.com/emer/etable/etensor"
+
+// NullReport returns a new table summarizing the missing data in this table,
+// with one row per column, and columns: Col (the column name), Cells (total
+// number of cells, i.e., Rows * cell size for n-dimensional columns), Nulls
+// (number of cells flagged as Null), and NullFrac (Nulls / Cells, 0 if no cells).
+func (dt *Table) NullReport() *Table {
+	nc := dt.NumCols()
+	rt := New(Schema{
+		{"Col", etensor.STRING, nil, nil},
+		{"Cells", etensor.INT64, nil, nil},
+		{"Nulls", etensor.INT64, nil, nil},
+		{"NullFrac", etensor.FLOAT64, nil, nil},
+	}, nc)
+	for ci, cl := range dt.Cols {
+		_, csz := cl.RowCellSize()
+		n := dt.Rows * csz
+		nnull := 0
+		for i := 0; i < n; i++ {
+			if cl.IsNull1D(i) {
+				nnull++
+			}
+		}
+		frac := 0.0
+		if n > 0 {
+			frac = float64(nnull) / float64(n)
+		}
+		rt.SetCellStringIdx(0, ci, dt.ColNames[ci])
+		rt.SetCellFloatIdx(1, ci, float64(n))
+		rt.SetCellFloatIdx(2, ci, float64(nnull))
+		rt.SetCellFloatIdx(3, ci, frac)
+	}
+	return rt
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestNullReport(t *testing.T) {
+	dt := New(Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"Pat", etensor.FLOAT32, []int{2, 2}, nil},
+		{"Gone", etensor.INT64, nil, nil},
+	}, 5)
+	dt.Cols[0].SetNull1D(3, true)
+	dt.Cols[1].SetNull1D(0, true)
+	dt.Cols[1].SetNull1D(4, true)
+	for _, i := range []int{1, 6, 7, 19} { // scattered across rows 0, 1, 4
+		dt.Cols[2].SetNull1D(i, true)
+	}
+	for i := 0; i < 5; i++ {
+		dt.Cols[3].SetNull1D(i, true)
+	}
+	rt := dt.NullReport()
+	if rt.Rows != 4 {
+		t.Fatalf("NullReport: rows %v != 4\n", rt.Rows)
+	}
+	cells := []float64{5, 5, 20, 5}
+	nulls := []float64{1, 2, 4, 5}
+	fracs := []float64{0.2, 0.4, 0.2, 1}
+	for i := range cells {
+		if rt.CellString("Col", i) != dt.ColNames[i] {
+			t.Errorf("NullReport: row %v Col %v != %v\n", i, rt.CellString("Col", i), dt.ColNames[i])
+		}
+		if rt.CellFloat("Cells", i) != cells[i] || rt.CellFloat("Nulls", i) != nulls[i] || rt.CellFloat("NullFrac", i) != fracs[i] {
+			t.Errorf("NullReport: row %v: %v %v %v != %v %v %v\n", i, rt.CellFloat("Cells", i), rt.CellFloat("Nulls", i), rt.CellFloat("NullFrac", i), cells[i], nulls[i], fracs[i])
+		}
+	}
+}
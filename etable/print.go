@@ -0,0 +1,132 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/emer/etable/etensor"
+)
+
+// String satisfies the fmt.Stringer interface, returning the full table
+// rendered as a markdown-style grid -- see Sprint.
+func (dt *Table) String() string {
+	return dt.Sprint(0)
+}
+
+// Sprint returns the table rendered as an aligned markdown-style grid, with
+// column names as headers, for logging and quick inspection.  Numeric columns
+// are right-aligned and string columns left-aligned.  Columns with n-dimensional
+// cells show the shape of each cell in brackets (e.g., [5 5]), and null cells
+// are left blank.  If maxRows > 0 and the table has more rows than that, only
+// the first maxRows are shown, followed by a line with the number of rows omitted.
+// Any | in column names or string values is escaped as \| and newlines are
+// replaced with spaces, so they do not break the grid.
+func (dt *Table) Sprint(maxRows int) string {
+	cis := make([]int, dt.NumCols())
+	for ci := range cis {
+		cis[ci] = ci
+	}
+	return dt.sprintCols(cis, maxRows)
+}
+
+// SprintCols returns the table rendered as in Sprint, with only the given
+// columns, in the given order, and at most maxRows rows if maxRows > 0.
+// Logs an error and returns "" if any column name is not found
+// (see SprintColsTry for a version that returns the error).
+func (dt *Table) SprintCols(colNames []string, maxRows int) string {
+	s, err := dt.SprintColsTry(colNames, maxRows)
+	if err != nil {
+		log.Println(err)
+	}
+	return s
+}
+
+// SprintColsTry returns the table rendered as in Sprint, with only the given
+// columns, in the given order, and at most maxRows rows if maxRows > 0.
+// Returns an error if any column name is not found.
+func (dt *Table) SprintColsTry(colNames []string, maxRows int) (string, error) {
+	cis, err := dt.ColIdxsByNamesTry(colNames)
+	if err != nil {
+		return "", err
+	}
+	return dt.sprintCols(cis, maxRows), nil
+}
+
+// mdEscaper escapes the strings in a markdown grid cell, for sprintCols
+var mdEscaper = strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ", "\r", " ")
+
+// sprintCols renders the given columns, implementing Sprint and SprintCols
+func (dt *Table) sprintCols(cis []int, maxRows int) string {
+	nc := len(cis)
+	nr := dt.Rows
+	if maxRows > 0 && nr > maxRows {
+		nr = maxRows
+	}
+	cells := make([][]string, nc)
+	wds := make([]int, nc)
+	for ci, dci := range cis {
+		cl := dt.Cols[dci]
+		cn := mdEscaper.Replace(dt.ColNames[dci])
+		wds[ci] = len(cn)
+		cells[ci] = make([]string, nr)
+		for ri := 0; ri < nr; ri++ {
+			var s string
+			switch {
+			case cl.NumDims() > 1:
+				s = fmt.Sprintf("%v", cl.Shapes()[1:])
+			case cl.IsNull1D(ri):
+			default:
+				s = mdEscaper.Replace(cl.StringVal1D(ri))
+			}
+			cells[ci][ri] = s
+			if len(s) > wds[ci] {
+				wds[ci] = len(s)
+			}
+		}
+		if wds[ci] < 3 {
+			wds[ci] = 3 // room for the markdown alignment marker
+		}
+	}
+	rtAlign := func(ci int) bool {
+		cl := dt.Cols[cis[ci]]
+		return cl.NumDims() == 1 && cl.DataType() != etensor.STRING
+	}
+	var b strings.Builder
+	b.WriteString("|")
+	for ci := range cis {
+		if rtAlign(ci) {
+			fmt.Fprintf(&b, " %*s |", wds[ci], mdEscaper.Replace(dt.ColNames[cis[ci]]))
+		} else {
+			fmt.Fprintf(&b, " %-*s |", wds[ci], mdEscaper.Replace(dt.ColNames[cis[ci]]))
+		}
+	}
+	b.WriteString("\n|")
+	for ci := range cis {
+		if rtAlign(ci) {
+			b.WriteString(strings.Repeat("-", wds[ci]+1) + ":|")
+		} else {
+			b.WriteString(":" + strings.Repeat("-", wds[ci]+1) + "|")
+		}
+	}
+	b.WriteString("\n")
+	for ri := 0; ri < nr; ri++ {
+		b.WriteString("|")
+		for ci := range cis {
+			if rtAlign(ci) {
+				fmt.Fprintf(&b, " %*s |", wds[ci], cells[ci][ri])
+			} else {
+				fmt.Fprintf(&b, " %-*s |", wds[ci], cells[ci][ri])
+			}
+		}
+		b.WriteString("\n")
+	}
+	if nr < dt.Rows {
+		fmt.Fprintf(&b, "... %d more rows\n", dt.Rows-nr)
+	}
+	return b.String()
+}
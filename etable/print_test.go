@@ -0,0 +1,88 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestSprint(t *testing.T) {
+	sc := Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"N", etensor.INT64, nil, nil},
+		{"Pat", etensor.FLOAT32, []int{2, 3}, nil},
+	}
+	dt := New(sc, 3)
+	dt.SetCellString("Name", 0, "alpha")
+	dt.SetCellString("Name", 1, "b")
+	dt.SetCellString("Name", 2, "gamma_x")
+	dt.SetCellFloat("Val", 0, 1.5)
+	dt.SetCellFloat("Val", 1, -20)
+	dt.ColByName("Val").SetNull1D(2, true)
+	dt.SetCellFloat("N", 0, 7)
+	dt.SetCellFloat("N", 1, 1234)
+	dt.SetCellFloat("N", 2, 0)
+
+	exp := `| Name    | Val |    N | Pat   |
+|:--------|----:|-----:|:------|
+| alpha   | 1.5 |    7 | [2 3] |
+| b       | -20 | 1234 | [2 3] |
+| gamma_x |     |    0 | [2 3] |
+`
+	if s := dt.String(); s != exp {
+		t.Errorf("String: got:\n%v\nexpected:\n%v\n", s, exp)
+	}
+
+	exp = `| Name  | Val |   N | Pat   |
+|:------|----:|----:|:------|
+| alpha | 1.5 |   7 | [2 3] |
+... 2 more rows
+`
+	if s := dt.Sprint(1); s != exp {
+		t.Errorf("Sprint(1): got:\n%v\nexpected:\n%v\n", s, exp)
+	}
+}
+
+func TestSprintCols(t *testing.T) {
+	dt := New(Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"N", etensor.INT64, nil, nil},
+	}, 3)
+	for i, nm := range []string{"a", "b", "c"} {
+		dt.SetCellString("Name", i, nm)
+		dt.SetCellFloat("Val", i, float64(i)+0.5)
+		dt.SetCellFloat("N", i, float64(10*i))
+	}
+	exp := `|   N | Name |
+|----:|:-----|
+|   0 | a    |
+|  10 | b    |
+... 1 more rows
+`
+	if s := dt.SprintCols([]string{"N", "Name"}, 2); s != exp {
+		t.Errorf("SprintCols: got:\n%v\nexpected:\n%v\n", s, exp)
+	}
+	if _, err := dt.SprintColsTry([]string{"Name", "Nope"}, 0); err == nil {
+		t.Errorf("SprintColsTry: no error for unknown column\n")
+	}
+}
+
+func TestSprintEscape(t *testing.T) {
+	dt := New(Schema{{"A|B", etensor.STRING, nil, nil}}, 2)
+	dt.SetCellString("A|B", 0, "x|y")
+	dt.SetCellString("A|B", 1, "line1\nline2")
+	exp := `| A\|B        |
+|:------------|
+| x\|y        |
+| line1 line2 |
+`
+	if s := dt.Sprint(0); s != exp {
+		t.Errorf("Sprint escape:\n%s\nexpected:\n%s\n", s, exp)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/emer/etable/etensor"
+)
+
+// Resample returns a new table with the same columns as this one and nRows
+// rows, evenly spaced over the rows of this table, so that the first and last
+// rows of each are the same -- e.g., for aligning learning curves with different
+// numbers of epochs before averaging them.  Each value of a numeric column
+// (including each value within n-dimensional cells) is linearly interpolated
+// between the nearest rows on either side where it is not Null or NaN, so Null
+// gaps are interpolated across, and values before the first or after the last
+// valid row take the nearest valid value.  Values with no valid rows are Null.
+// Integer columns are rounded to the nearest integer.  String columns use
+// the value of the nearest row.
+func (dt *Table) Resample(nRows int) (*Table, error) {
+	if nRows < 1 {
+		return nil, fmt.Errorf("etable.Table Resample: nRows must be >= 1, is: %v", nRows)
+	}
+	if dt.Rows == 0 {
+		return nil, fmt.Errorf("etable.Table Resample: table has no rows")
+	}
+	rt := New(dt.Schema(), nRows)
+	rt.CopyMetaDataFrom(dt)
+	pos := make([]float64, nRows)
+	if nRows > 1 {
+		for i := range pos {
+			pos[i] = float64(i*(dt.Rows-1)) / float64(nRows-1)
+		}
+	}
+	for ci, cl := range dt.Cols {
+		rc := rt.Cols[ci]
+		_, csz := cl.RowCellSize()
+		if cl.DataType() == etensor.STRING {
+			for i, p := range pos {
+				rc.CopyCellsFrom(cl, i*csz, int(math.Round(p))*csz, csz)
+			}
+			continue
+		}
+		isInt := cl.DataType() != etensor.FLOAT32 && cl.DataType() != etensor.FLOAT64
+		valid := make([]int, 0, dt.Rows)
+		for j := 0; j < csz; j++ {
+			valid = valid[:0]
+			for ri := 0; ri < dt.Rows; ri++ {
+				i := ri*csz + j
+				if !cl.IsNull1D(i) && !math.IsNaN(cl.FloatVal1D(i)) {
+					valid = append(valid, ri)
+				}
+			}
+			nv := len(valid)
+			for i, p := range pos {
+				ti := i*csz + j
+				if nv == 0 {
+					rc.SetNull1D(ti, true)
+					continue
+				}
+				hi := sort.Search(nv, func(k int) bool { return float64(valid[k]) >= p })
+				var v float64
+				switch {
+				case hi == 0:
+					v = cl.FloatVal1D(valid[0]*csz + j)
+				case hi == nv:
+					v = cl.FloatVal1D(valid[nv-1]*csz + j)
+				default:
+					lr, hr := valid[hi-1], valid[hi]
+					lv, hv := cl.FloatVal1D(lr*csz+j), cl.FloatVal1D(hr*csz+j)
+					v = lv + (hv-lv)*(p-float64(lr))/float64(hr-lr)
+				}
+				if isInt {
+					v = math.Round(v)
+				}
+				rc.SetFloat1D(ti, v)
+			}
+		}
+	}
+	return rt, nil
+}
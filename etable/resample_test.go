@@ -0,0 +1,54 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestResample(t *testing.T) {
+	dt := New(Schema{
+		{"Val", etensor.FLOAT64, nil, nil},
+		{"Gap", etensor.FLOAT64, nil, nil},
+		{"Int", etensor.INT64, nil, nil},
+		{"Name", etensor.STRING, nil, nil},
+	}, 3)
+	for ri := 0; ri < 3; ri++ {
+		dt.SetCellFloat("Val", ri, float64(ri*10))
+		dt.SetCellFloat("Gap", ri, float64(ri*10))
+		dt.SetCellFloat("Int", ri, float64(ri))
+		dt.SetCellString("Name", ri, []string{"a", "b", "c"}[ri])
+	}
+	dt.ColByName("Gap").SetNull1D(1, true)
+	rt, err := dt.Resample(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rt.Rows != 5 {
+		t.Fatalf("Resample: rows %v, expected 5\n", rt.Rows)
+	}
+	expv := []float64{0, 5, 10, 15, 20}
+	expi := []float64{0, 1, 1, 2, 2}
+	exps := []string{"a", "b", "b", "c", "c"}
+	for ri := 0; ri < 5; ri++ {
+		if v := rt.CellFloat("Val", ri); v != expv[ri] {
+			t.Errorf("Resample: Val row %v: %v, expected %v\n", ri, v, expv[ri])
+		}
+		if v := rt.CellFloat("Gap", ri); v != expv[ri] || rt.ColByName("Gap").IsNull1D(ri) {
+			t.Errorf("Resample: Gap row %v: %v, expected %v\n", ri, v, expv[ri])
+		}
+		if v := rt.CellFloat("Int", ri); v != expi[ri] {
+			t.Errorf("Resample: Int row %v: %v, expected %v\n", ri, v, expi[ri])
+		}
+		if s := rt.CellString("Name", ri); s != exps[ri] {
+			t.Errorf("Resample: Name row %v: %v, expected %v\n", ri, s, exps[ri])
+		}
+	}
+	if _, err := dt.Resample(0); err == nil {
+		t.Errorf("Resample: no error for 0 rows\n")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+
+	"github.com/emer/etable/etensor"
+)
+
+// RowKey returns a stable 64-bit hash (FNV-1a) of the values of the given
+// columns at given row, for use as a map key in dedup and join operations.
+// The hash is deterministic across runs and platforms: string values are
+// hashed by their bytes, and all numeric types by their float64 value,
+// so numerically equal values in columns of different types (e.g., INT64 and
+// FLOAT64) hash equal, with -0 equal to 0 and all NaN values equal.
+// Null values hash differently from any other value, and all the elements
+// of n-dimensional cells are included.  As with any hash, distinct keys can
+// collide (rarely), so the values should be compared directly when exactness
+// matters.
+func (dt *Table) RowKey(colIdxs []int, row int) uint64 {
+	h := fnv.New64a()
+	var buf [9]byte
+	for _, ci := range colIdxs {
+		cl := dt.Cols[ci]
+		_, csz := cl.RowCellSize()
+		for i := row * csz; i < (row+1)*csz; i++ {
+			switch {
+			case cl.IsNull1D(i):
+				buf[0] = 0
+				h.Write(buf[:1])
+			case cl.DataType() == etensor.STRING:
+				s := cl.StringVal1D(i)
+				buf[0] = 1
+				binary.LittleEndian.PutUint64(buf[1:], uint64(len(s))) // length prefix separates values
+				h.Write(buf[:])
+				h.Write([]byte(s))
+			default:
+				v := cl.FloatVal1D(i)
+				switch {
+				case v == 0:
+					v = 0
+				case math.IsNaN(v):
+					v = math.NaN()
+				}
+				buf[0] = 2
+				binary.LittleEndian.PutUint64(buf[1:], math.Float64bits(v))
+				h.Write(buf[:])
+			}
+		}
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestRowKey(t *testing.T) {
+	dt := New(Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"F", etensor.FLOAT64, nil, nil},
+		{"I", etensor.INT64, nil, nil},
+	}, 4)
+	dt.SetCellString("Name", 0, "ab")
+	dt.SetCellString("Name", 1, "ab")
+	dt.SetCellString("Name", 2, "a")
+	dt.SetCellString("Name", 3, "ab")
+	dt.SetCellFloat("F", 0, 3)
+	dt.SetCellFloat("F", 1, 3)
+	dt.SetCellFloat("F", 2, math.Copysign(0, -1))
+	dt.SetCellFloat("F", 3, math.NaN())
+	dt.SetCellFloat("I", 0, 3)
+	dt.SetCellFloat("I", 2, 0)
+	dt.Cols[2].SetNull1D(3, true)
+	key := []int{0, 1}
+	if dt.RowKey(key, 0) != dt.RowKey(key, 1) {
+		t.Errorf("RowKey: equal rows hash differently\n")
+	}
+	if dt.RowKey(key, 0) == dt.RowKey(key, 2) || dt.RowKey(key, 0) == dt.RowKey(key, 3) {
+		t.Errorf("RowKey: distinct rows hash equal\n")
+	}
+	if dt.RowKey([]int{1}, 0) != dt.RowKey([]int{2}, 0) || dt.RowKey([]int{1}, 2) != dt.RowKey([]int{2}, 2) {
+		t.Errorf("RowKey: equal numbers in FLOAT64 and INT64 columns (including -0 and 0) hash differently\n")
+	}
+	if dt.RowKey([]int{2}, 3) == dt.RowKey([]int{2}, 1) {
+		t.Errorf("RowKey: Null hashes equal to 0\n")
+	}
+
+	n := 10000
+	st := New(Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+	}, n)
+	for i := 0; i < n; i++ {
+		st.SetCellString("Name", i, fmt.Sprintf("s%d", i%100))
+		st.SetCellFloat("Val", i, float64(i/100))
+	}
+	keys := make(map[uint64]bool, n)
+	for i := 0; i < n; i++ {
+		keys[st.RowKey([]int{0, 1}, i)] = true
+	}
+	if len(keys) != n {
+		t.Errorf("RowKey: %v collisions among %v distinct keys\n", n-len(keys), n)
+	}
+}
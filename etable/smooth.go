@@ -0,0 +1,68 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/emer/etable/etensor"
+)
+
+// SmoothCol computes an exponential moving average (EMA) of the values in
+// the srcName column, in row order, and stores it in the dstName column, which
+// is added as a FLOAT64 column if it does not already exist.  Each smoothed
+// value is alpha * val + (1 - alpha) * prior smoothed value, starting from the
+// first valid value, so alpha = 1 is no smoothing and smaller values are smoother.
+// Null or NaN source values carry the previous smoothed value forward
+// (leading ones are set to Null in the destination).
+// Both columns must be 1D numeric columns, and alpha must be in (0, 1].
+func (dt *Table) SmoothCol(srcName, dstName string, alpha float64) error {
+	if alpha <= 0 || alpha > 1 {
+		return fmt.Errorf("etable.Table SmoothCol: alpha must be in (0, 1], is: %v", alpha)
+	}
+	src, err := dt.ColByNameTry(srcName)
+	if err != nil {
+		return err
+	}
+	if src.NumDims() > 1 || src.DataType() == etensor.STRING {
+		return fmt.Errorf("etable.Table SmoothCol: source column %v must be a 1D numeric column", srcName)
+	}
+	dst, has := dt.ColNameMap[dstName]
+	var dcl etensor.Tensor
+	if has {
+		dcl = dt.Cols[dst]
+		if dcl.NumDims() > 1 || dcl.DataType() == etensor.STRING {
+			return fmt.Errorf("etable.Table SmoothCol: destination column %v must be a 1D numeric column", dstName)
+		}
+	} else {
+		dcl = etensor.NewFloat64([]int{dt.Rows}, nil, []string{"row"})
+		if err := dt.AddCol(dcl, dstName); err != nil {
+			return err
+		}
+	}
+	sm := 0.0
+	started := false
+	for ri := 0; ri < dt.Rows; ri++ {
+		val := src.FloatVal1D(ri)
+		if !src.IsNull1D(ri) && !math.IsNaN(val) {
+			if started {
+				sm = alpha*val + (1-alpha)*sm
+			} else {
+				sm = val
+				started = true
+			}
+		}
+		if started {
+			dcl.SetFloat1D(ri, sm)
+			if dcl.IsNull1D(ri) {
+				dcl.SetNull1D(ri, false)
+			}
+		} else {
+			dcl.SetNull1D(ri, true)
+		}
+	}
+	return nil
+}
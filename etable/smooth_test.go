@@ -0,0 +1,46 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestSmoothCol(t *testing.T) {
+	dt := New(Schema{{"Err", etensor.FLOAT32, nil, nil}}, 6)
+	vals := []float64{0, 1, 0, 1, 0, 4}
+	for i, v := range vals {
+		dt.SetCellFloat("Err", i, v)
+	}
+	dt.ColByName("Err").SetNull1D(0, true)
+	dt.ColByName("Err").SetNull1D(3, true)
+	err := dt.SmoothCol("Err", "ErrSm", .5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := dt.ColByName("ErrSm")
+	if sc == nil || sc.DataType() != etensor.FLOAT64 {
+		t.Fatalf("SmoothCol: dest column not added as FLOAT64\n")
+	}
+	if !sc.IsNull1D(0) {
+		t.Errorf("SmoothCol: leading null not null\n")
+	}
+	// manual: 1, .5*0+.5*1 = .5, carry .5, .5*0+.5*.5 = .25, .5*4+.5*.25 = 2.125
+	exp := []float64{0, 1, .5, .5, .25, 2.125}
+	for i := 1; i < len(exp); i++ {
+		if sc.IsNull1D(i) || math.Abs(sc.FloatVal1D(i)-exp[i]) > 1.0e-12 {
+			t.Errorf("SmoothCol: row %v: %v != %v\n", i, sc.FloatVal1D(i), exp[i])
+		}
+	}
+	if err := dt.SmoothCol("Err", "ErrSm", 1); err != nil || sc.FloatVal1D(5) != 4 {
+		t.Errorf("SmoothCol: existing dest not overwritten: %v %v\n", err, sc.FloatVal1D(5))
+	}
+	if err := dt.SmoothCol("Err", "ErrSm", 0); err == nil {
+		t.Errorf("SmoothCol: expected error for alpha = 0\n")
+	}
+}
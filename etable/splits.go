@@ -95,6 +95,70 @@ func (spl *Splits) New(dt *Table, values []string, rows ...int) *IdxView {
 	return ix
 }
 
+// SetGroups sets the splits to the groups of rows in given view having the
+// same values in the given list of 1-dimensional columns, with the column
+// names as the Levels (replacing any existing splits and aggs).
+// The groups are in a deterministic order, regardless of the order of rows in
+// the view, with keys compared column by column in ascending order:
+// strings lexically, and numbers numerically with NaN last (-0 groups with 0).
+// Uses a stable sort on columns, so the order of rows within groups is preserved.
+// This is the basis of split.GroupBy.
+func (spl *Splits) SetGroups(ix *IdxView, colIdxs ...int) {
+	spl.Splits = nil
+	spl.Values = nil
+	spl.Aggs = nil
+	nc := len(colIdxs)
+	spl.Levels = make([]string, nc)
+	for i, ci := range colIdxs {
+		spl.Levels[i] = ix.Table.ColNames[ci]
+	}
+	srt := ix.Clone()
+	srt.SortStableCols(colIdxs, Ascending) // important for consistency
+	lstVals := make([]string, nc)
+	curVals := make([]string, nc)
+	var curIx *IdxView
+	for _, rw := range srt.Idxs {
+		diff := false
+		for i, ci := range colIdxs {
+			cv := groupKey(ix.Table.Cols[ci], rw)
+			curVals[i] = cv
+			if cv != lstVals[i] {
+				diff = true
+			}
+		}
+		if diff || curIx == nil {
+			curIx = spl.New(ix.Table, curVals, rw)
+			copy(lstVals, curVals)
+		} else {
+			curIx.AddIndex(rw)
+		}
+	}
+}
+
+// groupKey returns the string value of given row of given column for use as
+// a group key, with negative zero canonicalized to 0, so that values that are
+// equal in the numeric sort order always fall into the same group
+func groupKey(cl etensor.Tensor, row int) string {
+	if cl.DataType() != etensor.STRING && cl.FloatVal1D(row) == 0 {
+		return "0"
+	}
+	return cl.StringVal1D(row)
+}
+
+// AggCol applies given aggregation function to each element in the given column
+// over the rows of each split, as in IdxView.AggCol, adding the results as a new
+// SplitAgg with given name, which is returned, with results aligned with the
+// splits.  See AggsToTable for a summary table with one row per split.
+// See split.Agg for standard aggregation functions.
+func (spl *Splits) AggCol(name string, colIdx int, ini float64, fun etensor.AggFunc) *SplitAgg {
+	ag := spl.AddAgg(name, colIdx)
+	ag.Aggs = make([][]float64, len(spl.Splits))
+	for si, ix := range spl.Splits {
+		ag.Aggs[si] = ix.AggCol(colIdx, ini, fun)
+	}
+	return ag
+}
+
 // ByValue finds split indexes by matching to split values, returns nil if not found.
 // values are used in order as far as they go and any remaining values are assumed
 // to match, and any empty values will match anything.  Can use this to access different
@@ -0,0 +1,42 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+func TestSplitsSetGroups(t *testing.T) {
+	dt := New(Schema{
+		{"Cond", etensor.STRING, nil, nil},
+		{"Score", etensor.FLOAT64, nil, nil},
+	}, 6)
+	conds := []string{"b", "a", "b", "a", "a", "b"}
+	for ri, c := range conds {
+		dt.SetCellString("Cond", ri, c)
+		dt.SetCellFloat("Score", ri, float64(ri+1))
+	}
+	ix := NewIdxView(dt)
+	spl := &Splits{}
+	spl.SetGroups(ix, 0)
+	if spl.Len() != 2 || fmt.Sprint(spl.Levels) != "[Cond]" || fmt.Sprint(spl.Values) != "[[a] [b]]" {
+		t.Fatalf("SetGroups: %v splits, levels %v values %v\n", spl.Len(), spl.Levels, spl.Values)
+	}
+	if fmt.Sprint(spl.Splits[0].Idxs) != "[1 3 4]" || fmt.Sprint(spl.Splits[1].Idxs) != "[0 2 5]" {
+		t.Errorf("SetGroups: rows %v %v\n", spl.Splits[0].Idxs, spl.Splits[1].Idxs)
+	}
+	sum := func(idx int, val float64, agg float64) float64 { return agg + val }
+	ag := spl.AggCol("Sum", 1, 0, sum)
+	if fmt.Sprint(ag.Aggs) != "[[11] [10]]" {
+		t.Errorf("AggCol: %v, expected [[11] [10]]\n", ag.Aggs)
+	}
+	st := spl.AggsToTable(AddAggName)
+	if st.Rows != 2 || st.CellString("Cond", 1) != "b" || st.CellFloat("Score:Sum", 1) != 10 {
+		t.Errorf("AggsToTable: bad summary table:\n%v", st)
+	}
+}
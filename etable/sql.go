@@ -0,0 +1,108 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/emer/etable/etensor"
+)
+
+// OpenSQL returns a new table with all of the rows from given database/sql query
+// results, with the schema determined from rows.ColumnTypes(): integer types are
+// etensor.INT64, floating point (REAL, FLOAT, DOUBLE, NUMERIC, DECIMAL) types
+// are etensor.FLOAT64, and everything else (TEXT etc) is etensor.STRING.
+// SQL NULL values are marked as Null in the column tensor.
+// Reads all of the rows, but does not close them.
+func OpenSQL(rows *sql.Rows) (*Table, error) {
+	cts, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	sc := make(Schema, len(cts))
+	for i, ct := range cts {
+		sc[i] = Column{Name: ct.Name(), Type: SQLColType(ct)}
+	}
+	nc := len(sc)
+	var recs [][]interface{}
+	for rows.Next() {
+		rec := make([]interface{}, nc)
+		for ci, cl := range sc {
+			switch cl.Type {
+			case etensor.INT64:
+				rec[ci] = &sql.NullInt64{}
+			case etensor.FLOAT64:
+				rec[ci] = &sql.NullFloat64{}
+			default:
+				rec[ci] = &sql.NullString{}
+			}
+		}
+		if err := rows.Scan(rec...); err != nil {
+			return nil, fmt.Errorf("etable.OpenSQL: error scanning row %v: %v", len(recs), err)
+		}
+		recs = append(recs, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	dt := New(sc, len(recs))
+	for ri, rec := range recs {
+		for ci, rv := range rec {
+			col := dt.Cols[ci]
+			switch v := rv.(type) {
+			case *sql.NullInt64:
+				if v.Valid {
+					col.SetFloat1D(ri, float64(v.Int64))
+				} else {
+					col.SetNull1D(ri, true)
+				}
+			case *sql.NullFloat64:
+				if v.Valid {
+					col.SetFloat1D(ri, v.Float64)
+				} else {
+					col.SetNull1D(ri, true)
+				}
+			case *sql.NullString:
+				if v.Valid {
+					col.SetString1D(ri, v.String)
+				} else {
+					col.SetNull1D(ri, true)
+				}
+			}
+		}
+	}
+	return dt, nil
+}
+
+// SQLColType returns the etensor.Type to use for given sql column type,
+// based on the DatabaseTypeName if available, otherwise the ScanType.
+func SQLColType(ct *sql.ColumnType) etensor.Type {
+	tn := strings.ToUpper(ct.DatabaseTypeName())
+	switch {
+	case tn == "":
+	case strings.Contains(tn, "INT"):
+		return etensor.INT64
+	case strings.Contains(tn, "REAL"), strings.Contains(tn, "FLOA"), strings.Contains(tn, "DOUB"),
+		strings.Contains(tn, "NUMERIC"), strings.Contains(tn, "DECIMAL"):
+		return etensor.FLOAT64
+	default:
+		return etensor.STRING
+	}
+	st := ct.ScanType()
+	if st == nil {
+		return etensor.STRING
+	}
+	switch st.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return etensor.INT64
+	case reflect.Float32, reflect.Float64:
+		return etensor.FLOAT64
+	}
+	return etensor.STRING
+}
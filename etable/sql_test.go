@@ -0,0 +1,99 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/emer/etable/etensor"
+)
+
+// testSQLDriver is a minimal in-memory database/sql driver that returns a fixed
+// result set for any query, reporting sqlite-style column type names,
+// so OpenSQL can be tested without an external database.
+type testSQLDriver struct{}
+
+type testSQLConn struct{}
+
+type testSQLStmt struct{}
+
+type testSQLRows struct {
+	row int
+}
+
+var testSQLCols = []string{"id", "score", "name"}
+var testSQLTypes = []string{"INTEGER", "REAL", "TEXT"}
+var testSQLData = [][]driver.Value{
+	{int64(1), 0.5, "alpha"},
+	{int64(2), nil, "beta"},
+	{nil, 2.25, nil},
+}
+
+func (d testSQLDriver) Open(name string) (driver.Conn, error) { return testSQLConn{}, nil }
+
+func (c testSQLConn) Prepare(query string) (driver.Stmt, error) { return testSQLStmt{}, nil }
+func (c testSQLConn) Close() error                              { return nil }
+func (c testSQLConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+func (s testSQLStmt) Close() error                                    { return nil }
+func (s testSQLStmt) NumInput() int                                   { return -1 }
+func (s testSQLStmt) Exec(args []driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (s testSQLStmt) Query(args []driver.Value) (driver.Rows, error)  { return &testSQLRows{}, nil }
+
+func (r *testSQLRows) Columns() []string { return testSQLCols }
+func (r *testSQLRows) Close() error      { return nil }
+func (r *testSQLRows) ColumnTypeDatabaseTypeName(i int) string {
+	return testSQLTypes[i]
+}
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if r.row >= len(testSQLData) {
+		return io.EOF
+	}
+	copy(dest, testSQLData[r.row])
+	r.row++
+	return nil
+}
+
+func init() {
+	sql.Register("etable_test", testSQLDriver{})
+}
+
+func TestOpenSQL(t *testing.T) {
+	db, err := sql.Open("etable_test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT id, score, name FROM test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	dt, err := OpenSQL(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dt.Rows != 3 || dt.NumCols() != 3 {
+		t.Fatalf("OpenSQL: rows %v cols %v\n", dt.Rows, dt.NumCols())
+	}
+	types := []etensor.Type{etensor.INT64, etensor.FLOAT64, etensor.STRING}
+	for ci, ty := range types {
+		if dt.Cols[ci].DataType() != ty || dt.ColNames[ci] != testSQLCols[ci] {
+			t.Errorf("OpenSQL: col %v: %v %v\n", ci, dt.ColNames[ci], dt.Cols[ci].DataType())
+		}
+	}
+	if dt.CellFloat("id", 1) != 2 || dt.CellFloat("score", 2) != 2.25 || dt.CellString("name", 0) != "alpha" {
+		t.Errorf("OpenSQL: bad values:\n%v %v %v\n", dt.Cols[0], dt.Cols[1], dt.Cols[2])
+	}
+	if !dt.ColByName("score").IsNull1D(1) || !dt.ColByName("id").IsNull1D(2) || !dt.ColByName("name").IsNull1D(2) {
+		t.Errorf("OpenSQL: NULL not marked as Null\n")
+	}
+	if dt.ColByName("score").IsNull1D(0) || dt.ColByName("name").IsNull1D(1) {
+		t.Errorf("OpenSQL: non-NULL marked as Null\n")
+	}
+}
@@ -140,11 +140,26 @@ func (tsr *Bits) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Bits) SetFloats(vals []float64) {
-	sz := ints.MinInt(tsr.Len(), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values.Set(j, Float64ToBool(vals[j]))
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Bits SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values.Set(j, Float64ToBool(v))
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Bits) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Bits SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
@@ -198,6 +213,18 @@ func (tsr *Bits) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order,
+// using float64 conversions of the values (1 = true, 0 = false),
+// stopping as soon as the function returns false.
+func (tsr *Bits) Visit(fun VisitFunc) {
+	ln := tsr.Len()
+	for j := 0; j < ln; j++ {
+		if !fun(j, BoolToFloat64(tsr.Values.Index(j))) {
+			return
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor, using float64
 // conversions of the values, and puts the results into given float64 slice, which is
 // ensured to be of the proper length
@@ -229,11 +256,13 @@ func (tsr *Bits) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Bits) Clone() Tensor {
 	csr := NewBitsShape(&tsr.Shape)
 	csr.Values = tsr.Values.Clone()
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -19,6 +19,10 @@ type AggFunc func(idx int, val float64, agg float64) float64
 // element value, returning the computed value
 type EvalFunc func(idx int, val float64) float64
 
+// VisitFunc is a visiting function that is called on each element value
+// in turn, returning false to stop visiting any further elements
+type VisitFunc func(idx int, val float64) bool
+
 // Tensor is the general interface for n-dimensional tensors.
 //
 // Tensor is automatically a gonum/mat.Matrix, implementing the Dims(), At(), T(), and Symmetric() methods
@@ -151,9 +155,15 @@ type Tensor interface {
 	// for basic math, gonum/stats, etc.
 	Floats(flt *[]float64)
 
-	// SetFloats sets tensor values from a []float64 slice (copies values).
+	// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+	// which must have the same length as the tensor -- panics otherwise.
 	SetFloats(vals []float64)
 
+	// SetStrings sets all of the tensor values from a []string slice,
+	// which must have the same length as the tensor -- panics otherwise.
+	// Strings are converted as in SetString1D.
+	SetStrings(vals []string)
+
 	// StringVal1D returns the value of given 1-dimensional index (0-Len()-1) as a string
 	StringVal1D(i int) string
 
@@ -197,6 +207,11 @@ type Tensor interface {
 	// init is the initial value for the agg variable. returns final aggregate value
 	Agg(ini float64, fun AggFunc) float64
 
+	// Visit calls given function on each element in the tensor in order
+	// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+	// stopping as soon as the function returns false.  No copy of the values is made.
+	Visit(fun VisitFunc)
+
 	// Eval applies given function to each element in the tensor (automatically
 	// skips IsNull and NaN elements), using float64 conversions of the values.
 	// Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -229,6 +244,7 @@ type Tensor interface {
 	// start = starting index on from Tensor to start copying from, and n = number of
 	// values to copy.  Uses an optimized implementation if the other tensor is
 	// of the same type, and otherwise it goes through appropriate standard type.
+	// The Null flags of the copied values are copied as well.
 	CopyCellsFrom(from Tensor, to, start, n int)
 
 	// SetShape sets the shape parameters of the tensor, and resizes backing storage appropriately.
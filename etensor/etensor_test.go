@@ -0,0 +1,207 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etensor
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetFloats(t *testing.T) {
+	vals := []float64{0, 1, 2, 3, 4, 5}
+	strs := []string{"0", "1", "2", "3", "4", "5"}
+	for _, typ := range []Type{FLOAT64, FLOAT32, INT64, INT32, UINT8, STRING} {
+		tsr := New(typ, []int{3, 2}, nil, nil)
+		tsr.SetFloats(vals)
+		var flt []float64
+		tsr.Floats(&flt)
+		for i, v := range vals {
+			if flt[i] != v {
+				t.Errorf("SetFloats %v: %v != %v\n", typ, flt, vals)
+				break
+			}
+		}
+		tsr.SetZeros()
+		tsr.SetStrings(strs)
+		for i, s := range strs {
+			if tsr.StringVal1D(i) != s {
+				t.Errorf("SetStrings %v: value %v: %v != %v\n", typ, i, tsr.StringVal1D(i), s)
+			}
+		}
+	}
+	check := func(nm string, fun func()) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Errorf("%v: no panic for length mismatch\n", nm)
+			} else if msg, _ := r.(string); !strings.Contains(msg, "!= tensor Len: 6") {
+				t.Errorf("%v: unclear panic message: %v\n", nm, r)
+			}
+		}()
+		fun()
+	}
+	tsr := NewFloat64([]int{3, 2}, nil, nil)
+	check("SetFloats", func() { tsr.SetFloats(vals[:4]) })
+	check("SetStrings", func() { tsr.SetStrings(append(strs, "6")) })
+}
+
+func TestClone(t *testing.T) {
+	shp := []int{3, 2}
+	nms := []string{"Row", "Col"}
+	tsrs := []Tensor{NewInt(shp, nil, nms), NewBits(shp, nil, nms)}
+	for _, typ := range []Type{FLOAT64, FLOAT32, INT64, INT32, UINT8, STRING} {
+		tsrs = append(tsrs, New(typ, shp, nil, nms))
+	}
+	for _, tsr := range tsrs {
+		typ := tsr.DataType()
+		tsr.SetFloat1D(1, 1)
+		tsr.SetFloat1D(2, 1)
+		tsr.SetNull1D(3, true)
+		tsr.SetMetaData("name", "orig")
+		cl := tsr.Clone()
+		if reflect.TypeOf(cl) != reflect.TypeOf(tsr) {
+			t.Errorf("Clone %v: type %T != %T\n", typ, cl, tsr)
+			continue
+		}
+		if !reflect.DeepEqual(cl.Shapes(), shp) || !reflect.DeepEqual(cl.DimNames(), nms) {
+			t.Errorf("Clone %v: shape %v %v\n", typ, cl.Shapes(), cl.DimNames())
+		}
+		if md, _ := cl.MetaData("name"); md != "orig" {
+			t.Errorf("Clone %v: meta data not copied: %v\n", typ, md)
+		}
+		if typ != BOOL && (!cl.IsNull1D(3) || cl.IsNull1D(2)) {
+			t.Errorf("Clone %v: nulls not copied\n", typ)
+		}
+		cl.SetFloat1D(1, 0)
+		cl.SetNull1D(4, true)
+		cl.SetMetaData("name", "clone")
+		if tsr.FloatVal1D(1) != 1 || cl.FloatVal1D(1) != 0 || cl.FloatVal1D(2) != 1 {
+			t.Errorf("Clone %v: values not independent: %v %v\n", typ, tsr.FloatVal1D(1), cl.FloatVal1D(1))
+		}
+		if tsr.IsNull1D(4) {
+			t.Errorf("Clone %v: nulls not independent\n", typ)
+		}
+		if md, _ := tsr.MetaData("name"); md != "orig" {
+			t.Errorf("Clone %v: meta data not independent: %v\n", typ, md)
+		}
+		cl.DimNames()[0] = "A"
+		if tsr.DimNames()[0] != "Row" {
+			t.Errorf("Clone %v: dim names not independent\n", typ)
+		}
+	}
+}
+
+func TestIntClamp(t *testing.T) {
+	u8 := NewUint8([]int{6}, nil, nil)
+	in := []float64{-5, 0, 100, 255, 256, 1000}
+	exp := []float64{0, 0, 100, 255, 255, 255}
+	u8.SetFloats(in)
+	var flt []float64
+	u8.Floats(&flt)
+	for i, v := range exp {
+		if flt[i] != v {
+			t.Errorf("Uint8 clamp: %v -> %v, expected %v\n", in[i], flt[i], v)
+		}
+	}
+	u8.SetFloat1D(0, 300)
+	u8.SetString1D(1, "-1")
+	u8.SetFloatRowCell(2, 0, 42.7)
+	if u8.Values[0] != 255 || u8.Values[1] != 0 || u8.Values[2] != 42 {
+		t.Errorf("Uint8 clamp: %v\n", u8.Values[:3])
+	}
+	u8.Floats(&flt)
+	rt := NewUint8([]int{6}, nil, nil)
+	rt.SetFloats(flt)
+	for i, v := range u8.Values {
+		if rt.Values[i] != v {
+			t.Errorf("Uint8 Floats round trip: %v != %v\n", rt.Values, u8.Values)
+			break
+		}
+	}
+	i32 := NewInt32([]int{2}, nil, nil)
+	i32.SetFloat1D(0, 1e12)
+	i32.SetFloat1D(1, -1e12)
+	if i32.Values[0] != math.MaxInt32 || i32.Values[1] != math.MinInt32 {
+		t.Errorf("Int32 clamp: %v\n", i32.Values)
+	}
+	i8 := NewInt8([]int{1}, nil, nil)
+	i8.SetFloat1D(0, -200)
+	if i8.Values[0] != math.MinInt8 {
+		t.Errorf("Int8 clamp: %v\n", i8.Values)
+	}
+}
+
+func TestGather(t *testing.T) {
+	tsr := NewFloat32([]int{3, 4}, nil, []string{"Row", "Unit"})
+	for i := range tsr.Values {
+		tsr.Values[i] = float32(i)
+	}
+	tsr.SetNull1D(9, true)
+	gt := Gather(tsr, 0, []int{0, 2})
+	if !reflect.DeepEqual(gt.Shapes(), []int{2, 4}) || gt.DimName(1) != "Unit" {
+		t.Fatalf("Gather: shape %v %v\n", gt.Shapes(), gt.DimNames())
+	}
+	exp := []float32{0, 1, 2, 3, 8, 9, 10, 11}
+	if !reflect.DeepEqual(gt.(*Float32).Values, exp) {
+		t.Errorf("Gather rows: %v != %v\n", gt.(*Float32).Values, exp)
+	}
+	if !gt.IsNull1D(5) || gt.IsNull1D(4) {
+		t.Errorf("Gather: nulls not copied\n")
+	}
+	gt = Gather(tsr, 1, []int{3, 1, 1})
+	exp = []float32{3, 1, 1, 7, 5, 5, 11, 9, 9}
+	if !reflect.DeepEqual(gt.Shapes(), []int{3, 3}) || !reflect.DeepEqual(gt.(*Float32).Values, exp) {
+		t.Errorf("Gather cols: %v %v != %v\n", gt.Shapes(), gt.(*Float32).Values, exp)
+	}
+	it := NewInt([]int{3, 2}, nil, nil)
+	it.SetFloats([]float64{0, 1, 2, 3, 4, 5})
+	if gi := Gather(it, 0, []int{2}); gi.Len() != 2 || gi.FloatVal1D(0) != 4 || gi.FloatVal1D(1) != 5 {
+		t.Errorf("Gather Int: %v\n", gi)
+	}
+	if _, err := GatherTry(tsr, 0, []int{3}); err == nil {
+		t.Errorf("Gather: no error for index out of range\n")
+	}
+	if _, err := GatherTry(tsr, 2, []int{0}); err == nil {
+		t.Errorf("Gather: no error for dim out of range\n")
+	}
+}
+
+func TestVisit(t *testing.T) {
+	tsr := NewFloat32([]int{6}, nil, nil)
+	for i := range tsr.Values {
+		tsr.Values[i] = float32(i)
+	}
+	tsr.SetNull1D(1, true)
+	tsr.Values[3] = float32(math.NaN())
+	var idxs []int
+	tsr.Visit(func(idx int, val float64) bool {
+		idxs = append(idxs, idx)
+		return val < 4
+	})
+	if fmt.Sprint(idxs) != "[0 2 4]" {
+		t.Errorf("Visit: visited %v, expected [0 2 4]\n", idxs)
+	}
+	var ct Tensor = tsr
+	n := 0
+	ct.Visit(func(idx int, val float64) bool { n++; return true })
+	if n != 4 {
+		t.Errorf("Visit: visited %v non-null values, expected 4\n", n)
+	}
+
+	st := NewString([]int{4}, nil, nil)
+	copy(st.Values, []string{"a", "b", "c", "d"})
+	st.SetNull1D(0, true)
+	var strs []string
+	st.VisitString(func(idx int, val string) bool {
+		strs = append(strs, val)
+		return idx < 2
+	})
+	if fmt.Sprint(strs) != "[b c]" {
+		t.Errorf("VisitString: visited %v, expected [b c]\n", strs)
+	}
+}
@@ -147,11 +147,27 @@ func (tsr *Float64) Floats(flt *[]float64) {
 	copy(*flt, tsr.Values) // diff: blit from values directly
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Float64) SetFloats(vals []float64) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Float64 SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
 	copy(tsr.Values, vals) // diff: blit from values directly
 }
 
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Float64) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Float64 SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
+	}
+}
+
 func (tsr *Float64) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Float64) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
@@ -207,6 +223,20 @@ func (tsr *Float64) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Float64) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -243,7 +273,8 @@ func (tsr *Float64) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Float64) Clone() Tensor {
 	csr := NewFloat64Shape(&tsr.Shape, nil)
@@ -251,6 +282,7 @@ func (tsr *Float64) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -289,12 +321,15 @@ func (tsr *Float64) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Float64) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Float64); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
@@ -303,6 +338,8 @@ func (tsr *Float64) CopyCellsFrom(frm Tensor, to, start, n int) {
 		tsr.Values[to+i] = float64(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -318,6 +355,9 @@ func (tsr *Float64) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -336,6 +376,9 @@ func (tsr *Float64) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -491,6 +534,21 @@ func (tsr *Float64) T() mat.Matrix {
 	return mat.Transpose{tsr}
 }
 
+// Matrix returns a gonum/mat.Dense matrix that shares the Values of this
+// tensor without copying, with the outer-most dimension as the rows and all
+// of the remaining inner dimensions flattened into the columns, assuming
+// default row-major layout.  For an etable column, each row of the matrix is
+// then one row of the table, with the cell values as its columns.
+// Changes to either the matrix or the tensor values affect both.
+// Returns nil if the tensor is empty.
+func (tsr *Float64) Matrix() *mat.Dense {
+	if tsr.Len() == 0 || tsr.NumDims() == 0 {
+		return nil
+	}
+	rows := tsr.Dim(0)
+	return mat.NewDense(rows, tsr.Len()/rows, tsr.Values[:tsr.Len()])
+}
+
 // Symmetric is the gonum/mat.Matrix interface method for returning the dimensionality of a symmetric
 // 2D Matrix.
 func (tsr *Float64) Symmetric() (r int) {
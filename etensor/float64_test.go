@@ -0,0 +1,43 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etensor
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestFloat64Matrix(t *testing.T) {
+	tsr := NewFloat64([]int{3, 2, 2}, nil, nil)
+	for i := range tsr.Values {
+		tsr.Values[i] = float64(i)
+	}
+	m := tsr.Matrix()
+	r, c := m.Dims()
+	if r != 3 || c != 4 {
+		t.Fatalf("Matrix: Dims %v, %v != 3, 4\n", r, c)
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if m.At(i, j) != tsr.FloatVal1D(i*c+j) {
+				t.Errorf("Matrix: At(%v, %v) = %v != %v\n", i, j, m.At(i, j), tsr.FloatVal1D(i*c+j))
+			}
+		}
+	}
+	if tc, tr := m.T().Dims(); tc != 4 || tr != 3 {
+		t.Errorf("Matrix: T Dims %v, %v != 4, 3\n", tc, tr)
+	}
+	// shares values without copying
+	m.Set(1, 2, 100)
+	if tsr.Value([]int{1, 1, 0}) != 100 {
+		t.Errorf("Matrix: values not shared with tensor\n")
+	}
+	var prod mat.Dense
+	prod.Mul(m, m.T())
+	if r, c := prod.Dims(); r != 3 || c != 3 {
+		t.Errorf("Matrix: product Dims %v, %v != 3, 3\n", r, c)
+	}
+}
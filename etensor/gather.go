@@ -0,0 +1,57 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etensor
+
+import (
+	"fmt"
+	"log"
+)
+
+// Gather returns a new tensor of the same type as given tensor, containing
+// only the slices at the given indexes along given dimension, in the order
+// of the indexes (which can repeat) -- e.g., for selecting a subset of units
+// from an activation tensor.  The result has the same shape, except for
+// len(idxs) along dim, and is a separate copy of the values, along with their
+// Null flags, dimension names and meta data.  Logs an error and returns nil if
+// dim or any of the indexes is out of range -- see GatherTry for error return.
+func Gather(tsr Tensor, dim int, idxs []int) Tensor {
+	gt, err := GatherTry(tsr, dim, idxs)
+	if err != nil {
+		log.Println(err)
+	}
+	return gt
+}
+
+// GatherTry returns a new tensor of the same type as given tensor, containing
+// only the slices at the given indexes along given dimension, as in Gather.
+// Returns an error if dim or any of the indexes is out of range.
+func GatherTry(tsr Tensor, dim int, idxs []int) (Tensor, error) {
+	nd := tsr.NumDims()
+	if dim < 0 || dim >= nd {
+		return nil, fmt.Errorf("etensor.Gather: dim %d out of range for tensor with %d dims", dim, nd)
+	}
+	dn := tsr.Dim(dim)
+	for _, ix := range idxs {
+		if ix < 0 || ix >= dn {
+			return nil, fmt.Errorf("etensor.Gather: index %d out of range for dim %d of size %d", ix, dim, dn)
+		}
+	}
+	shp := CopyInts(tsr.Shapes())
+	shp[dim] = len(idxs)
+	gt := New(tsr.DataType(), shp, nil, tsr.DimNames())
+	if gt == nil { // type without a New case, e.g., Int
+		gt = tsr.Clone()
+		gt.SetShape(shp, nil, tsr.DimNames())
+	} else {
+		gt.CopyMetaData(tsr)
+	}
+	gsh := gt.ShapeObj()
+	for j, n := 0, gt.Len(); j < n; j++ {
+		ix := gsh.Index(j)
+		ix[dim] = idxs[ix[dim]]
+		gt.CopyCellsFrom(tsr, j, tsr.Offset(ix), 1)
+	}
+	return gt, nil
+}
@@ -110,19 +110,37 @@ func (tsr *Int) SetNull1D(i int, nul bool) {
 	tsr.Nulls.Set(i, nul)
 }
 
+// intFromFloat converts given float64 value to int, clamping
+// values outside the range of int to its min or max, instead of wrapping around
+func intFromFloat(v float64) int {
+	switch {
+	case v <= float64(minInt):
+		return minInt
+	case v >= float64(maxInt):
+		return maxInt
+	}
+	return int(v)
+}
+
+// maxInt, minInt are the range of int values
+const (
+	maxInt = int(^uint(0) >> 1)
+	minInt = -maxInt - 1
+)
+
 func (tsr *Int) FloatVal(i []int) float64      { j := tsr.Offset(i); return float64(tsr.Values[j]) }
-func (tsr *Int) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = int(val) }
+func (tsr *Int) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = intFromFloat(val) }
 
 func (tsr *Int) StringVal(i []int) string { j := tsr.Offset(i); return kit.ToString(tsr.Values[j]) }
 func (tsr *Int) SetString(i []int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		j := tsr.Offset(i)
-		tsr.Values[j] = int(fv)
+		tsr.Values[j] = intFromFloat(fv)
 	}
 }
 
 func (tsr *Int) FloatVal1D(off int) float64      { return float64(tsr.Values[off]) }
-func (tsr *Int) SetFloat1D(off int, val float64) { tsr.Values[off] = int(val) }
+func (tsr *Int) SetFloat1D(off int, val float64) { tsr.Values[off] = intFromFloat(val) }
 
 func (tsr *Int) FloatValRowCell(row, cell int) float64 {
 	_, sz := tsr.RowCellSize()
@@ -130,7 +148,7 @@ func (tsr *Int) FloatValRowCell(row, cell int) float64 {
 }
 func (tsr *Int) SetFloatRowCell(row, cell int, val float64) {
 	_, sz := tsr.RowCellSize()
-	tsr.Values[row*sz+cell] = int(val)
+	tsr.Values[row*sz+cell] = intFromFloat(val)
 }
 
 // Floats sets []float64 slice of all elements in the tensor
@@ -151,18 +169,33 @@ func (tsr *Int) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Int) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = int(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Int SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values[j] = intFromFloat(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Int) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Int SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
 func (tsr *Int) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Int) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
-		tsr.Values[off] = int(fv)
+		tsr.Values[off] = intFromFloat(fv)
 	}
 }
 
@@ -173,7 +206,7 @@ func (tsr *Int) StringValRowCell(row, cell int) string {
 func (tsr *Int) SetStringRowCell(row, cell int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		_, sz := tsr.RowCellSize()
-		tsr.Values[row*sz+cell] = int(fv)
+		tsr.Values[row*sz+cell] = intFromFloat(fv)
 	}
 }
 
@@ -214,6 +247,20 @@ func (tsr *Int) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Int) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -237,7 +284,7 @@ func (tsr *Int) SetFunc(fun EvalFunc) {
 	for j, vl := range tsr.Values {
 		val := float64(vl)
 		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
-			tsr.Values[j] = int(fun(j, val))
+			tsr.Values[j] = intFromFloat(fun(j, val))
 		}
 	}
 }
@@ -250,7 +297,8 @@ func (tsr *Int) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Int) Clone() Tensor {
 	csr := NewIntShape(&tsr.Shape, nil)
@@ -258,6 +306,7 @@ func (tsr *Int) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -278,7 +327,7 @@ func (tsr *Int) CopyFrom(frm Tensor) {
 	}
 	sz := ints.MinInt(len(tsr.Values), frm.Len())
 	for i := 0; i < sz; i++ {
-		tsr.Values[i] = int(frm.FloatVal1D(i))
+		tsr.Values[i] = intFromFloat(frm.FloatVal1D(i))
 		if frm.IsNull1D(i) {
 			tsr.SetNull1D(i, true)
 		}
@@ -296,20 +345,25 @@ func (tsr *Int) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Int) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Int); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
 	}
 	for i := 0; i < n; i++ {
-		tsr.Values[to+i] = int(frm.FloatVal1D(start + i))
+		tsr.Values[to+i] = intFromFloat(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -325,6 +379,9 @@ func (tsr *Int) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -343,6 +400,9 @@ func (tsr *Int) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
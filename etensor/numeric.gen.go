@@ -111,19 +111,34 @@ func (tsr *Int64) SetNull1D(i int, nul bool) {
 	tsr.Nulls.Set(i, nul)
 }
 
-func (tsr *Int64) FloatVal(i []int) float64      { j := tsr.Offset(i); return float64(tsr.Values[j]) }
-func (tsr *Int64) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = int64(val) }
+// int64FromFloat converts given float64 value to int64, clamping
+// values outside the range of int64 to its min or max, instead of wrapping around
+func int64FromFloat(v float64) int64 {
+	switch {
+	case v <= math.MinInt64:
+		return math.MinInt64
+	case v >= math.MaxInt64:
+		return math.MaxInt64
+	}
+	return int64(v)
+}
+
+func (tsr *Int64) FloatVal(i []int) float64 { j := tsr.Offset(i); return float64(tsr.Values[j]) }
+func (tsr *Int64) SetFloat(i []int, val float64) {
+	j := tsr.Offset(i)
+	tsr.Values[j] = int64FromFloat(val)
+}
 
 func (tsr *Int64) StringVal(i []int) string { j := tsr.Offset(i); return kit.ToString(tsr.Values[j]) }
 func (tsr *Int64) SetString(i []int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		j := tsr.Offset(i)
-		tsr.Values[j] = int64(fv)
+		tsr.Values[j] = int64FromFloat(fv)
 	}
 }
 
 func (tsr *Int64) FloatVal1D(off int) float64      { return float64(tsr.Values[off]) }
-func (tsr *Int64) SetFloat1D(off int, val float64) { tsr.Values[off] = int64(val) }
+func (tsr *Int64) SetFloat1D(off int, val float64) { tsr.Values[off] = int64FromFloat(val) }
 
 func (tsr *Int64) FloatValRowCell(row, cell int) float64 {
 	_, sz := tsr.RowCellSize()
@@ -131,7 +146,7 @@ func (tsr *Int64) FloatValRowCell(row, cell int) float64 {
 }
 func (tsr *Int64) SetFloatRowCell(row, cell int, val float64) {
 	_, sz := tsr.RowCellSize()
-	tsr.Values[row*sz+cell] = int64(val)
+	tsr.Values[row*sz+cell] = int64FromFloat(val)
 }
 
 // Floats sets []float64 slice of all elements in the tensor
@@ -152,18 +167,33 @@ func (tsr *Int64) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Int64) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = int64(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Int64 SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values[j] = int64FromFloat(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Int64) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Int64 SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
 func (tsr *Int64) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Int64) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
-		tsr.Values[off] = int64(fv)
+		tsr.Values[off] = int64FromFloat(fv)
 	}
 }
 
@@ -174,7 +204,7 @@ func (tsr *Int64) StringValRowCell(row, cell int) string {
 func (tsr *Int64) SetStringRowCell(row, cell int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		_, sz := tsr.RowCellSize()
-		tsr.Values[row*sz+cell] = int64(fv)
+		tsr.Values[row*sz+cell] = int64FromFloat(fv)
 	}
 }
 
@@ -215,6 +245,20 @@ func (tsr *Int64) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Int64) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -238,7 +282,7 @@ func (tsr *Int64) SetFunc(fun EvalFunc) {
 	for j, vl := range tsr.Values {
 		val := float64(vl)
 		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
-			tsr.Values[j] = int64(fun(j, val))
+			tsr.Values[j] = int64FromFloat(fun(j, val))
 		}
 	}
 }
@@ -251,7 +295,8 @@ func (tsr *Int64) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Int64) Clone() Tensor {
 	csr := NewInt64Shape(&tsr.Shape, nil)
@@ -259,6 +304,7 @@ func (tsr *Int64) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -279,7 +325,7 @@ func (tsr *Int64) CopyFrom(frm Tensor) {
 	}
 	sz := ints.MinInt(len(tsr.Values), frm.Len())
 	for i := 0; i < sz; i++ {
-		tsr.Values[i] = int64(frm.FloatVal1D(i))
+		tsr.Values[i] = int64FromFloat(frm.FloatVal1D(i))
 		if frm.IsNull1D(i) {
 			tsr.SetNull1D(i, true)
 		}
@@ -297,20 +343,25 @@ func (tsr *Int64) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Int64) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Int64); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
 	}
 	for i := 0; i < n; i++ {
-		tsr.Values[to+i] = int64(frm.FloatVal1D(start + i))
+		tsr.Values[to+i] = int64FromFloat(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -326,6 +377,9 @@ func (tsr *Int64) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -344,6 +398,9 @@ func (tsr *Int64) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -639,19 +696,34 @@ func (tsr *Uint64) SetNull1D(i int, nul bool) {
 	tsr.Nulls.Set(i, nul)
 }
 
-func (tsr *Uint64) FloatVal(i []int) float64      { j := tsr.Offset(i); return float64(tsr.Values[j]) }
-func (tsr *Uint64) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = uint64(val) }
+// uint64FromFloat converts given float64 value to uint64, clamping
+// values outside the range of uint64 to its min or max, instead of wrapping around
+func uint64FromFloat(v float64) uint64 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= math.MaxUint64:
+		return math.MaxUint64
+	}
+	return uint64(v)
+}
+
+func (tsr *Uint64) FloatVal(i []int) float64 { j := tsr.Offset(i); return float64(tsr.Values[j]) }
+func (tsr *Uint64) SetFloat(i []int, val float64) {
+	j := tsr.Offset(i)
+	tsr.Values[j] = uint64FromFloat(val)
+}
 
 func (tsr *Uint64) StringVal(i []int) string { j := tsr.Offset(i); return kit.ToString(tsr.Values[j]) }
 func (tsr *Uint64) SetString(i []int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		j := tsr.Offset(i)
-		tsr.Values[j] = uint64(fv)
+		tsr.Values[j] = uint64FromFloat(fv)
 	}
 }
 
 func (tsr *Uint64) FloatVal1D(off int) float64      { return float64(tsr.Values[off]) }
-func (tsr *Uint64) SetFloat1D(off int, val float64) { tsr.Values[off] = uint64(val) }
+func (tsr *Uint64) SetFloat1D(off int, val float64) { tsr.Values[off] = uint64FromFloat(val) }
 
 func (tsr *Uint64) FloatValRowCell(row, cell int) float64 {
 	_, sz := tsr.RowCellSize()
@@ -659,7 +731,7 @@ func (tsr *Uint64) FloatValRowCell(row, cell int) float64 {
 }
 func (tsr *Uint64) SetFloatRowCell(row, cell int, val float64) {
 	_, sz := tsr.RowCellSize()
-	tsr.Values[row*sz+cell] = uint64(val)
+	tsr.Values[row*sz+cell] = uint64FromFloat(val)
 }
 
 // Floats sets []float64 slice of all elements in the tensor
@@ -680,18 +752,33 @@ func (tsr *Uint64) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Uint64) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = uint64(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Uint64 SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values[j] = uint64FromFloat(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Uint64) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Uint64 SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
 func (tsr *Uint64) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Uint64) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
-		tsr.Values[off] = uint64(fv)
+		tsr.Values[off] = uint64FromFloat(fv)
 	}
 }
 
@@ -702,7 +789,7 @@ func (tsr *Uint64) StringValRowCell(row, cell int) string {
 func (tsr *Uint64) SetStringRowCell(row, cell int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		_, sz := tsr.RowCellSize()
-		tsr.Values[row*sz+cell] = uint64(fv)
+		tsr.Values[row*sz+cell] = uint64FromFloat(fv)
 	}
 }
 
@@ -743,6 +830,20 @@ func (tsr *Uint64) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Uint64) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -766,7 +867,7 @@ func (tsr *Uint64) SetFunc(fun EvalFunc) {
 	for j, vl := range tsr.Values {
 		val := float64(vl)
 		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
-			tsr.Values[j] = uint64(fun(j, val))
+			tsr.Values[j] = uint64FromFloat(fun(j, val))
 		}
 	}
 }
@@ -779,7 +880,8 @@ func (tsr *Uint64) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Uint64) Clone() Tensor {
 	csr := NewUint64Shape(&tsr.Shape, nil)
@@ -787,6 +889,7 @@ func (tsr *Uint64) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -807,7 +910,7 @@ func (tsr *Uint64) CopyFrom(frm Tensor) {
 	}
 	sz := ints.MinInt(len(tsr.Values), frm.Len())
 	for i := 0; i < sz; i++ {
-		tsr.Values[i] = uint64(frm.FloatVal1D(i))
+		tsr.Values[i] = uint64FromFloat(frm.FloatVal1D(i))
 		if frm.IsNull1D(i) {
 			tsr.SetNull1D(i, true)
 		}
@@ -825,20 +928,25 @@ func (tsr *Uint64) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Uint64) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Uint64); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
 	}
 	for i := 0; i < n; i++ {
-		tsr.Values[to+i] = uint64(frm.FloatVal1D(start + i))
+		tsr.Values[to+i] = uint64FromFloat(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -854,6 +962,9 @@ func (tsr *Uint64) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -872,6 +983,9 @@ func (tsr *Uint64) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -1167,19 +1281,34 @@ func (tsr *Int32) SetNull1D(i int, nul bool) {
 	tsr.Nulls.Set(i, nul)
 }
 
-func (tsr *Int32) FloatVal(i []int) float64      { j := tsr.Offset(i); return float64(tsr.Values[j]) }
-func (tsr *Int32) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = int32(val) }
+// int32FromFloat converts given float64 value to int32, clamping
+// values outside the range of int32 to its min or max, instead of wrapping around
+func int32FromFloat(v float64) int32 {
+	switch {
+	case v <= math.MinInt32:
+		return math.MinInt32
+	case v >= math.MaxInt32:
+		return math.MaxInt32
+	}
+	return int32(v)
+}
+
+func (tsr *Int32) FloatVal(i []int) float64 { j := tsr.Offset(i); return float64(tsr.Values[j]) }
+func (tsr *Int32) SetFloat(i []int, val float64) {
+	j := tsr.Offset(i)
+	tsr.Values[j] = int32FromFloat(val)
+}
 
 func (tsr *Int32) StringVal(i []int) string { j := tsr.Offset(i); return kit.ToString(tsr.Values[j]) }
 func (tsr *Int32) SetString(i []int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		j := tsr.Offset(i)
-		tsr.Values[j] = int32(fv)
+		tsr.Values[j] = int32FromFloat(fv)
 	}
 }
 
 func (tsr *Int32) FloatVal1D(off int) float64      { return float64(tsr.Values[off]) }
-func (tsr *Int32) SetFloat1D(off int, val float64) { tsr.Values[off] = int32(val) }
+func (tsr *Int32) SetFloat1D(off int, val float64) { tsr.Values[off] = int32FromFloat(val) }
 
 func (tsr *Int32) FloatValRowCell(row, cell int) float64 {
 	_, sz := tsr.RowCellSize()
@@ -1187,7 +1316,7 @@ func (tsr *Int32) FloatValRowCell(row, cell int) float64 {
 }
 func (tsr *Int32) SetFloatRowCell(row, cell int, val float64) {
 	_, sz := tsr.RowCellSize()
-	tsr.Values[row*sz+cell] = int32(val)
+	tsr.Values[row*sz+cell] = int32FromFloat(val)
 }
 
 // Floats sets []float64 slice of all elements in the tensor
@@ -1208,18 +1337,33 @@ func (tsr *Int32) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Int32) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = int32(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Int32 SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values[j] = int32FromFloat(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Int32) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Int32 SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
 func (tsr *Int32) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Int32) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
-		tsr.Values[off] = int32(fv)
+		tsr.Values[off] = int32FromFloat(fv)
 	}
 }
 
@@ -1230,7 +1374,7 @@ func (tsr *Int32) StringValRowCell(row, cell int) string {
 func (tsr *Int32) SetStringRowCell(row, cell int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		_, sz := tsr.RowCellSize()
-		tsr.Values[row*sz+cell] = int32(fv)
+		tsr.Values[row*sz+cell] = int32FromFloat(fv)
 	}
 }
 
@@ -1271,6 +1415,20 @@ func (tsr *Int32) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Int32) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -1294,7 +1452,7 @@ func (tsr *Int32) SetFunc(fun EvalFunc) {
 	for j, vl := range tsr.Values {
 		val := float64(vl)
 		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
-			tsr.Values[j] = int32(fun(j, val))
+			tsr.Values[j] = int32FromFloat(fun(j, val))
 		}
 	}
 }
@@ -1307,7 +1465,8 @@ func (tsr *Int32) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Int32) Clone() Tensor {
 	csr := NewInt32Shape(&tsr.Shape, nil)
@@ -1315,6 +1474,7 @@ func (tsr *Int32) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -1335,7 +1495,7 @@ func (tsr *Int32) CopyFrom(frm Tensor) {
 	}
 	sz := ints.MinInt(len(tsr.Values), frm.Len())
 	for i := 0; i < sz; i++ {
-		tsr.Values[i] = int32(frm.FloatVal1D(i))
+		tsr.Values[i] = int32FromFloat(frm.FloatVal1D(i))
 		if frm.IsNull1D(i) {
 			tsr.SetNull1D(i, true)
 		}
@@ -1353,20 +1513,25 @@ func (tsr *Int32) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Int32) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Int32); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
 	}
 	for i := 0; i < n; i++ {
-		tsr.Values[to+i] = int32(frm.FloatVal1D(start + i))
+		tsr.Values[to+i] = int32FromFloat(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -1382,6 +1547,9 @@ func (tsr *Int32) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -1400,6 +1568,9 @@ func (tsr *Int32) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -1695,19 +1866,34 @@ func (tsr *Uint32) SetNull1D(i int, nul bool) {
 	tsr.Nulls.Set(i, nul)
 }
 
-func (tsr *Uint32) FloatVal(i []int) float64      { j := tsr.Offset(i); return float64(tsr.Values[j]) }
-func (tsr *Uint32) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = uint32(val) }
+// uint32FromFloat converts given float64 value to uint32, clamping
+// values outside the range of uint32 to its min or max, instead of wrapping around
+func uint32FromFloat(v float64) uint32 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= math.MaxUint32:
+		return math.MaxUint32
+	}
+	return uint32(v)
+}
+
+func (tsr *Uint32) FloatVal(i []int) float64 { j := tsr.Offset(i); return float64(tsr.Values[j]) }
+func (tsr *Uint32) SetFloat(i []int, val float64) {
+	j := tsr.Offset(i)
+	tsr.Values[j] = uint32FromFloat(val)
+}
 
 func (tsr *Uint32) StringVal(i []int) string { j := tsr.Offset(i); return kit.ToString(tsr.Values[j]) }
 func (tsr *Uint32) SetString(i []int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		j := tsr.Offset(i)
-		tsr.Values[j] = uint32(fv)
+		tsr.Values[j] = uint32FromFloat(fv)
 	}
 }
 
 func (tsr *Uint32) FloatVal1D(off int) float64      { return float64(tsr.Values[off]) }
-func (tsr *Uint32) SetFloat1D(off int, val float64) { tsr.Values[off] = uint32(val) }
+func (tsr *Uint32) SetFloat1D(off int, val float64) { tsr.Values[off] = uint32FromFloat(val) }
 
 func (tsr *Uint32) FloatValRowCell(row, cell int) float64 {
 	_, sz := tsr.RowCellSize()
@@ -1715,7 +1901,7 @@ func (tsr *Uint32) FloatValRowCell(row, cell int) float64 {
 }
 func (tsr *Uint32) SetFloatRowCell(row, cell int, val float64) {
 	_, sz := tsr.RowCellSize()
-	tsr.Values[row*sz+cell] = uint32(val)
+	tsr.Values[row*sz+cell] = uint32FromFloat(val)
 }
 
 // Floats sets []float64 slice of all elements in the tensor
@@ -1736,18 +1922,33 @@ func (tsr *Uint32) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Uint32) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = uint32(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Uint32 SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values[j] = uint32FromFloat(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Uint32) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Uint32 SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
 func (tsr *Uint32) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Uint32) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
-		tsr.Values[off] = uint32(fv)
+		tsr.Values[off] = uint32FromFloat(fv)
 	}
 }
 
@@ -1758,7 +1959,7 @@ func (tsr *Uint32) StringValRowCell(row, cell int) string {
 func (tsr *Uint32) SetStringRowCell(row, cell int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		_, sz := tsr.RowCellSize()
-		tsr.Values[row*sz+cell] = uint32(fv)
+		tsr.Values[row*sz+cell] = uint32FromFloat(fv)
 	}
 }
 
@@ -1799,6 +2000,20 @@ func (tsr *Uint32) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Uint32) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -1822,7 +2037,7 @@ func (tsr *Uint32) SetFunc(fun EvalFunc) {
 	for j, vl := range tsr.Values {
 		val := float64(vl)
 		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
-			tsr.Values[j] = uint32(fun(j, val))
+			tsr.Values[j] = uint32FromFloat(fun(j, val))
 		}
 	}
 }
@@ -1835,7 +2050,8 @@ func (tsr *Uint32) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Uint32) Clone() Tensor {
 	csr := NewUint32Shape(&tsr.Shape, nil)
@@ -1843,6 +2059,7 @@ func (tsr *Uint32) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -1863,7 +2080,7 @@ func (tsr *Uint32) CopyFrom(frm Tensor) {
 	}
 	sz := ints.MinInt(len(tsr.Values), frm.Len())
 	for i := 0; i < sz; i++ {
-		tsr.Values[i] = uint32(frm.FloatVal1D(i))
+		tsr.Values[i] = uint32FromFloat(frm.FloatVal1D(i))
 		if frm.IsNull1D(i) {
 			tsr.SetNull1D(i, true)
 		}
@@ -1881,20 +2098,25 @@ func (tsr *Uint32) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Uint32) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Uint32); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
 	}
 	for i := 0; i < n; i++ {
-		tsr.Values[to+i] = uint32(frm.FloatVal1D(start + i))
+		tsr.Values[to+i] = uint32FromFloat(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -1910,6 +2132,9 @@ func (tsr *Uint32) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -1928,6 +2153,9 @@ func (tsr *Uint32) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -2223,19 +2451,27 @@ func (tsr *Float32) SetNull1D(i int, nul bool) {
 	tsr.Nulls.Set(i, nul)
 }
 
-func (tsr *Float32) FloatVal(i []int) float64      { j := tsr.Offset(i); return float64(tsr.Values[j]) }
-func (tsr *Float32) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = float32(val) }
+// float32FromFloat converts given float64 value to float32
+func float32FromFloat(v float64) float32 {
+	return float32(v)
+}
+
+func (tsr *Float32) FloatVal(i []int) float64 { j := tsr.Offset(i); return float64(tsr.Values[j]) }
+func (tsr *Float32) SetFloat(i []int, val float64) {
+	j := tsr.Offset(i)
+	tsr.Values[j] = float32FromFloat(val)
+}
 
 func (tsr *Float32) StringVal(i []int) string { j := tsr.Offset(i); return kit.ToString(tsr.Values[j]) }
 func (tsr *Float32) SetString(i []int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		j := tsr.Offset(i)
-		tsr.Values[j] = float32(fv)
+		tsr.Values[j] = float32FromFloat(fv)
 	}
 }
 
 func (tsr *Float32) FloatVal1D(off int) float64      { return float64(tsr.Values[off]) }
-func (tsr *Float32) SetFloat1D(off int, val float64) { tsr.Values[off] = float32(val) }
+func (tsr *Float32) SetFloat1D(off int, val float64) { tsr.Values[off] = float32FromFloat(val) }
 
 func (tsr *Float32) FloatValRowCell(row, cell int) float64 {
 	_, sz := tsr.RowCellSize()
@@ -2243,7 +2479,7 @@ func (tsr *Float32) FloatValRowCell(row, cell int) float64 {
 }
 func (tsr *Float32) SetFloatRowCell(row, cell int, val float64) {
 	_, sz := tsr.RowCellSize()
-	tsr.Values[row*sz+cell] = float32(val)
+	tsr.Values[row*sz+cell] = float32FromFloat(val)
 }
 
 // Floats sets []float64 slice of all elements in the tensor
@@ -2264,18 +2500,33 @@ func (tsr *Float32) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Float32) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = float32(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Float32 SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values[j] = float32FromFloat(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Float32) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Float32 SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
 func (tsr *Float32) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Float32) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
-		tsr.Values[off] = float32(fv)
+		tsr.Values[off] = float32FromFloat(fv)
 	}
 }
 
@@ -2286,7 +2537,7 @@ func (tsr *Float32) StringValRowCell(row, cell int) string {
 func (tsr *Float32) SetStringRowCell(row, cell int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		_, sz := tsr.RowCellSize()
-		tsr.Values[row*sz+cell] = float32(fv)
+		tsr.Values[row*sz+cell] = float32FromFloat(fv)
 	}
 }
 
@@ -2327,6 +2578,20 @@ func (tsr *Float32) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Float32) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -2350,7 +2615,7 @@ func (tsr *Float32) SetFunc(fun EvalFunc) {
 	for j, vl := range tsr.Values {
 		val := float64(vl)
 		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
-			tsr.Values[j] = float32(fun(j, val))
+			tsr.Values[j] = float32FromFloat(fun(j, val))
 		}
 	}
 }
@@ -2363,7 +2628,8 @@ func (tsr *Float32) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Float32) Clone() Tensor {
 	csr := NewFloat32Shape(&tsr.Shape, nil)
@@ -2371,6 +2637,7 @@ func (tsr *Float32) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -2391,7 +2658,7 @@ func (tsr *Float32) CopyFrom(frm Tensor) {
 	}
 	sz := ints.MinInt(len(tsr.Values), frm.Len())
 	for i := 0; i < sz; i++ {
-		tsr.Values[i] = float32(frm.FloatVal1D(i))
+		tsr.Values[i] = float32FromFloat(frm.FloatVal1D(i))
 		if frm.IsNull1D(i) {
 			tsr.SetNull1D(i, true)
 		}
@@ -2409,20 +2676,25 @@ func (tsr *Float32) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Float32) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Float32); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
 	}
 	for i := 0; i < n; i++ {
-		tsr.Values[to+i] = float32(frm.FloatVal1D(start + i))
+		tsr.Values[to+i] = float32FromFloat(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -2438,6 +2710,9 @@ func (tsr *Float32) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -2456,6 +2731,9 @@ func (tsr *Float32) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -2751,19 +3029,34 @@ func (tsr *Int16) SetNull1D(i int, nul bool) {
 	tsr.Nulls.Set(i, nul)
 }
 
-func (tsr *Int16) FloatVal(i []int) float64      { j := tsr.Offset(i); return float64(tsr.Values[j]) }
-func (tsr *Int16) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = int16(val) }
+// int16FromFloat converts given float64 value to int16, clamping
+// values outside the range of int16 to its min or max, instead of wrapping around
+func int16FromFloat(v float64) int16 {
+	switch {
+	case v <= math.MinInt16:
+		return math.MinInt16
+	case v >= math.MaxInt16:
+		return math.MaxInt16
+	}
+	return int16(v)
+}
+
+func (tsr *Int16) FloatVal(i []int) float64 { j := tsr.Offset(i); return float64(tsr.Values[j]) }
+func (tsr *Int16) SetFloat(i []int, val float64) {
+	j := tsr.Offset(i)
+	tsr.Values[j] = int16FromFloat(val)
+}
 
 func (tsr *Int16) StringVal(i []int) string { j := tsr.Offset(i); return kit.ToString(tsr.Values[j]) }
 func (tsr *Int16) SetString(i []int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		j := tsr.Offset(i)
-		tsr.Values[j] = int16(fv)
+		tsr.Values[j] = int16FromFloat(fv)
 	}
 }
 
 func (tsr *Int16) FloatVal1D(off int) float64      { return float64(tsr.Values[off]) }
-func (tsr *Int16) SetFloat1D(off int, val float64) { tsr.Values[off] = int16(val) }
+func (tsr *Int16) SetFloat1D(off int, val float64) { tsr.Values[off] = int16FromFloat(val) }
 
 func (tsr *Int16) FloatValRowCell(row, cell int) float64 {
 	_, sz := tsr.RowCellSize()
@@ -2771,7 +3064,7 @@ func (tsr *Int16) FloatValRowCell(row, cell int) float64 {
 }
 func (tsr *Int16) SetFloatRowCell(row, cell int, val float64) {
 	_, sz := tsr.RowCellSize()
-	tsr.Values[row*sz+cell] = int16(val)
+	tsr.Values[row*sz+cell] = int16FromFloat(val)
 }
 
 // Floats sets []float64 slice of all elements in the tensor
@@ -2792,18 +3085,33 @@ func (tsr *Int16) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Int16) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = int16(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Int16 SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values[j] = int16FromFloat(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Int16) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Int16 SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
 func (tsr *Int16) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Int16) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
-		tsr.Values[off] = int16(fv)
+		tsr.Values[off] = int16FromFloat(fv)
 	}
 }
 
@@ -2814,7 +3122,7 @@ func (tsr *Int16) StringValRowCell(row, cell int) string {
 func (tsr *Int16) SetStringRowCell(row, cell int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		_, sz := tsr.RowCellSize()
-		tsr.Values[row*sz+cell] = int16(fv)
+		tsr.Values[row*sz+cell] = int16FromFloat(fv)
 	}
 }
 
@@ -2855,6 +3163,20 @@ func (tsr *Int16) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Int16) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -2878,7 +3200,7 @@ func (tsr *Int16) SetFunc(fun EvalFunc) {
 	for j, vl := range tsr.Values {
 		val := float64(vl)
 		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
-			tsr.Values[j] = int16(fun(j, val))
+			tsr.Values[j] = int16FromFloat(fun(j, val))
 		}
 	}
 }
@@ -2891,7 +3213,8 @@ func (tsr *Int16) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Int16) Clone() Tensor {
 	csr := NewInt16Shape(&tsr.Shape, nil)
@@ -2899,6 +3222,7 @@ func (tsr *Int16) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -2919,7 +3243,7 @@ func (tsr *Int16) CopyFrom(frm Tensor) {
 	}
 	sz := ints.MinInt(len(tsr.Values), frm.Len())
 	for i := 0; i < sz; i++ {
-		tsr.Values[i] = int16(frm.FloatVal1D(i))
+		tsr.Values[i] = int16FromFloat(frm.FloatVal1D(i))
 		if frm.IsNull1D(i) {
 			tsr.SetNull1D(i, true)
 		}
@@ -2937,20 +3261,25 @@ func (tsr *Int16) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Int16) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Int16); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
 	}
 	for i := 0; i < n; i++ {
-		tsr.Values[to+i] = int16(frm.FloatVal1D(start + i))
+		tsr.Values[to+i] = int16FromFloat(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -2966,6 +3295,9 @@ func (tsr *Int16) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -2984,6 +3316,9 @@ func (tsr *Int16) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -3279,19 +3614,34 @@ func (tsr *Uint16) SetNull1D(i int, nul bool) {
 	tsr.Nulls.Set(i, nul)
 }
 
-func (tsr *Uint16) FloatVal(i []int) float64      { j := tsr.Offset(i); return float64(tsr.Values[j]) }
-func (tsr *Uint16) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = uint16(val) }
+// uint16FromFloat converts given float64 value to uint16, clamping
+// values outside the range of uint16 to its min or max, instead of wrapping around
+func uint16FromFloat(v float64) uint16 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= math.MaxUint16:
+		return math.MaxUint16
+	}
+	return uint16(v)
+}
+
+func (tsr *Uint16) FloatVal(i []int) float64 { j := tsr.Offset(i); return float64(tsr.Values[j]) }
+func (tsr *Uint16) SetFloat(i []int, val float64) {
+	j := tsr.Offset(i)
+	tsr.Values[j] = uint16FromFloat(val)
+}
 
 func (tsr *Uint16) StringVal(i []int) string { j := tsr.Offset(i); return kit.ToString(tsr.Values[j]) }
 func (tsr *Uint16) SetString(i []int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		j := tsr.Offset(i)
-		tsr.Values[j] = uint16(fv)
+		tsr.Values[j] = uint16FromFloat(fv)
 	}
 }
 
 func (tsr *Uint16) FloatVal1D(off int) float64      { return float64(tsr.Values[off]) }
-func (tsr *Uint16) SetFloat1D(off int, val float64) { tsr.Values[off] = uint16(val) }
+func (tsr *Uint16) SetFloat1D(off int, val float64) { tsr.Values[off] = uint16FromFloat(val) }
 
 func (tsr *Uint16) FloatValRowCell(row, cell int) float64 {
 	_, sz := tsr.RowCellSize()
@@ -3299,7 +3649,7 @@ func (tsr *Uint16) FloatValRowCell(row, cell int) float64 {
 }
 func (tsr *Uint16) SetFloatRowCell(row, cell int, val float64) {
 	_, sz := tsr.RowCellSize()
-	tsr.Values[row*sz+cell] = uint16(val)
+	tsr.Values[row*sz+cell] = uint16FromFloat(val)
 }
 
 // Floats sets []float64 slice of all elements in the tensor
@@ -3320,18 +3670,33 @@ func (tsr *Uint16) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Uint16) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = uint16(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Uint16 SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values[j] = uint16FromFloat(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Uint16) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Uint16 SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
 func (tsr *Uint16) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Uint16) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
-		tsr.Values[off] = uint16(fv)
+		tsr.Values[off] = uint16FromFloat(fv)
 	}
 }
 
@@ -3342,7 +3707,7 @@ func (tsr *Uint16) StringValRowCell(row, cell int) string {
 func (tsr *Uint16) SetStringRowCell(row, cell int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		_, sz := tsr.RowCellSize()
-		tsr.Values[row*sz+cell] = uint16(fv)
+		tsr.Values[row*sz+cell] = uint16FromFloat(fv)
 	}
 }
 
@@ -3383,6 +3748,20 @@ func (tsr *Uint16) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Uint16) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -3406,7 +3785,7 @@ func (tsr *Uint16) SetFunc(fun EvalFunc) {
 	for j, vl := range tsr.Values {
 		val := float64(vl)
 		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
-			tsr.Values[j] = uint16(fun(j, val))
+			tsr.Values[j] = uint16FromFloat(fun(j, val))
 		}
 	}
 }
@@ -3419,7 +3798,8 @@ func (tsr *Uint16) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Uint16) Clone() Tensor {
 	csr := NewUint16Shape(&tsr.Shape, nil)
@@ -3427,6 +3807,7 @@ func (tsr *Uint16) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -3447,7 +3828,7 @@ func (tsr *Uint16) CopyFrom(frm Tensor) {
 	}
 	sz := ints.MinInt(len(tsr.Values), frm.Len())
 	for i := 0; i < sz; i++ {
-		tsr.Values[i] = uint16(frm.FloatVal1D(i))
+		tsr.Values[i] = uint16FromFloat(frm.FloatVal1D(i))
 		if frm.IsNull1D(i) {
 			tsr.SetNull1D(i, true)
 		}
@@ -3465,20 +3846,25 @@ func (tsr *Uint16) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Uint16) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Uint16); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
 	}
 	for i := 0; i < n; i++ {
-		tsr.Values[to+i] = uint16(frm.FloatVal1D(start + i))
+		tsr.Values[to+i] = uint16FromFloat(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -3494,6 +3880,9 @@ func (tsr *Uint16) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -3512,6 +3901,9 @@ func (tsr *Uint16) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -3807,19 +4199,34 @@ func (tsr *Int8) SetNull1D(i int, nul bool) {
 	tsr.Nulls.Set(i, nul)
 }
 
-func (tsr *Int8) FloatVal(i []int) float64      { j := tsr.Offset(i); return float64(tsr.Values[j]) }
-func (tsr *Int8) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = int8(val) }
+// int8FromFloat converts given float64 value to int8, clamping
+// values outside the range of int8 to its min or max, instead of wrapping around
+func int8FromFloat(v float64) int8 {
+	switch {
+	case v <= math.MinInt8:
+		return math.MinInt8
+	case v >= math.MaxInt8:
+		return math.MaxInt8
+	}
+	return int8(v)
+}
+
+func (tsr *Int8) FloatVal(i []int) float64 { j := tsr.Offset(i); return float64(tsr.Values[j]) }
+func (tsr *Int8) SetFloat(i []int, val float64) {
+	j := tsr.Offset(i)
+	tsr.Values[j] = int8FromFloat(val)
+}
 
 func (tsr *Int8) StringVal(i []int) string { j := tsr.Offset(i); return kit.ToString(tsr.Values[j]) }
 func (tsr *Int8) SetString(i []int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		j := tsr.Offset(i)
-		tsr.Values[j] = int8(fv)
+		tsr.Values[j] = int8FromFloat(fv)
 	}
 }
 
 func (tsr *Int8) FloatVal1D(off int) float64      { return float64(tsr.Values[off]) }
-func (tsr *Int8) SetFloat1D(off int, val float64) { tsr.Values[off] = int8(val) }
+func (tsr *Int8) SetFloat1D(off int, val float64) { tsr.Values[off] = int8FromFloat(val) }
 
 func (tsr *Int8) FloatValRowCell(row, cell int) float64 {
 	_, sz := tsr.RowCellSize()
@@ -3827,7 +4234,7 @@ func (tsr *Int8) FloatValRowCell(row, cell int) float64 {
 }
 func (tsr *Int8) SetFloatRowCell(row, cell int, val float64) {
 	_, sz := tsr.RowCellSize()
-	tsr.Values[row*sz+cell] = int8(val)
+	tsr.Values[row*sz+cell] = int8FromFloat(val)
 }
 
 // Floats sets []float64 slice of all elements in the tensor
@@ -3848,18 +4255,33 @@ func (tsr *Int8) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Int8) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = int8(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Int8 SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values[j] = int8FromFloat(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Int8) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Int8 SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
 func (tsr *Int8) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Int8) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
-		tsr.Values[off] = int8(fv)
+		tsr.Values[off] = int8FromFloat(fv)
 	}
 }
 
@@ -3870,7 +4292,7 @@ func (tsr *Int8) StringValRowCell(row, cell int) string {
 func (tsr *Int8) SetStringRowCell(row, cell int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		_, sz := tsr.RowCellSize()
-		tsr.Values[row*sz+cell] = int8(fv)
+		tsr.Values[row*sz+cell] = int8FromFloat(fv)
 	}
 }
 
@@ -3911,6 +4333,20 @@ func (tsr *Int8) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Int8) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -3934,7 +4370,7 @@ func (tsr *Int8) SetFunc(fun EvalFunc) {
 	for j, vl := range tsr.Values {
 		val := float64(vl)
 		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
-			tsr.Values[j] = int8(fun(j, val))
+			tsr.Values[j] = int8FromFloat(fun(j, val))
 		}
 	}
 }
@@ -3947,7 +4383,8 @@ func (tsr *Int8) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Int8) Clone() Tensor {
 	csr := NewInt8Shape(&tsr.Shape, nil)
@@ -3955,6 +4392,7 @@ func (tsr *Int8) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -3975,7 +4413,7 @@ func (tsr *Int8) CopyFrom(frm Tensor) {
 	}
 	sz := ints.MinInt(len(tsr.Values), frm.Len())
 	for i := 0; i < sz; i++ {
-		tsr.Values[i] = int8(frm.FloatVal1D(i))
+		tsr.Values[i] = int8FromFloat(frm.FloatVal1D(i))
 		if frm.IsNull1D(i) {
 			tsr.SetNull1D(i, true)
 		}
@@ -3993,20 +4431,25 @@ func (tsr *Int8) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Int8) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Int8); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
 	}
 	for i := 0; i < n; i++ {
-		tsr.Values[to+i] = int8(frm.FloatVal1D(start + i))
+		tsr.Values[to+i] = int8FromFloat(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -4022,6 +4465,9 @@ func (tsr *Int8) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -4040,6 +4486,9 @@ func (tsr *Int8) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -4335,19 +4784,34 @@ func (tsr *Uint8) SetNull1D(i int, nul bool) {
 	tsr.Nulls.Set(i, nul)
 }
 
-func (tsr *Uint8) FloatVal(i []int) float64      { j := tsr.Offset(i); return float64(tsr.Values[j]) }
-func (tsr *Uint8) SetFloat(i []int, val float64) { j := tsr.Offset(i); tsr.Values[j] = uint8(val) }
+// uint8FromFloat converts given float64 value to uint8, clamping
+// values outside the range of uint8 to its min or max, instead of wrapping around
+func uint8FromFloat(v float64) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= math.MaxUint8:
+		return math.MaxUint8
+	}
+	return uint8(v)
+}
+
+func (tsr *Uint8) FloatVal(i []int) float64 { j := tsr.Offset(i); return float64(tsr.Values[j]) }
+func (tsr *Uint8) SetFloat(i []int, val float64) {
+	j := tsr.Offset(i)
+	tsr.Values[j] = uint8FromFloat(val)
+}
 
 func (tsr *Uint8) StringVal(i []int) string { j := tsr.Offset(i); return kit.ToString(tsr.Values[j]) }
 func (tsr *Uint8) SetString(i []int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		j := tsr.Offset(i)
-		tsr.Values[j] = uint8(fv)
+		tsr.Values[j] = uint8FromFloat(fv)
 	}
 }
 
 func (tsr *Uint8) FloatVal1D(off int) float64      { return float64(tsr.Values[off]) }
-func (tsr *Uint8) SetFloat1D(off int, val float64) { tsr.Values[off] = uint8(val) }
+func (tsr *Uint8) SetFloat1D(off int, val float64) { tsr.Values[off] = uint8FromFloat(val) }
 
 func (tsr *Uint8) FloatValRowCell(row, cell int) float64 {
 	_, sz := tsr.RowCellSize()
@@ -4355,7 +4819,7 @@ func (tsr *Uint8) FloatValRowCell(row, cell int) float64 {
 }
 func (tsr *Uint8) SetFloatRowCell(row, cell int, val float64) {
 	_, sz := tsr.RowCellSize()
-	tsr.Values[row*sz+cell] = uint8(val)
+	tsr.Values[row*sz+cell] = uint8FromFloat(val)
 }
 
 // Floats sets []float64 slice of all elements in the tensor
@@ -4376,18 +4840,33 @@ func (tsr *Uint8) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *Uint8) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = uint8(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Uint8 SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.Values[j] = uint8FromFloat(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *Uint8) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.Uint8 SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	for j, v := range vals {
+		tsr.SetString1D(j, v)
 	}
 }
 
 func (tsr *Uint8) StringVal1D(off int) string { return kit.ToString(tsr.Values[off]) }
 func (tsr *Uint8) SetString1D(off int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
-		tsr.Values[off] = uint8(fv)
+		tsr.Values[off] = uint8FromFloat(fv)
 	}
 }
 
@@ -4398,7 +4877,7 @@ func (tsr *Uint8) StringValRowCell(row, cell int) string {
 func (tsr *Uint8) SetStringRowCell(row, cell int, val string) {
 	if fv, err := strconv.ParseFloat(val, 64); err == nil {
 		_, sz := tsr.RowCellSize()
-		tsr.Values[row*sz+cell] = uint8(fv)
+		tsr.Values[row*sz+cell] = uint8FromFloat(fv)
 	}
 }
 
@@ -4439,6 +4918,20 @@ func (tsr *Uint8) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+func (tsr *Uint8) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := float64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -4462,7 +4955,7 @@ func (tsr *Uint8) SetFunc(fun EvalFunc) {
 	for j, vl := range tsr.Values {
 		val := float64(vl)
 		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
-			tsr.Values[j] = uint8(fun(j, val))
+			tsr.Values[j] = uint8FromFloat(fun(j, val))
 		}
 	}
 }
@@ -4475,7 +4968,8 @@ func (tsr *Uint8) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *Uint8) Clone() Tensor {
 	csr := NewUint8Shape(&tsr.Shape, nil)
@@ -4483,6 +4977,7 @@ func (tsr *Uint8) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -4503,7 +4998,7 @@ func (tsr *Uint8) CopyFrom(frm Tensor) {
 	}
 	sz := ints.MinInt(len(tsr.Values), frm.Len())
 	for i := 0; i < sz; i++ {
-		tsr.Values[i] = uint8(frm.FloatVal1D(i))
+		tsr.Values[i] = uint8FromFloat(frm.FloatVal1D(i))
 		if frm.IsNull1D(i) {
 			tsr.SetNull1D(i, true)
 		}
@@ -4521,20 +5016,25 @@ func (tsr *Uint8) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *Uint8) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*Uint8); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
 	}
 	for i := 0; i < n; i++ {
-		tsr.Values[to+i] = uint8(frm.FloatVal1D(start + i))
+		tsr.Values[to+i] = uint8FromFloat(frm.FloatVal1D(start + i))
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -4550,6 +5050,9 @@ func (tsr *Uint8) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -4568,6 +5071,9 @@ func (tsr *Uint8) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -155,12 +155,25 @@ func (tsr *String) Floats(flt *[]float64) {
 	}
 }
 
-// SetFloats sets tensor values from a []float64 slice (copies values).
+// SetFloats sets all of the tensor values from a []float64 slice (copies values),
+// which must have the same length as the tensor -- panics otherwise.
 func (tsr *String) SetFloats(vals []float64) {
-	sz := ints.MinInt(len(tsr.Values), len(vals))
-	for j := 0; j < sz; j++ {
-		tsr.Values[j] = Float64ToString(vals[j])
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.String SetFloats: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
 	}
+	for j, v := range vals {
+		tsr.Values[j] = Float64ToString(v)
+	}
+}
+
+// SetStrings sets all of the tensor values from a []string slice,
+// which must have the same length as the tensor -- panics otherwise.
+// Strings are converted as in SetString1D.
+func (tsr *String) SetStrings(vals []string) {
+	if len(vals) != tsr.Len() {
+		panic(fmt.Sprintf("etensor.String SetStrings: length of vals: %d != tensor Len: %d", len(vals), tsr.Len()))
+	}
+	copy(tsr.Values, vals)
 }
 
 func (tsr *String) StringVal1D(off int) string      { return tsr.Values[off] }
@@ -196,6 +209,34 @@ func (tsr *String) Agg(ini float64, fun AggFunc) float64 {
 	return ag
 }
 
+// Visit calls given function on each element in the tensor in order
+// (automatically skips IsNull and NaN elements), using float64 conversions of the values,
+// stopping as soon as the function returns false.  No copy of the values is made.
+// See VisitString for visiting the string values.
+func (tsr *String) Visit(fun VisitFunc) {
+	for j, vl := range tsr.Values {
+		val := StringToFloat64(vl)
+		if !tsr.IsNull1D(j) && !math.IsNaN(val) {
+			if !fun(j, val) {
+				return
+			}
+		}
+	}
+}
+
+// VisitString calls given function on each string value in the tensor in order
+// (automatically skips IsNull elements), stopping as soon as the function
+// returns false.  No copy of the values is made.
+func (tsr *String) VisitString(fun func(idx int, val string) bool) {
+	for j, vl := range tsr.Values {
+		if !tsr.IsNull1D(j) {
+			if !fun(j, vl) {
+				return
+			}
+		}
+	}
+}
+
 // Eval applies given function to each element in the tensor (automatically
 // skips IsNull and NaN elements), using float64 conversions of the values.
 // Puts the results into given float64 slice, which is ensured to be of the proper length.
@@ -233,7 +274,8 @@ func (tsr *String) SetZeros() {
 }
 
 // Clone clones this tensor, creating a duplicate copy of itself with its
-// own separate memory representation of all the values, and returns
+// own separate memory representation of all the values, and copies of
+// the shape (including dimension names), Null flags and meta data, and returns
 // that as a Tensor (which can be converted into the known type as needed).
 func (tsr *String) Clone() Tensor {
 	csr := NewStringShape(&tsr.Shape)
@@ -241,6 +283,7 @@ func (tsr *String) Clone() Tensor {
 	if tsr.Nulls != nil {
 		csr.Nulls = tsr.Nulls.Clone()
 	}
+	csr.CopyMetaData(tsr)
 	return csr
 }
 
@@ -279,12 +322,15 @@ func (tsr *String) CopyShapeFrom(frm Tensor) {
 // start = starting index on from Tensor to start copying from, and n = number of
 // values to copy.  Uses an optimized implementation if the other tensor is
 // of the same type, and otherwise it goes through appropriate standard type.
+// The Null flags of the copied values are copied as well.
 func (tsr *String) CopyCellsFrom(frm Tensor, to, start, n int) {
 	if fsm, ok := frm.(*String); ok {
 		for i := 0; i < n; i++ {
 			tsr.Values[to+i] = fsm.Values[start+i]
 			if fsm.IsNull1D(start + i) {
 				tsr.SetNull1D(to+i, true)
+			} else if tsr.Nulls != nil {
+				tsr.Nulls.Set(to+i, false)
 			}
 		}
 		return
@@ -293,6 +339,8 @@ func (tsr *String) CopyCellsFrom(frm Tensor, to, start, n int) {
 		tsr.Values[to+i] = frm.StringVal1D(start + i)
 		if frm.IsNull1D(start + i) {
 			tsr.SetNull1D(to+i, true)
+		} else if tsr.Nulls != nil {
+			tsr.Nulls.Set(to+i, false)
 		}
 	}
 }
@@ -308,6 +356,9 @@ func (tsr *String) SetShape(shape, strides []int, names []string) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SetNumRows sets the number of rows (outer-most dimension) in a RowMajor organized tensor.
@@ -331,6 +382,9 @@ func (tsr *String) SetNumRows(rows int) {
 		copy(nv, tsr.Values)
 		tsr.Values = nv
 	}
+	if tsr.Nulls != nil {
+		tsr.Nulls.SetLen(nln)
+	}
 }
 
 // SubSpace returns a new tensor with innermost subspace at given
@@ -0,0 +1,206 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metric
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/emer/etable/etensor"
+)
+
+// Index is a KD-tree index over the rows of a tensor column, for fast
+// repeated Euclidean nearest-neighbor queries (e.g., ClosestRow64 with Euclidean64).
+// The tree only applies to the Euclidean (and equivalently SumSquares) metric --
+// use ClosestRow64 for other metrics.  Values are copied at build time, so
+// the index must be rebuilt if the column changes.  NaN values are not
+// skipped as they are in the metric functions, so they should not be present.
+type Index struct {
+	Rows     int       `desc:"number of rows in the column"`
+	CellSize int       `desc:"number of values in each row"`
+	Values   []float64 `desc:"copy of all the column values, in row-major order"`
+	nodes    []kdNode
+	root     int
+}
+
+// kdNode is a node in the KD-tree, splitting on dim at the value of row
+type kdNode struct {
+	row, dim    int
+	left, right int
+}
+
+// NewIndex64 returns a new KD-tree Index for given etensor.Tensor where the
+// outer-most dimension is assumed to be a row (e.g., as a column in an etable).
+func NewIndex64(col etensor.Tensor) *Index {
+	ix := &Index{}
+	ix.Build(col)
+	return ix
+}
+
+// Build (re)builds the index from given tensor column
+func (ix *Index) Build(col etensor.Tensor) {
+	ix.Rows = col.Dim(0)
+	ix.CellSize = 0
+	if ix.Rows > 0 {
+		ix.CellSize = col.Len() / ix.Rows
+	}
+	ix.Values = make([]float64, col.Len())
+	copy(ix.Values, float64Values(col))
+	ix.nodes = make([]kdNode, 0, ix.Rows)
+	rows := make([]int, ix.Rows)
+	for i := range rows {
+		rows[i] = i
+	}
+	ix.root = ix.build(rows)
+}
+
+// build recursively builds the tree for given rows, returning the node index
+func (ix *Index) build(rows []int) int {
+	if len(rows) == 0 {
+		return -1
+	}
+	dim := ix.maxSpreadDim(rows)
+	sort.Slice(rows, func(i, j int) bool {
+		return ix.val(rows[i], dim) < ix.val(rows[j], dim)
+	})
+	m := len(rows) / 2
+	ni := len(ix.nodes)
+	ix.nodes = append(ix.nodes, kdNode{row: rows[m], dim: dim})
+	left := ix.build(rows[:m])
+	right := ix.build(rows[m+1:])
+	ix.nodes[ni].left = left
+	ix.nodes[ni].right = right
+	return ni
+}
+
+// maxSpreadDim returns the dimension with the largest range of values across rows
+func (ix *Index) maxSpreadDim(rows []int) int {
+	bd := 0
+	bs := -1.0
+	for d := 0; d < ix.CellSize; d++ {
+		mn, mx := math.MaxFloat64, -math.MaxFloat64
+		for _, r := range rows {
+			v := ix.val(r, d)
+			mn = math.Min(mn, v)
+			mx = math.Max(mx, v)
+		}
+		if mx-mn > bs {
+			bs = mx - mn
+			bd = d
+		}
+	}
+	return bd
+}
+
+func (ix *Index) val(row, dim int) float64 {
+	return ix.Values[row*ix.CellSize+dim]
+}
+
+func (ix *Index) rowVals(row int) []float64 {
+	st := row * ix.CellSize
+	return ix.Values[st : st+ix.CellSize]
+}
+
+// sqDist returns the squared Euclidean distance between probe and row
+func (ix *Index) sqDist(pv []float64, row int) float64 {
+	ss := 0.0
+	for i, v := range ix.rowVals(row) {
+		d := pv[i] - v
+		ss += d * d
+	}
+	return ss
+}
+
+// probeVals returns the probe values, checking the size
+func (ix *Index) probeVals(probe etensor.Tensor) []float64 {
+	if probe.Len() != ix.CellSize {
+		panic("metric.Index: probe size != cell size of index!\n")
+	}
+	return float64Values(probe)
+}
+
+// Nearest returns the closest row to the probe pattern according to the
+// Euclidean64 metric, and that distance, with the same results as
+// ClosestRow64(probe, col, Euclidean64) including the first row winning ties.
+// Returns -1 if there are no rows.
+func (ix *Index) Nearest(probe etensor.Tensor) (int, float64) {
+	pv := ix.probeVals(probe)
+	best := rowDist{-1, math.MaxFloat64}
+	ix.nearest(ix.root, pv, &best)
+	if best.Row < 0 {
+		return -1, math.MaxFloat64
+	}
+	return best.Row, Euclidean64(pv, ix.rowVals(best.Row))
+}
+
+func (ix *Index) nearest(ni int, pv []float64, best *rowDist) {
+	if ni < 0 {
+		return
+	}
+	nd := &ix.nodes[ni]
+	d := ix.sqDist(pv, nd.row)
+	if d < best.Val || (d == best.Val && nd.row < best.Row) {
+		best.Row = nd.row
+		best.Val = d
+	}
+	diff := pv[nd.dim] - ix.val(nd.row, nd.dim)
+	near, far := nd.left, nd.right
+	if diff > 0 {
+		near, far = far, near
+	}
+	ix.nearest(near, pv, best)
+	if diff*diff <= best.Val {
+		ix.nearest(far, pv, best)
+	}
+}
+
+// NearestN returns the k closest rows to the probe pattern according to the
+// Euclidean64 metric, and those distances, in ascending order of distance
+// with ties in order of row, as in ClosestRowsN64.  If k is larger than
+// the number of rows, all rows are returned.
+func (ix *Index) NearestN(probe etensor.Tensor, k int) ([]int, []float64) {
+	pv := ix.probeVals(probe)
+	if k > ix.Rows {
+		k = ix.Rows
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+	rh := make(rowDistHeap, 0, k)
+	ix.nearestN(ix.root, pv, k, &rh)
+	sort.Slice(rh, func(i, j int) bool { return rh.Less(j, i) })
+	ris := make([]int, k)
+	vals := make([]float64, k)
+	for i, rd := range rh {
+		ris[i] = rd.Row
+		vals[i] = Euclidean64(pv, ix.rowVals(rd.Row))
+	}
+	return ris, vals
+}
+
+func (ix *Index) nearestN(ni int, pv []float64, k int, rh *rowDistHeap) {
+	if ni < 0 {
+		return
+	}
+	nd := &ix.nodes[ni]
+	d := ix.sqDist(pv, nd.row)
+	rd := rowDist{nd.row, d}
+	if len(*rh) < k {
+		heap.Push(rh, rd)
+	} else if top := (*rh)[0]; d < top.Val || (d == top.Val && nd.row < top.Row) {
+		(*rh)[0] = rd
+		heap.Fix(rh, 0)
+	}
+	diff := pv[nd.dim] - ix.val(nd.row, nd.dim)
+	near, far := nd.left, nd.right
+	if diff > 0 {
+		near, far = far, near
+	}
+	ix.nearestN(near, pv, k, rh)
+	if len(*rh) < k || diff*diff <= (*rh)[0].Val {
+		ix.nearestN(far, pv, k, rh)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metric
+
+import (
+	"fmt"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+// LabelRows returns a copy of table dt with two columns added, giving the label
+// of the closest prototype pattern for the pattern in each row, and the distance
+// to it: for each row of dt, the patCol pattern is compared to all of the
+// protoPatCol patterns in protoTable using ClosestRow64 with given metric function,
+// *which must have the Increasing property* -- i.e., larger = further.
+// The label column has the protoLabelCol name and holds the string value of that
+// column for the closest prototype, and the distance column is named
+// protoLabelCol + "Dist".  Returns an error if any of the columns are not found,
+// the pattern cell sizes do not match, or dt already has a column of either name.
+func LabelRows(dt *etable.Table, patCol string, protoTable *etable.Table, protoPatCol, protoLabelCol string, mfun Func64) (*etable.Table, error) {
+	pcol, err := dt.ColByNameTry(patCol)
+	if err != nil {
+		return nil, err
+	}
+	ppcol, err := protoTable.ColByNameTry(protoPatCol)
+	if err != nil {
+		return nil, err
+	}
+	plcol, err := protoTable.ColByNameTry(protoLabelCol)
+	if err != nil {
+		return nil, err
+	}
+	if protoTable.Rows == 0 {
+		return nil, fmt.Errorf("metric.LabelRows: prototype table has no rows")
+	}
+	_, csz := pcol.RowCellSize()
+	_, pcsz := ppcol.RowCellSize()
+	if csz != pcsz {
+		return nil, fmt.Errorf("metric.LabelRows: pattern cell size %v of column %v != prototype cell size %v of column %v", csz, patCol, pcsz, protoPatCol)
+	}
+	dstNm := protoLabelCol + "Dist"
+	for _, nm := range []string{protoLabelCol, dstNm} {
+		if dt.ColIdx(nm) >= 0 {
+			return nil, fmt.Errorf("metric.LabelRows: table already has a column named: %v", nm)
+		}
+	}
+	nt := dt.Clone()
+	rows := nt.Rows
+	pci := nt.ColIdx(patCol)
+	lcol := etensor.NewString([]int{rows}, nil, []string{"row"})
+	dcol := etensor.NewFloat64([]int{rows}, nil, []string{"row"})
+	for ri := 0; ri < rows; ri++ {
+		probe := nt.CellTensorIdx(pci, ri)
+		if probe == nil { // scalar column
+			probe = etensor.NewFloat64([]int{1}, nil, nil)
+			probe.SetFloat1D(0, pcol.FloatVal1D(ri))
+		}
+		pi, dist := ClosestRow64(probe, ppcol, mfun)
+		if pi >= 0 {
+			lcol.Values[ri] = plcol.StringVal1D(pi)
+		}
+		dcol.Values[ri] = dist
+	}
+	nt.AddCol(lcol, protoLabelCol)
+	nt.AddCol(dcol, dstNm)
+	return nt, nil
+}
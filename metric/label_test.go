@@ -0,0 +1,59 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metric
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+func TestLabelRows(t *testing.T) {
+	psc := etable.Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Pat", etensor.FLOAT64, []int{2, 2}, nil},
+	}
+	pt := etable.New(psc, 3)
+	protos := [][]float64{{1, 0, 0, 0}, {0, 1, 1, 0}, {1, 1, 1, 1}}
+	for ri, pv := range protos {
+		pt.SetCellString("Name", ri, []string{"A", "B", "C"}[ri])
+		for i, v := range pv {
+			pt.SetCellTensorFloat1D("Pat", ri, i, v)
+		}
+	}
+	dsc := etable.Schema{
+		{"Trial", etensor.INT64, nil, nil},
+		{"Input", etensor.FLOAT32, []int{2, 2}, nil},
+	}
+	dt := etable.New(dsc, 4)
+	pats := [][]float64{{1, 1, 1, .8}, {.9, 0, 0, .1}, {0, 1, .8, 0}, {1, .1, 0, 0}}
+	for ri, pv := range pats {
+		dt.SetCellFloat("Trial", ri, float64(ri))
+		for i, v := range pv {
+			dt.SetCellTensorFloat1D("Input", ri, i, v)
+		}
+	}
+	lt, err := LabelRows(dt, "Input", pt, "Pat", "Name", SumSquares64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lt.NumCols() != 4 || dt.NumCols() != 2 {
+		t.Fatalf("LabelRows: cols %v, orig cols %v\n", lt.NumCols(), dt.NumCols())
+	}
+	for ri, lb := range []string{"C", "A", "B", "A"} {
+		if lt.CellString("Name", ri) != lb {
+			t.Errorf("LabelRows: row %v: %v != %v\n", ri, lt.CellString("Name", ri), lb)
+		}
+	}
+	if d := lt.CellFloat("NameDist", 1); d < .019 || d > .021 { // .01 + .01 in float32
+		t.Errorf("LabelRows: dist %v != .02\n", d)
+	}
+
+	bpt := etable.New(etable.Schema{{"Name", etensor.STRING, nil, nil}, {"Pat", etensor.FLOAT64, []int{3}, nil}}, 1)
+	if _, err := LabelRows(dt, "Input", bpt, "Pat", "Name", SumSquares64); err == nil {
+		t.Errorf("LabelRows: expected error for cell size mismatch\n")
+	}
+}
@@ -81,3 +81,153 @@ func TestAll(t *testing.T) {
 		t.Errorf("Hamming32: %g\n", hm32)
 	}
 }
+
+func TestCorrelCosine(t *testing.T) {
+	// hand-computed: a, b have means 2, 4; deviations (-1,0,1), (-2,0,2)
+	a := []float64{1, 2, 3}
+	b := []float64{2, 4, 6}
+	c := []float64{3, 2, 1}
+	if cr := Correlation64(a, b); math.Abs(cr-1) > 1.0e-10 {
+		t.Errorf("Correlation64: %g != 1\n", cr)
+	}
+	if cr := Correlation64(a, c); math.Abs(cr+1) > 1.0e-10 {
+		t.Errorf("Correlation64: %g != -1\n", cr)
+	}
+	if ic := InvCorrelation64(a, c); math.Abs(ic-2) > 1.0e-10 {
+		t.Errorf("InvCorrelation64: %g != 2\n", ic)
+	}
+	// a . c = 10, |a|^2 = |c|^2 = 14
+	if cs := Cosine64(a, c); math.Abs(cs-10.0/14.0) > 1.0e-10 {
+		t.Errorf("Cosine64: %g != %g\n", cs, 10.0/14.0)
+	}
+	if cs := Cosine64(a, b); math.Abs(cs-1) > 1.0e-10 {
+		t.Errorf("Cosine64: %g != 1\n", cs)
+	}
+
+	// zero variance / zero norm sentinels
+	k := []float64{2, 2, 2}
+	z := []float64{0, 0, 0}
+	if cr := Correlation64(a, k); cr != 0 {
+		t.Errorf("Correlation64 zero variance: %g != 0\n", cr)
+	}
+	if ic := InvCorrelation64(k, a); ic != 1 {
+		t.Errorf("InvCorrelation64 zero variance: %g != 1\n", ic)
+	}
+	if cs := Cosine64(a, z); cs != 0 {
+		t.Errorf("Cosine64 zero norm: %g != 0\n", cs)
+	}
+	if ic := InvCosine64(z, z); ic != 1 {
+		t.Errorf("InvCosine64 zero norm: %g != 1\n", ic)
+	}
+	if cr := Correlation32([]float32{1, 1}, []float32{1, 2}); cr != 0 {
+		t.Errorf("Correlation32 zero variance: %g != 0\n", cr)
+	}
+	if cs := Cosine32([]float32{0, 0}, []float32{1, 2}); cs != 0 {
+		t.Errorf("Cosine32 zero norm: %g != 0\n", cs)
+	}
+}
+
+func TestNull(t *testing.T) {
+	a := []float64{1, 2, 3, 4}
+	b := []float64{1, 0, 3, 100}
+	bn := []bool{false, false, false, true}
+	ec := EuclideanNull64(a, b, nil, bn)
+	// valid diffs: 0, 2, 0 -> ss = 4, scaled by 4/3
+	if math.Abs(ec-math.Sqrt(4*4.0/3.0)) > 1.0e-10 {
+		t.Errorf("EuclideanNull64: %g\n", ec)
+	}
+	if ec := EuclideanNull64(a, a, nil, nil); ec != Euclidean64(a, a) {
+		t.Errorf("EuclideanNull64 no nulls: %g\n", ec)
+	}
+	if ec := EuclideanNull64(a, b[:4], nil, nil); math.Abs(ec-Euclidean64(a, b)) > 1.0e-10 {
+		t.Errorf("EuclideanNull64 no nulls: %g != %g\n", ec, Euclidean64(a, b))
+	}
+	all := []bool{true, true, true, true}
+	if ec := EuclideanNull64(a, b, all, nil); !math.IsNaN(ec) {
+		t.Errorf("EuclideanNull64 all null: %g != NaN\n", ec)
+	}
+	an := []bool{false, true, false, false}
+	// valid pairs 0, 2: a = 1, 3; b = 1, 3
+	if cs := CosineNull64(a, b, an, bn); math.Abs(cs-1) > 1.0e-10 {
+		t.Errorf("CosineNull64: %g != 1\n", cs)
+	}
+	if cs := CosineNull64(a, b, nil, nil); math.Abs(cs-Cosine64(a, b)) > 1.0e-10 {
+		t.Errorf("CosineNull64 no nulls: %g != %g\n", cs, Cosine64(a, b))
+	}
+	if cs := InvCosineNull64(a, b, all, nil); !math.IsNaN(cs) {
+		t.Errorf("InvCosineNull64 all null: %g != NaN\n", cs)
+	}
+	a32 := []float32{1, 2, 3, 4}
+	b32 := []float32{1, 0, 3, 100}
+	if ec32 := EuclideanNull32(a32, b32, nil, bn); float64(ec32) != float64(float32(ec)) {
+		t.Errorf("EuclideanNull32: %g\n", ec32)
+	}
+	if cs32 := CosineNull32(a32, b32, an, bn); math.Abs(float64(cs32)-1) > 1.0e-6 {
+		t.Errorf("CosineNull32: %g\n", cs32)
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	a64 := []float64{.5, .2, .1, .7, math.NaN(), .5}
+	b64 := []float64{.2, .5, .1, .7, 0, .2}
+	w64 := []float64{1, 1, 1, 1, 1, 1}
+	if ec := EuclideanWeighted64(w64)(a64, b64); math.Abs(ec-Euclidean64(a64, b64)) > 1.0e-10 {
+		t.Errorf("EuclideanWeighted64: %g != %g\n", ec, Euclidean64(a64, b64))
+	}
+	if ss := SumSquaresWeighted64(w64)(a64, b64); math.Abs(ss-SumSquares64(a64, b64)) > 1.0e-10 {
+		t.Errorf("SumSquaresWeighted64: %g != %g\n", ss, SumSquares64(a64, b64))
+	}
+	w64[0] = 0
+	if ss := SumSquaresWeighted64(w64)(a64, b64); math.Abs(ss-0.18) > 1.0e-10 {
+		t.Errorf("SumSquaresWeighted64 w0 = 0: %g != 0.18\n", ss)
+	}
+	a32 := []float32{.5, .2, .1, .7, math32.NaN(), .5}
+	b32 := []float32{.2, .5, .1, .7, 0, .2}
+	w32 := []float32{1, 1, 1, 1, 1, 1}
+	if ec := EuclideanWeighted32(w32)(a32, b32); math32.Abs(ec-Euclidean32(a32, b32)) > 1.0e-6 {
+		t.Errorf("EuclideanWeighted32: %g != %g\n", ec, Euclidean32(a32, b32))
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("EuclideanWeighted64: no panic for weights length mismatch\n")
+		}
+	}()
+	EuclideanWeighted64(w64[:3])(a64, b64)
+}
+
+func TestStdFunc64Name(t *testing.T) {
+	a := []float64{1, 2, 3, 4}
+	b := []float64{2, 2, 5, 3}
+	exp := map[string]float64{
+		"Euclidean":    math.Sqrt(6),
+		"SumSquares":   6,
+		"Abs":          4,
+		"Hamming":      3,
+		"InnerProduct": 2 + 4 + 15 + 12,
+		"Cosine":       33 / (math.Sqrt(30) * math.Sqrt(42)),
+		"InvCosine":    1 - 33/(math.Sqrt(30)*math.Sqrt(42)),
+	}
+	for nm, ev := range exp {
+		fun, err := StdFunc64Name(nm)
+		if err != nil {
+			t.Errorf("StdFunc64Name: %v\n", err)
+			continue
+		}
+		if v := fun(a, b); math.Abs(v-ev) > 1.0e-12 {
+			t.Errorf("StdFunc64Name: %v: %v != %v\n", nm, v, ev)
+		}
+	}
+	cfun, _ := StdFunc64Name("Correlation")
+	if v := cfun(a, []float64{2, 4, 6, 8}); math.Abs(v-1) > 1.0e-12 {
+		t.Errorf("StdFunc64Name: Correlation: %v != 1\n", v)
+	}
+	for _, nm := range []string{"Euclid", "", "StdMetricsN"} {
+		if _, err := StdFunc64Name(nm); err == nil {
+			t.Errorf("StdFunc64Name: expected error for name: %q\n", nm)
+		}
+	}
+	RegisterFunc64("Max", func(a, b []float64) float64 { return 42 })
+	if fun, err := StdFunc64Name("Max"); err != nil || fun(a, b) != 42 {
+		t.Errorf("RegisterFunc64: registered function not found: %v\n", err)
+	}
+}
@@ -4,7 +4,11 @@
 
 package metric
 
-import "github.com/goki/ki/kit"
+import (
+	"fmt"
+
+	"github.com/goki/ki/kit"
+)
 
 // Func32 is a distance / similarity metric operating on slices of float32 numbers
 type Func32 func(a, b []float32) float32
@@ -124,3 +128,31 @@ func StdFunc64(std StdMetrics) Func64 {
 	}
 	return nil
 }
+
+// Funcs64 is a registry of additional named Func64 metric functions,
+// which are looked up by StdFunc64Name before the StdMetrics names --
+// add to it with RegisterFunc64.
+var Funcs64 = map[string]Func64{}
+
+// RegisterFunc64 adds given metric function to the Funcs64 registry under
+// given name, so it can be selected by name using StdFunc64Name
+// (e.g., from a config file).  Replaces any existing function of that name.
+func RegisterFunc64(name string, fun Func64) {
+	Funcs64[name] = fun
+}
+
+// StdFunc64Name returns a metric function by name, for string-driven
+// selection of metrics (e.g., from config files): functions registered in
+// Funcs64 take precedence, followed by the StdMetrics names
+// (e.g., "Euclidean", "SumSquares", "Abs", "Cosine", "Correlation").
+// Returns an error if the name is not found.
+func StdFunc64Name(name string) (Func64, error) {
+	if fun, has := Funcs64[name]; has {
+		return fun, nil
+	}
+	var std StdMetrics
+	if err := std.FromString(name); err != nil || std == StdMetricsN {
+		return nil, fmt.Errorf("metric.StdFunc64Name: metric named: %v not found", name)
+	}
+	return StdFunc64(std), nil
+}
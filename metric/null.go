@@ -0,0 +1,167 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metric
+
+import (
+	"math"
+
+	"github.com/chewxy/math32"
+)
+
+// The Null versions of metrics take additional null masks for each vector,
+// where a true value marks that element as missing (e.g., from the Nulls of
+// an etensor), and skip any element where either vector is null, in addition
+// to skipping NaN's.  Either mask can be nil, meaning no nulls.
+// Masks must be the same length as the vectors (panics if not).
+// If there are no valid pairs of elements, NaN is returned, which makes
+// such rows always lose in ClosestRow functions.
+
+// validPair returns true if element i is not marked null in either mask
+func validPair(i int, anull, bnull []bool) bool {
+	if anull != nil && anull[i] {
+		return false
+	}
+	if bnull != nil && bnull[i] {
+		return false
+	}
+	return true
+}
+
+// checkNullLens panics if the lengths of the slices and non-nil masks do not match
+func checkNullLens(na, nb, nan, nbn int) {
+	if nb != na || (nan != 0 && nan != na) || (nbn != 0 && nbn != na) {
+		panic("metric: slice lengths do not match")
+	}
+}
+
+///////////////////////////////////////////
+//  EuclideanNull
+
+// EuclideanNull32 computes the square-root of sum-of-squares distance
+// between two vectors, skipping elements where either is null (per the masks)
+// or NaN. The sum of squares is normalized by the number of valid pairs,
+// and scaled up to the full length of the vectors, so that it is comparable to
+// (and with no nulls identical to) the regular Euclidean distance.
+// Returns NaN if there are no valid pairs.  Panics if lengths are not equal.
+func EuclideanNull32(a, b []float32, anull, bnull []bool) float32 {
+	checkNullLens(len(a), len(b), len(anull), len(bnull))
+	ss := float32(0)
+	nv := 0
+	for i, av := range a {
+		bv := b[i]
+		if !validPair(i, anull, bnull) || math32.IsNaN(av) || math32.IsNaN(bv) {
+			continue
+		}
+		d := av - bv
+		ss += d * d
+		nv++
+	}
+	if nv == 0 {
+		return math32.NaN()
+	}
+	return math32.Sqrt(ss * float32(len(a)) / float32(nv))
+}
+
+// EuclideanNull64 computes the square-root of sum-of-squares distance
+// between two vectors, skipping elements where either is null (per the masks)
+// or NaN. The sum of squares is normalized by the number of valid pairs,
+// and scaled up to the full length of the vectors, so that it is comparable to
+// (and with no nulls identical to) the regular Euclidean distance.
+// Returns NaN if there are no valid pairs.  Panics if lengths are not equal.
+func EuclideanNull64(a, b []float64, anull, bnull []bool) float64 {
+	checkNullLens(len(a), len(b), len(anull), len(bnull))
+	ss := float64(0)
+	nv := 0
+	for i, av := range a {
+		bv := b[i]
+		if !validPair(i, anull, bnull) || math.IsNaN(av) || math.IsNaN(bv) {
+			continue
+		}
+		d := av - bv
+		ss += d * d
+		nv++
+	}
+	if nv == 0 {
+		return math.NaN()
+	}
+	return math.Sqrt(ss * float64(len(a)) / float64(nv))
+}
+
+///////////////////////////////////////////
+//  CosineNull
+
+// CosineNull32 computes the cosine of the angle between two vectors (-1..1),
+// as the normalized inner product: inner product / sqrt(ssA * ssB),
+// skipping elements where either is null (per the masks) or NaN.
+// The normalization by the number of valid pairs cancels out in the ratio.
+// Returns NaN if there are no valid pairs, and 0 if either vector has
+// zero norm over the valid pairs (as in Cosine).  Panics if lengths are not equal.
+func CosineNull32(a, b []float32, anull, bnull []bool) float32 {
+	checkNullLens(len(a), len(b), len(anull), len(bnull))
+	ss := float32(0)
+	var ass, bss float32
+	nv := 0
+	for i, av := range a {
+		bv := b[i]
+		if !validPair(i, anull, bnull) || math32.IsNaN(av) || math32.IsNaN(bv) {
+			continue
+		}
+		ss += av * bv  // between
+		ass += av * av // within
+		bss += bv * bv
+		nv++
+	}
+	if nv == 0 {
+		return math32.NaN()
+	}
+	vp := math32.Sqrt(ass * bss)
+	if vp > 0 {
+		ss /= vp
+	}
+	return ss
+}
+
+// CosineNull64 computes the cosine of the angle between two vectors (-1..1),
+// as the normalized inner product: inner product / sqrt(ssA * ssB),
+// skipping elements where either is null (per the masks) or NaN.
+// The normalization by the number of valid pairs cancels out in the ratio.
+// Returns NaN if there are no valid pairs, and 0 if either vector has
+// zero norm over the valid pairs (as in Cosine).  Panics if lengths are not equal.
+func CosineNull64(a, b []float64, anull, bnull []bool) float64 {
+	checkNullLens(len(a), len(b), len(anull), len(bnull))
+	ss := float64(0)
+	var ass, bss float64
+	nv := 0
+	for i, av := range a {
+		bv := b[i]
+		if !validPair(i, anull, bnull) || math.IsNaN(av) || math.IsNaN(bv) {
+			continue
+		}
+		ss += av * bv  // between
+		ass += av * av // within
+		bss += bv * bv
+		nv++
+	}
+	if nv == 0 {
+		return math.NaN()
+	}
+	vp := math.Sqrt(ass * bss)
+	if vp > 0 {
+		ss /= vp
+	}
+	return ss
+}
+
+// InvCosineNull32 computes 1 - CosineNull32 -- an Increasing metric.
+// Returns NaN if there are no valid pairs.
+func InvCosineNull32(a, b []float32, anull, bnull []bool) float32 {
+	return 1 - CosineNull32(a, b, anull, bnull)
+}
+
+// InvCosineNull64 computes 1 - CosineNull64 -- an Increasing metric.
+// Returns NaN if there are no valid pairs.
+func InvCosineNull64(a, b []float64, anull, bnull []bool) float64 {
+	return 1 - CosineNull64(a, b, anull, bnull)
+}
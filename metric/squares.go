@@ -405,6 +405,8 @@ func Covariance64(a, b []float64) float64 {
 // cor(A,B) = E[(A - E(A))(B - E(B))] / sigma(A) sigma(B).
 // (i.e., the standardized covariance) -- equivalent to the cosine of mean-normalized
 // vectors.
+// Returns 0 if either vector has zero variance (e.g., all values the same),
+// instead of the NaN that would result from dividing by 0.
 // Skips NaN's and panics if lengths are not equal.
 func Correlation32(a, b []float32) float32 {
 	if len(a) != len(b) {
@@ -438,6 +440,8 @@ func Correlation32(a, b []float32) float32 {
 // cor(A,B) = E[(A - E(A))(B - E(B))] / sigma(A) sigma(B).
 // (i.e., the standardized covariance) -- equivalent to the cosine of mean-normalized
 // vectors.
+// Returns 0 if either vector has zero variance (e.g., all values the same),
+// instead of the NaN that would result from dividing by 0.
 // Skips NaN's and panics if lengths are not equal.
 func Correlation64(a, b []float64) float64 {
 	if len(a) != len(b) {
@@ -512,6 +516,7 @@ func InnerProduct64(a, b []float64) float64 {
 // Cosine32 computes the cosine of the angle between two vectors (-1..1),
 // as the normalized inner product: inner product / sqrt(ssA * ssB).
 // If vectors are mean-normalized = Correlation.
+// Returns 0 if either vector has zero norm (all 0), instead of NaN.
 // Skips NaN's and panics if lengths are not equal.
 func Cosine32(a, b []float32) float32 {
 	if len(a) != len(b) {
@@ -535,9 +540,10 @@ func Cosine32(a, b []float32) float32 {
 	return ss
 }
 
-// Cosine32 computes the cosine of the angle between two vectors (-1..1),
+// Cosine64 computes the cosine of the angle between two vectors (-1..1),
 // as the normalized inner product: inner product / sqrt(ssA * ssB).
 // If vectors are mean-normalized = Correlation.
+// Returns 0 if either vector has zero norm (all 0), instead of NaN.
 // Skips NaN's and panics if lengths are not equal.
 func Cosine64(a, b []float64) float64 {
 	if len(a) != len(b) {
@@ -567,14 +573,16 @@ func Cosine64(a, b []float64) float64 {
 // InvCosine32 computes 1 - cosine of the angle between two vectors (-1..1),
 // as the normalized inner product: inner product / sqrt(ssA * ssB).
 // If vectors are mean-normalized = Correlation.
+// Returns 1 if either vector has zero norm (see Cosine).
 // Skips NaN's and panics if lengths are not equal.
 func InvCosine32(a, b []float32) float32 {
 	return 1 - Cosine32(a, b)
 }
 
-// InvCosine32 computes 1 - cosine of the angle between two vectors (-1..1),
+// InvCosine64 computes 1 - cosine of the angle between two vectors (-1..1),
 // as the normalized inner product: inner product / sqrt(ssA * ssB).
 // If vectors are mean-normalized = Correlation.
+// Returns 1 if either vector has zero norm (see Cosine).
 // Skips NaN's and panics if lengths are not equal.
 func InvCosine64(a, b []float64) float64 {
 	return 1 - Cosine64(a, b)
@@ -589,6 +597,7 @@ func InvCosine64(a, b []float64) float64 {
 // cor(A,B) = E[(A - E(A))(B - E(B))] / sigma(A) sigma(B).
 // (i.e., the standardized covariance) -- equivalent to the cosine of mean-normalized
 // vectors.
+// Returns 1 if either vector has zero variance (see Correlation).
 // Skips NaN's and panics if lengths are not equal.
 func InvCorrelation32(a, b []float32) float32 {
 	return 1 - Correlation32(a, b)
@@ -600,6 +609,7 @@ func InvCorrelation32(a, b []float32) float32 {
 // cor(A,B) = E[(A - E(A))(B - E(B))] / sigma(A) sigma(B).
 // (i.e., the standardized covariance) -- equivalent to the cosine of mean-normalized
 // vectors.
+// Returns 1 if either vector has zero variance (see Correlation).
 // Skips NaN's and panics if lengths are not equal.
 func InvCorrelation64(a, b []float64) float64 {
 	return 1 - Correlation64(a, b)
@@ -5,7 +5,12 @@
 package metric
 
 import (
+	"container/heap"
+	"context"
 	"math"
+	"runtime"
+	"sort"
+	"sync"
 
 	"github.com/emer/etable/etensor"
 )
@@ -91,3 +96,371 @@ func ClosestRow64(probe etensor.Tensor, col etensor.Tensor, mfun Func64) (int, f
 	}
 	return ci, minv
 }
+
+// float64Values returns the values of given tensor as a []float64,
+// directly using the Values for an etensor.Float64, and otherwise
+// converting with the Floats method.
+func float64Values(tsr etensor.Tensor) []float64 {
+	if ft, ok := tsr.(*etensor.Float64); ok {
+		return ft.Values
+	}
+	var fv []float64
+	tsr.Floats(&fv)
+	return fv
+}
+
+// rowDist is a row index and metric value, for sorting closest rows
+type rowDist struct {
+	Row int
+	Val float64
+}
+
+// rowDistHeap is a max-heap of rowDist, keeping the furthest row at the top,
+// with ties broken in favor of keeping earlier rows
+type rowDistHeap []rowDist
+
+func (rh rowDistHeap) Len() int { return len(rh) }
+func (rh rowDistHeap) Less(i, j int) bool {
+	if rh[i].Val == rh[j].Val {
+		return rh[i].Row > rh[j].Row
+	}
+	return rh[i].Val > rh[j].Val
+}
+func (rh rowDistHeap) Swap(i, j int)       { rh[i], rh[j] = rh[j], rh[i] }
+func (rh *rowDistHeap) Push(x interface{}) { *rh = append(*rh, x.(rowDist)) }
+func (rh *rowDistHeap) Pop() interface{} {
+	old := *rh
+	n := len(old)
+	x := old[n-1]
+	*rh = old[:n-1]
+	return x
+}
+
+// ClosestRowsN64 returns the k closest fits between probe pattern and patterns in
+// an etensor.Tensor where the outer-most dimension is assumed to be a row
+// (e.g., as a column in an etable), using the given metric function,
+// *which must have the Increasing property* -- i.e., larger = further.
+// returns the rows and metric values for those rows, in ascending order
+// of metric value, with ties in order of row.  If k is larger than the
+// number of rows, all rows are returned.  Rows with a NaN metric value are
+// skipped (as in ClosestRow64), so fewer than k rows may be returned.
+// Col cell sizes must match size of probe (panics if not).
+func ClosestRowsN64(probe etensor.Tensor, col etensor.Tensor, k int, mfun Func64) ([]int, []float64) {
+	rows := col.Dim(0)
+	csz := col.Len() / rows
+	if csz != probe.Len() {
+		panic("metric.ClosestRowsN64: probe size != cell size of tensor column!\n")
+	}
+	if k > rows {
+		k = rows
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+	fpv := float64Values(probe)
+	fcv := float64Values(col)
+	rh := make(rowDistHeap, 0, k)
+	for ri := 0; ri < rows; ri++ {
+		st := ri * csz
+		v := mfun(fpv, fcv[st:st+csz])
+		if math.IsNaN(v) { // never compares, so would block the heap
+			continue
+		}
+		if len(rh) < k {
+			heap.Push(&rh, rowDist{ri, v})
+		} else if v < rh[0].Val {
+			rh[0] = rowDist{ri, v}
+			heap.Fix(&rh, 0)
+		}
+	}
+	sort.Slice(rh, func(i, j int) bool { return rh.Less(j, i) })
+	ris := make([]int, len(rh))
+	vals := make([]float64, len(rh))
+	for i, rd := range rh {
+		ris[i] = rd.Row
+		vals[i] = rd.Val
+	}
+	return ris, vals
+}
+
+// ClosestRow64Parallel returns the closest fit between probe pattern and patterns in
+// an etensor.Tensor where the outer-most dimension is assumed to be a row
+// (e.g., as a column in an etable), using the given metric function,
+// *which must have the Increasing property* -- i.e., larger = further.
+// This is a parallel version of ClosestRow64, splitting the rows across nThreads
+// goroutines (uses runtime.NumCPU() if <= 0), with identical results,
+// including the first row winning for ties.  The metric function must be
+// safe to call concurrently.
+// returns the row and metric value for that row.
+// Col cell sizes must match size of probe (panics if not).
+func ClosestRow64Parallel(probe etensor.Tensor, col etensor.Tensor, mfun Func64, nThreads int) (int, float64) {
+	rows := col.Dim(0)
+	csz := col.Len() / rows
+	if csz != probe.Len() {
+		panic("metric.ClosestRow64Parallel: probe size != cell size of tensor column!\n")
+	}
+	if nThreads <= 0 {
+		nThreads = runtime.NumCPU()
+	}
+	if nThreads > rows {
+		nThreads = rows
+	}
+	fpv := float64Values(probe)
+	fcv := float64Values(col)
+	cis := make([]int, nThreads)
+	mins := make([]float64, nThreads)
+	var wg sync.WaitGroup
+	for ti := 0; ti < nThreads; ti++ {
+		wg.Add(1)
+		go func(ti int) {
+			defer wg.Done()
+			ci := -1
+			minv := math.MaxFloat64
+			ed := ((ti + 1) * rows) / nThreads
+			for ri := (ti * rows) / nThreads; ri < ed; ri++ {
+				st := ri * csz
+				v := mfun(fpv, fcv[st:st+csz])
+				if v < minv {
+					ci = ri
+					minv = v
+				}
+			}
+			cis[ti] = ci
+			mins[ti] = minv
+		}(ti)
+	}
+	wg.Wait()
+	ci := -1
+	minv := math.MaxFloat64
+	for ti := 0; ti < nThreads; ti++ { // in row order, so first wins
+		if cis[ti] >= 0 && mins[ti] < minv {
+			ci = cis[ti]
+			minv = mins[ti]
+		}
+	}
+	return ci, minv
+}
+
+// Matrix64 returns the full pairwise distance / similarity matrix of the
+// rows of a etensor.Tensor where the outer-most dimension is assumed to be a row
+// (e.g., as a column in an etable), using the given metric function.
+// Entry [i,j] is the metric between rows i and j, as an N x N etensor.Float64.
+// Only the lower triangle is computed and then copied to the upper triangle,
+// so this assumes a symmetric metric function (all the standard metrics except
+// CrossEntropy are symmetric).  See simat.SimMat for a version with labels.
+func Matrix64(col etensor.Tensor, mfun Func64) *etensor.Float64 {
+	rows := col.Dim(0)
+	mat := etensor.NewFloat64([]int{rows, rows}, nil, []string{"Row", "Row"})
+	if rows == 0 {
+		return mat
+	}
+	csz := col.Len() / rows
+	fcv := float64Values(col)
+	for ai := 0; ai < rows; ai++ {
+		av := fcv[ai*csz : (ai+1)*csz]
+		for bi := 0; bi <= ai; bi++ { // lower diag
+			v := mfun(av, fcv[bi*csz:(bi+1)*csz])
+			mat.Values[ai*rows+bi] = v
+			mat.Values[bi*rows+ai] = v
+		}
+	}
+	return mat
+}
+
+// Matrix64Parallel returns the full pairwise distance / similarity matrix of
+// the rows of a etensor.Tensor, identical to Matrix64, computed in parallel
+// across nThreads goroutines (uses runtime.NumCPU() if <= 0).  Each row of
+// the lower triangle (with its mirror in the upper triangle) is computed by
+// one goroutine, with rows interleaved across goroutines to balance the
+// triangular workload, so no locking is needed.  The metric function must be
+// safe to call concurrently, and symmetric as in Matrix64.
+func Matrix64Parallel(col etensor.Tensor, mfun Func64, nThreads int) *etensor.Float64 {
+	rows := col.Dim(0)
+	mat := etensor.NewFloat64([]int{rows, rows}, nil, []string{"Row", "Row"})
+	if rows == 0 {
+		return mat
+	}
+	if nThreads <= 0 {
+		nThreads = runtime.NumCPU()
+	}
+	if nThreads > rows {
+		nThreads = rows
+	}
+	csz := col.Len() / rows
+	fcv := float64Values(col)
+	var wg sync.WaitGroup
+	for ti := 0; ti < nThreads; ti++ {
+		wg.Add(1)
+		go func(ti int) {
+			defer wg.Done()
+			for ai := ti; ai < rows; ai += nThreads {
+				av := fcv[ai*csz : (ai+1)*csz]
+				for bi := 0; bi <= ai; bi++ { // lower diag
+					v := mfun(av, fcv[bi*csz:(bi+1)*csz])
+					mat.Values[ai*rows+bi] = v
+					mat.Values[bi*rows+ai] = v
+				}
+			}
+		}(ti)
+	}
+	wg.Wait()
+	return mat
+}
+
+// ClosestRowsBatch64 returns the closest fit between each of the probe patterns
+// in probes, where the outer-most dimension is assumed to be a row, and patterns in
+// an etensor.Tensor where the outer-most dimension is also a row
+// (e.g., as columns in an etable), using the given metric function,
+// *which must have the Increasing property* -- i.e., larger = further.
+// returns the closest row and metric value for each probe row, identical
+// to calling ClosestRow64 on each probe, with the conversion of col to float64
+// values only done once.
+// Col cell sizes must match cell size of probes (panics if not).
+func ClosestRowsBatch64(probes etensor.Tensor, col etensor.Tensor, mfun Func64) ([]int, []float64) {
+	rows := col.Dim(0)
+	csz := col.Len() / rows
+	nprb := probes.Dim(0)
+	if nprb == 0 {
+		return nil, nil
+	}
+	if csz != probes.Len()/nprb {
+		panic("metric.ClosestRowsBatch64: probes cell size != cell size of tensor column!\n")
+	}
+	fpv := float64Values(probes)
+	fcv := float64Values(col)
+	cis := make([]int, nprb)
+	mins := make([]float64, nprb)
+	for pi := 0; pi < nprb; pi++ {
+		pv := fpv[pi*csz : (pi+1)*csz]
+		ci := -1
+		minv := math.MaxFloat64
+		for ri := 0; ri < rows; ri++ {
+			st := ri * csz
+			v := mfun(pv, fcv[st:st+csz])
+			if v < minv {
+				ci = ri
+				minv = v
+			}
+		}
+		cis[pi] = ci
+		mins[pi] = minv
+	}
+	return cis, mins
+}
+
+// ClosestRowsBatch64Ctx returns the closest fit between each of the probe
+// patterns in probes and patterns in col, identical to ClosestRowsBatch64,
+// computed in parallel with the probes split across runtime.NumCPU()
+// goroutines, which check the given context before each probe.  If the
+// context is cancelled (or times out) before all probes are done, the
+// goroutines stop and the context error is returned, with nil results.
+// The metric function must be safe to call concurrently.
+// Col cell sizes must match cell size of probes (panics if not).
+func ClosestRowsBatch64Ctx(ctx context.Context, probes etensor.Tensor, col etensor.Tensor, mfun Func64) ([]int, []float64, error) {
+	rows := col.Dim(0)
+	csz := col.Len() / rows
+	nprb := probes.Dim(0)
+	if nprb == 0 {
+		return nil, nil, ctx.Err()
+	}
+	if csz != probes.Len()/nprb {
+		panic("metric.ClosestRowsBatch64Ctx: probes cell size != cell size of tensor column!\n")
+	}
+	nThreads := runtime.NumCPU()
+	if nThreads > nprb {
+		nThreads = nprb
+	}
+	fpv := float64Values(probes)
+	fcv := float64Values(col)
+	cis := make([]int, nprb)
+	mins := make([]float64, nprb)
+	var wg sync.WaitGroup
+	for ti := 0; ti < nThreads; ti++ {
+		wg.Add(1)
+		go func(ti int) {
+			defer wg.Done()
+			ed := ((ti + 1) * nprb) / nThreads
+			for pi := (ti * nprb) / nThreads; pi < ed; pi++ {
+				if ctx.Err() != nil {
+					return
+				}
+				pv := fpv[pi*csz : (pi+1)*csz]
+				ci := -1
+				minv := math.MaxFloat64
+				for ri := 0; ri < rows; ri++ {
+					st := ri * csz
+					v := mfun(pv, fcv[st:st+csz])
+					if v < minv {
+						ci = ri
+						minv = v
+					}
+				}
+				cis[pi] = ci
+				mins[pi] = minv
+			}
+		}(ti)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return cis, mins, nil
+}
+
+// FarthestRow64 returns the row with the maximum metric value between probe
+// pattern and patterns in an etensor.Tensor where the outer-most dimension is
+// assumed to be a row (e.g., as a column in an etable), using the given metric
+// function.  This is the closest row for similarity metrics where larger = closer
+// (e.g., Cosine, Correlation, which are *not* Increasing), or the farthest
+// row for Increasing metrics.  The first row wins for ties.
+// returns the row and metric value for that row.
+// Col cell sizes must match size of probe (panics if not).
+func FarthestRow64(probe etensor.Tensor, col etensor.Tensor, mfun Func64) (int, float64) {
+	rows := col.Dim(0)
+	csz := col.Len() / rows
+	if csz != probe.Len() {
+		panic("metric.FarthestRow64: probe size != cell size of tensor column!\n")
+	}
+	fpv := float64Values(probe)
+	fcv := float64Values(col)
+	ci := -1
+	maxv := -math.MaxFloat64
+	for ri := 0; ri < rows; ri++ {
+		st := ri * csz
+		v := mfun(fpv, fcv[st:st+csz])
+		if v > maxv {
+			ci = ri
+			maxv = v
+		}
+	}
+	return ci, maxv
+}
+
+// ClosestRowIdxs64 returns the closest fit between probe pattern and patterns in
+// an etensor.Tensor where the outer-most dimension is assumed to be a row
+// (e.g., as a column in an etable), using the given metric function,
+// *which must have the Increasing property* -- i.e., larger = further,
+// only considering the given row indexes (e.g., the Idxs of an etable.IdxView).
+// returns the row (as an actual row index into col, not an index into idxs)
+// and metric value for that row, or -1 if idxs is empty.  The first of idxs wins for ties.
+// Col cell sizes must match size of probe (panics if not).
+func ClosestRowIdxs64(probe etensor.Tensor, col etensor.Tensor, idxs []int, mfun Func64) (int, float64) {
+	rows := col.Dim(0)
+	csz := col.Len() / rows
+	if csz != probe.Len() {
+		panic("metric.ClosestRowIdxs64: probe size != cell size of tensor column!\n")
+	}
+	fpv := float64Values(probe)
+	fcv := float64Values(col)
+	ci := -1
+	minv := math.MaxFloat64
+	for _, ri := range idxs {
+		st := ri * csz
+		v := mfun(fpv, fcv[st:st+csz])
+		if v < minv {
+			ci = ri
+			minv = v
+		}
+	}
+	return ci, minv
+}
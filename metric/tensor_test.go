@@ -0,0 +1,359 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metric
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/emer/etable/etensor"
+)
+
+// randPats returns a rows x csz tensor of random patterns, with values
+// quantized so that there are some ties in the metric values
+func randPats(rows, csz int) *etensor.Float64 {
+	tsr := etensor.NewFloat64([]int{rows, csz}, nil, nil)
+	for i := range tsr.Values {
+		tsr.Values[i] = float64(rand.Intn(4))
+	}
+	return tsr
+}
+
+func TestClosestRowsN(t *testing.T) {
+	rand.Seed(1)
+	rows, csz := 20, 4
+	col := randPats(rows, csz)
+	probe := randPats(1, csz)
+	probe.SetShape([]int{csz}, nil, nil)
+	// brute force reference
+	ref := make([]rowDist, rows)
+	for ri := 0; ri < rows; ri++ {
+		ref[ri] = rowDist{ri, SumSquares64(probe.Values, col.Values[ri*csz:(ri+1)*csz])}
+	}
+	sort.SliceStable(ref, func(i, j int) bool { return ref[i].Val < ref[j].Val })
+	for _, k := range []int{0, 1, 5, rows, rows + 10} {
+		ris, vals := ClosestRowsN64(probe, col, k, SumSquares64)
+		ek := k
+		if ek > rows {
+			ek = rows
+		}
+		if len(ris) != ek || len(vals) != ek {
+			t.Errorf("ClosestRowsN64 k=%v: len %v != %v\n", k, len(ris), ek)
+			continue
+		}
+		for i := range ris {
+			if ris[i] != ref[i].Row || vals[i] != ref[i].Val {
+				t.Errorf("ClosestRowsN64 k=%v: %v: %v, %v != %v, %v\n", k, i, ris[i], vals[i], ref[i].Row, ref[i].Val)
+			}
+		}
+	}
+	ci, cv := ClosestRow64(probe, col, SumSquares64)
+	ris, vals := ClosestRowsN64(probe, col, 1, SumSquares64)
+	if ris[0] != ci || vals[0] != cv {
+		t.Errorf("ClosestRowsN64 1: %v, %v != ClosestRow64: %v, %v\n", ris[0], vals[0], ci, cv)
+	}
+}
+
+func TestClosestRowsNNaN(t *testing.T) {
+	rows, csz := 10, 2
+	col := etensor.NewFloat64([]int{rows, csz}, nil, nil)
+	for ri := 0; ri < rows; ri++ {
+		col.Values[ri*csz] = float64(rows - ri)
+	}
+	col.Values[0] = -1 // NaN rows, first one enters the heap while filling
+	col.Values[5*csz] = -1
+	probe := etensor.NewFloat64([]int{csz}, nil, nil)
+	nanfun := func(a, b []float64) float64 {
+		if b[0] < 0 {
+			return math.NaN()
+		}
+		return SumSquares64(a, b)
+	}
+	ris, vals := ClosestRowsN64(probe, col, 3, nanfun)
+	exp := []int{9, 8, 7}
+	if len(ris) != 3 {
+		t.Fatalf("ClosestRowsN64 NaN: rows %v, expected %v\n", ris, exp)
+	}
+	for i, e := range exp {
+		if ris[i] != e || math.IsNaN(vals[i]) {
+			t.Errorf("ClosestRowsN64 NaN: rows %v vals %v, expected %v\n", ris, vals, exp)
+			break
+		}
+	}
+	if ris, _ := ClosestRowsN64(probe, col, rows, nanfun); len(ris) != rows-2 {
+		t.Errorf("ClosestRowsN64 NaN: %v rows for k = all, expected %v\n", len(ris), rows-2)
+	}
+}
+
+func TestClosestRowParallel(t *testing.T) {
+	rand.Seed(2)
+	rows, csz := 1000, 8
+	col := randPats(rows, csz)
+	for pi := 0; pi < 20; pi++ {
+		probe := randPats(1, csz)
+		probe.SetShape([]int{csz}, nil, nil)
+		ci, cv := ClosestRow64(probe, col, SumSquares64)
+		for _, nt := range []int{0, 1, 3, 7, rows + 1} {
+			pci, pcv := ClosestRow64Parallel(probe, col, SumSquares64, nt)
+			if pci != ci || pcv != cv {
+				t.Errorf("ClosestRow64Parallel %v: %v, %v != ClosestRow64: %v, %v\n", nt, pci, pcv, ci, cv)
+			}
+		}
+	}
+}
+
+func BenchmarkClosestRow64(b *testing.B) {
+	col := randPats(100000, 32)
+	probe := randPats(1, 32)
+	probe.SetShape([]int{32}, nil, nil)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ClosestRow64(probe, col, SumSquares64)
+	}
+}
+
+func BenchmarkClosestRow64Parallel(b *testing.B) {
+	col := randPats(100000, 32)
+	probe := randPats(1, 32)
+	probe.SetShape([]int{32}, nil, nil)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ClosestRow64Parallel(probe, col, SumSquares64, 0)
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	rand.Seed(3)
+	rows, csz := 10, 5
+	col := randPats(rows, csz)
+	mat := Matrix64(col, Euclidean64)
+	if mat.Dim(0) != rows || mat.Dim(1) != rows {
+		t.Errorf("Matrix64: shape %v\n", mat.Shapes())
+	}
+	for i := 0; i < rows; i++ {
+		if d := mat.Value([]int{i, i}); d != 0 {
+			t.Errorf("Matrix64: diagonal %v = %v\n", i, d)
+		}
+		for j := 0; j < rows; j++ {
+			if mat.Value([]int{i, j}) != mat.Value([]int{j, i}) {
+				t.Errorf("Matrix64: not symmetric at %v, %v\n", i, j)
+			}
+			ev := Euclidean64(col.Values[i*csz:(i+1)*csz], col.Values[j*csz:(j+1)*csz])
+			if mat.Value([]int{i, j}) != ev {
+				t.Errorf("Matrix64: %v, %v = %v != %v\n", i, j, mat.Value([]int{i, j}), ev)
+			}
+		}
+	}
+}
+
+func TestMatrixParallel(t *testing.T) {
+	rand.Seed(5)
+	rows, csz := 101, 7
+	col := randPats(rows, csz)
+	mat := Matrix64(col, Euclidean64)
+	for _, nt := range []int{0, 1, 3, 8, rows + 1} {
+		pmat := Matrix64Parallel(col, Euclidean64, nt)
+		if pmat.Dim(0) != rows || pmat.Dim(1) != rows {
+			t.Fatalf("Matrix64Parallel %v: shape %v\n", nt, pmat.Shapes())
+		}
+		for i, v := range mat.Values {
+			if pmat.Values[i] != v {
+				t.Errorf("Matrix64Parallel %v: value %v: %v != Matrix64: %v\n", nt, i, pmat.Values[i], v)
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkMatrix64(b *testing.B) {
+	col := randPats(1000, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Matrix64(col, SumSquares64)
+	}
+}
+
+func BenchmarkMatrix64Parallel(b *testing.B) {
+	col := randPats(1000, 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Matrix64Parallel(col, SumSquares64, 0)
+	}
+}
+
+func TestClosestRowsBatch(t *testing.T) {
+	rand.Seed(4)
+	rows, csz := 50, 6
+	col := randPats(rows, csz)
+	probes := etensor.NewFloat32([]int{10, 2, 3}, nil, nil)
+	for i := range probes.Values {
+		probes.Values[i] = float32(rand.Intn(4))
+	}
+	cis, mins := ClosestRowsBatch64(probes, col, SumSquares64)
+	if len(cis) != 10 || len(mins) != 10 {
+		t.Errorf("ClosestRowsBatch64: len %v != 10\n", len(cis))
+	}
+	for pi := 0; pi < 10; pi++ {
+		probe := probes.SubSpace([]int{pi})
+		ci, cv := ClosestRow64(probe, col, SumSquares64)
+		if cis[pi] != ci || mins[pi] != cv {
+			t.Errorf("ClosestRowsBatch64 %v: %v, %v != ClosestRow64: %v, %v\n", pi, cis[pi], mins[pi], ci, cv)
+		}
+	}
+}
+
+func TestClosestRowsBatchCtx(t *testing.T) {
+	rand.Seed(4)
+	col := randPats(50, 6)
+	probes := randPats(40, 6)
+	cis, mins, err := ClosestRowsBatch64Ctx(context.Background(), probes, col, SumSquares64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bcis, bmins := ClosestRowsBatch64(probes, col, SumSquares64)
+	for pi := range bcis {
+		if cis[pi] != bcis[pi] || mins[pi] != bmins[pi] {
+			t.Errorf("ClosestRowsBatch64Ctx %v: %v, %v != ClosestRowsBatch64: %v, %v\n", pi, cis[pi], mins[pi], bcis[pi], bmins[pi])
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var ncalls int64
+	slow := func(a, b []float64) float64 { // would take 2 sec without cancel
+		if atomic.AddInt64(&ncalls, 1) == 100 {
+			cancel()
+		}
+		time.Sleep(time.Millisecond)
+		return SumSquares64(a, b)
+	}
+	st := time.Now()
+	cis, mins, err = ClosestRowsBatch64Ctx(ctx, probes, col, slow)
+	if err != context.Canceled {
+		t.Errorf("ClosestRowsBatch64Ctx: err %v, expected context.Canceled\n", err)
+	}
+	if cis != nil || mins != nil {
+		t.Errorf("ClosestRowsBatch64Ctx: results not nil after cancel\n")
+	}
+	if dur := time.Since(st); dur > time.Second {
+		t.Errorf("ClosestRowsBatch64Ctx: took %v to return after cancel\n", dur)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	rand.Seed(5)
+	rows, csz := 500, 4
+	col := etensor.NewFloat64([]int{rows, csz}, nil, nil)
+	for i := range col.Values {
+		col.Values[i] = rand.Float64()
+	}
+	ix := NewIndex64(col)
+	for pi := 0; pi < 50; pi++ {
+		probe := etensor.NewFloat64([]int{csz}, nil, nil)
+		for i := range probe.Values {
+			probe.Values[i] = rand.Float64()
+		}
+		ci, cv := ClosestRow64(probe, col, Euclidean64)
+		ni, nv := ix.Nearest(probe)
+		if ni != ci || math.Abs(nv-cv) > 1.0e-10 {
+			t.Errorf("Index.Nearest: %v, %v != ClosestRow64: %v, %v\n", ni, nv, ci, cv)
+		}
+		cis, cvs := ClosestRowsN64(probe, col, 10, Euclidean64)
+		nis, nvs := ix.NearestN(probe, 10)
+		for i := range cis {
+			if nis[i] != cis[i] || math.Abs(nvs[i]-cvs[i]) > 1.0e-10 {
+				t.Errorf("Index.NearestN: %v: %v, %v != ClosestRowsN64: %v, %v\n", i, nis[i], nvs[i], cis[i], cvs[i])
+			}
+		}
+	}
+	// exact duplicate rows: first one wins, as in ClosestRow64
+	col.SetShape([]int{3, 2}, nil, nil)
+	copy(col.Values, []float64{1, 1, 0, 0, 0, 0})
+	ix = NewIndex64(col)
+	probe := etensor.NewFloat64([]int{2}, nil, nil)
+	if ni, _ := ix.Nearest(probe); ni != 1 {
+		t.Errorf("Index.Nearest ties: %v != 1\n", ni)
+	}
+	if nis, _ := ix.NearestN(probe, 5); len(nis) != 3 || nis[0] != 1 || nis[1] != 2 || nis[2] != 0 {
+		t.Errorf("Index.NearestN ties: %v\n", nis)
+	}
+}
+
+func TestFarthestRow(t *testing.T) {
+	col := etensor.NewFloat64([]int{4, 2}, nil, nil)
+	copy(col.Values, []float64{1, 0, 0, 1, 1, 1, 0, 1})
+	probe := etensor.NewFloat64([]int{2}, nil, nil)
+	copy(probe.Values, []float64{0, 1})
+	// cosines: 0, 1, .707, 1 -- first max wins
+	ri, rv := FarthestRow64(probe, col, Cosine64)
+	if ri != 1 || math.Abs(rv-1) > 1.0e-10 {
+		t.Errorf("FarthestRow64 Cosine: %v, %v != 1, 1\n", ri, rv)
+	}
+	ri, rv = FarthestRow64(probe, col, SumSquares64)
+	if ri != 0 || rv != 2 {
+		t.Errorf("FarthestRow64 SumSquares: %v, %v != 0, 2\n", ri, rv)
+	}
+}
+
+func TestClosestRowIdxs(t *testing.T) {
+	col := etensor.NewFloat64([]int{4, 2}, nil, nil)
+	copy(col.Values, []float64{1, 0, 0, 1, 1, 1, 0, .9})
+	probe := etensor.NewFloat64([]int{2}, nil, nil)
+	copy(probe.Values, []float64{0, 1})
+	if ri, _ := ClosestRowIdxs64(probe, col, []int{0, 1, 2, 3}, SumSquares64); ri != 1 {
+		t.Errorf("ClosestRowIdxs64 all: %v != 1\n", ri)
+	}
+	if ri, _ := ClosestRowIdxs64(probe, col, []int{0, 2, 3}, SumSquares64); ri != 3 {
+		t.Errorf("ClosestRowIdxs64 subset: %v != 3\n", ri)
+	}
+	if ri, rv := ClosestRowIdxs64(probe, col, []int{2, 0}, SumSquares64); ri != 2 || rv != 1 {
+		t.Errorf("ClosestRowIdxs64 subset: %v, %v != 2, 1\n", ri, rv)
+	}
+	if ri, _ := ClosestRowIdxs64(probe, col, nil, SumSquares64); ri != -1 {
+		t.Errorf("ClosestRowIdxs64 empty: %v != -1\n", ri)
+	}
+}
+
+func TestClosestRow64Tie(t *testing.T) {
+	// rows 1, 3 and 4 are equidistant from the probe and closest
+	col := etensor.NewFloat64([]int{6, 2}, nil, nil)
+	copy(col.Values, []float64{5, 5, 1, 0, 3, 3, 0, 1, 1, 0, 4, 4})
+	probe := etensor.NewFloat64([]int{2}, nil, nil)
+	ri, v := ClosestRow64Tie(probe, col, SumSquares64, TieFirst, nil)
+	if ri != 1 || v != 1 {
+		t.Errorf("ClosestRow64Tie First: %v %v\n", ri, v)
+	}
+	if fr, _ := ClosestRow64(probe, col, SumSquares64); fr != ri {
+		t.Errorf("ClosestRow64Tie First: %v != ClosestRow64 %v\n", ri, fr)
+	}
+	ri, v = ClosestRow64Tie(probe, col, SumSquares64, TieLast, nil)
+	if ri != 4 || v != 1 {
+		t.Errorf("ClosestRow64Tie Last: %v %v\n", ri, v)
+	}
+	var seq []int
+	seen := map[int]bool{}
+	rnd := rand.New(rand.NewSource(10))
+	for i := 0; i < 30; i++ {
+		ri, _ = ClosestRow64Tie(probe, col, SumSquares64, TieRandom, rnd)
+		seq = append(seq, ri)
+		seen[ri] = true
+	}
+	if len(seen) != 3 || !seen[1] || !seen[3] || !seen[4] {
+		t.Errorf("ClosestRow64Tie Random: did not select among the ties: %v\n", seq)
+	}
+	rnd = rand.New(rand.NewSource(10))
+	for i := 0; i < 30; i++ {
+		ri, _ = ClosestRow64Tie(probe, col, SumSquares64, TieRandom, rnd)
+		if ri != seq[i] {
+			t.Errorf("ClosestRow64Tie Random: not reproducible with same seed at %v\n", i)
+			break
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metric
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/emer/etable/etensor"
+	"github.com/goki/ki/kit"
+)
+
+// TieBreaks determine which row is selected by ClosestRow64Tie
+// when multiple rows have exactly the same closest metric value
+type TieBreaks int
+
+const (
+	// TieFirst selects the first (lowest index) of the tied rows,
+	// as ClosestRow64 does
+	TieFirst TieBreaks = iota
+
+	// TieLast selects the last (highest index) of the tied rows
+	TieLast
+
+	// TieRandom selects one of the tied rows at random, with equal probability,
+	// to avoid a systematic bias toward either end
+	TieRandom
+
+	TieBreaksN
+)
+
+//go:generate stringer -type=TieBreaks
+
+var KiT_TieBreaks = kit.Enums.AddEnum(TieBreaksN, kit.NotBitFlag, nil)
+
+func (ev TieBreaks) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *TieBreaks) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// ClosestRow64Tie returns the closest fit between probe pattern and patterns in
+// an etensor.Tensor where the outer-most dimension is assumed to be a row
+// (e.g., as a column in an etable), using the given metric function,
+// *which must have the Increasing property* -- i.e., larger = further,
+// with ties among rows having exactly the same closest value resolved
+// according to the tie arg.  For TieRandom, the given rand source is used
+// (pass a rand.New with a fixed seed for reproducible results), or the global
+// math/rand source if nil.
+// returns the row and metric value for that row.
+// Col cell sizes must match size of probe (panics if not).
+func ClosestRow64Tie(probe etensor.Tensor, col etensor.Tensor, mfun Func64, tie TieBreaks, rnd *rand.Rand) (int, float64) {
+	rows := col.Dim(0)
+	csz := col.Len() / rows
+	if csz != probe.Len() {
+		panic("metric.ClosestRow64Tie: probe size != cell size of tensor column!\n")
+	}
+	pv := float64Values(probe)
+	cv := float64Values(col)
+	ci := -1
+	minv := math.MaxFloat64
+	nties := 0
+	for ri := 0; ri < rows; ri++ {
+		st := ri * csz
+		v := mfun(pv, cv[st:st+csz])
+		switch {
+		case v < minv:
+			ci = ri
+			minv = v
+			nties = 1
+		case v == minv:
+			nties++
+			switch tie {
+			case TieLast:
+				ci = ri
+			case TieRandom: // reservoir sampling: keep each tie with prob 1/nties
+				var r int
+				if rnd != nil {
+					r = rnd.Intn(nties)
+				} else {
+					r = rand.Intn(nties)
+				}
+				if r == 0 {
+					ci = ri
+				}
+			}
+		}
+	}
+	return ci, minv
+}
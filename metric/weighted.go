@@ -0,0 +1,87 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metric
+
+import (
+	"math"
+
+	"github.com/chewxy/math32"
+)
+
+// The Weighted metric factories return a metric function, satisfying the
+// standard Func32 or Func64 signature (e.g., for use in ClosestRow64), that
+// multiplies the contribution of each element by the given weight.
+// The returned functions skip NaN's and panic if the lengths of the two
+// slices or the weights are not equal.
+
+///////////////////////////////////////////
+//  SumSquaresWeighted
+
+// SumSquaresWeighted32 returns a metric function computing the weighted
+// sum-of-squares distance between two vectors: sum_i w_i (a_i - b_i)^2
+func SumSquaresWeighted32(weights []float32) Func32 {
+	return func(a, b []float32) float32 {
+		if len(a) != len(b) {
+			panic("metric: slice lengths do not match")
+		}
+		if len(weights) != len(a) {
+			panic("metric: weights length does not match slice lengths")
+		}
+		ss := float32(0)
+		for i, av := range a {
+			bv := b[i]
+			if math32.IsNaN(av) || math32.IsNaN(bv) {
+				continue
+			}
+			d := av - bv
+			ss += weights[i] * d * d
+		}
+		return ss
+	}
+}
+
+// SumSquaresWeighted64 returns a metric function computing the weighted
+// sum-of-squares distance between two vectors: sum_i w_i (a_i - b_i)^2
+func SumSquaresWeighted64(weights []float64) Func64 {
+	return func(a, b []float64) float64 {
+		if len(a) != len(b) {
+			panic("metric: slice lengths do not match")
+		}
+		if len(weights) != len(a) {
+			panic("metric: weights length does not match slice lengths")
+		}
+		ss := float64(0)
+		for i, av := range a {
+			bv := b[i]
+			if math.IsNaN(av) || math.IsNaN(bv) {
+				continue
+			}
+			d := av - bv
+			ss += weights[i] * d * d
+		}
+		return ss
+	}
+}
+
+///////////////////////////////////////////
+//  EuclideanWeighted
+
+// EuclideanWeighted32 returns a metric function computing the weighted
+// Euclidean distance between two vectors: sqrt(sum_i w_i (a_i - b_i)^2)
+func EuclideanWeighted32(weights []float32) Func32 {
+	ssf := SumSquaresWeighted32(weights)
+	return func(a, b []float32) float32 {
+		return math32.Sqrt(ssf(a, b))
+	}
+}
+
+// EuclideanWeighted64 returns a metric function computing the weighted
+// Euclidean distance between two vectors: sqrt(sum_i w_i (a_i - b_i)^2)
+func EuclideanWeighted64(weights []float64) Func64 {
+	ssf := SumSquaresWeighted64(weights)
+	return func(a, b []float64) float64 {
+		return math.Sqrt(ssf(a, b))
+	}
+}
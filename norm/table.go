@@ -0,0 +1,185 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package norm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+	"github.com/emer/etable/split"
+	"github.com/goki/ki/kit"
+)
+
+// The table column normalization functions transform all the values of a
+// numeric column of an etable.Table in place, using FloatVal1D / SetFloat1D,
+// so they work for any numeric type and for n-dimensional cells (all values
+// in the column are normalized together -- integer columns will be truncated,
+// so these are mainly useful for float columns).  Null and NaN values are excluded
+// from the statistics and are left unchanged.
+
+// colValues returns the numeric column of given name, and its non-null, non-NaN
+// values and their 1D indexes in the column
+func colValues(dt *etable.Table, colNm string) (etensor.Tensor, []float64, []int, error) {
+	col, err := dt.ColByNameTry(colNm)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if col.DataType() == etensor.STRING {
+		return nil, nil, nil, fmt.Errorf("norm: column %v is a string column and cannot be normalized", colNm)
+	}
+	n := col.Len()
+	vals := make([]float64, 0, n)
+	idxs := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		if col.IsNull1D(i) {
+			continue
+		}
+		v := col.FloatVal1D(i)
+		if math.IsNaN(v) {
+			continue
+		}
+		vals = append(vals, v)
+		idxs = append(idxs, i)
+	}
+	return col, vals, idxs, nil
+}
+
+// setColValues sets the values back into given indexes of the column
+func setColValues(col etensor.Tensor, vals []float64, idxs []int) {
+	for i, v := range vals {
+		col.SetFloat1D(idxs[i], v)
+	}
+}
+
+// ZScore subtracts the mean and divides by the (sample) standard deviation
+// for all of the non-null values of given column in the table, in place.
+// Returns error if the column is not found or is a string column.
+func ZScore(dt *etable.Table, colNm string) error {
+	col, vals, idxs, err := colValues(dt, colNm)
+	if err != nil {
+		return err
+	}
+	ZScore64(vals)
+	setColValues(col, vals, idxs)
+	return nil
+}
+
+// UnitNorm divides all of the non-null values of given column in the table
+// by their L2 norm (square root of the sum of squares), in place,
+// so the column has a vector length of 1.
+// Returns error if the column is not found or is a string column.
+func UnitNorm(dt *etable.Table, colNm string) error {
+	col, vals, idxs, err := colValues(dt, colNm)
+	if err != nil {
+		return err
+	}
+	DivNorm64(vals, L264)
+	setColValues(col, vals, idxs)
+	return nil
+}
+
+// MinMaxScale linearly rescales all of the non-null values of given column in
+// the table, in place, so the min value maps to lo and the max value maps to hi.
+// If all values are the same, they are set to lo.
+// Returns error if the column is not found or is a string column.
+func MinMaxScale(dt *etable.Table, colNm string, lo, hi float64) error {
+	col, vals, idxs, err := colValues(dt, colNm)
+	if err != nil {
+		return err
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+	mn := Min64(vals)
+	rng := Max64(vals) - mn
+	for i, v := range vals {
+		if rng > 0 {
+			vals[i] = lo + (hi-lo)*(v-mn)/rng
+		} else {
+			vals[i] = lo
+		}
+	}
+	setColValues(col, vals, idxs)
+	return nil
+}
+
+// NormModes are the ways of normalizing the values within each group,
+// for NormalizeByGroup
+type NormModes int
+
+const (
+	// ZScoreNorm subtracts the mean and divides by the (sample) standard deviation
+	ZScoreNorm NormModes = iota
+
+	// MinMaxNorm linearly rescales the values so the min maps to 0 and the max to 1
+	MinMaxNorm
+
+	NormModesN
+)
+
+//go:generate stringer -type=NormModes
+
+var KiT_NormModes = kit.Enums.AddEnum(NormModesN, kit.NotBitFlag, nil)
+
+func (ev NormModes) MarshalJSON() ([]byte, error)  { return kit.EnumMarshalJSON(ev) }
+func (ev *NormModes) UnmarshalJSON(b []byte) error { return kit.EnumUnmarshalJSON(ev, b) }
+
+// NormalizeByGroup normalizes the values of given column in the table, in place,
+// independently within each group of rows having the same values of the
+// given group columns (as in split.GroupBy), according to the mode.
+// Null and NaN values are excluded from the statistics of each group and are
+// left unchanged, and groups with all the same value are set to 0.
+// Returns error if any column is not found or the value column is a string column.
+func NormalizeByGroup(dt *etable.Table, groupCols []string, valueCol string, mode NormModes) error {
+	col, _, _, err := colValues(dt, valueCol)
+	if err != nil {
+		return err
+	}
+	spl, err := split.GroupByTry(etable.NewIdxView(dt), groupCols)
+	if err != nil {
+		return err
+	}
+	_, csz := col.RowCellSize()
+	var vals []float64
+	var idxs []int
+	for _, gp := range spl.Splits {
+		vals = vals[:0]
+		idxs = idxs[:0]
+		for _, row := range gp.Idxs {
+			for ci := row * csz; ci < (row+1)*csz; ci++ {
+				if col.IsNull1D(ci) {
+					continue
+				}
+				v := col.FloatVal1D(ci)
+				if math.IsNaN(v) {
+					continue
+				}
+				vals = append(vals, v)
+				idxs = append(idxs, ci)
+			}
+		}
+		if len(vals) == 0 {
+			continue
+		}
+		switch mode {
+		case ZScoreNorm:
+			ZScore64(vals)
+		case MinMaxNorm:
+			mn := Min64(vals)
+			rng := Max64(vals) - mn
+			for i, v := range vals {
+				if rng > 0 {
+					vals[i] = (v - mn) / rng
+				} else {
+					vals[i] = 0
+				}
+			}
+		}
+		setColValues(col, vals, idxs)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2019, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package norm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+func testTable() *etable.Table {
+	dt := etable.New(etable.Schema{
+		{"Name", etensor.STRING, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+	}, 6)
+	for i, v := range []float64{1, 2, 3, 4, 5, 6} {
+		dt.SetCellFloat("Val", i, v)
+	}
+	dt.SetCellFloat("Val", 2, 1000)
+	dt.ColByName("Val").SetNull1D(2, true) // should be ignored
+	return dt
+}
+
+func TestTableNorm(t *testing.T) {
+	dt := testTable()
+	if err := ZScore(dt, "Val"); err != nil {
+		t.Error(err)
+	}
+	col := dt.ColByName("Val").(*etensor.Float64)
+	if col.Values[2] != 1000 {
+		t.Errorf("ZScore: null value changed: %v\n", col.Values[2])
+	}
+	vals := []float64{col.Values[0], col.Values[1], col.Values[3], col.Values[4], col.Values[5]}
+	if mn := Mean64(vals); math.Abs(mn) > 1.0e-10 {
+		t.Errorf("ZScore: mean %v != 0\n", mn)
+	}
+	if sd := Std64(vals); math.Abs(sd-1) > 1.0e-10 {
+		t.Errorf("ZScore: std %v != 1\n", sd)
+	}
+
+	dt = testTable()
+	if err := UnitNorm(dt, "Val"); err != nil {
+		t.Error(err)
+	}
+	col = dt.ColByName("Val").(*etensor.Float64)
+	vals = []float64{col.Values[0], col.Values[1], col.Values[3], col.Values[4], col.Values[5]}
+	if l2 := L264(vals); math.Abs(l2-1) > 1.0e-10 {
+		t.Errorf("UnitNorm: L2 %v != 1\n", l2)
+	}
+
+	dt = testTable()
+	if err := MinMaxScale(dt, "Val", -1, 1); err != nil {
+		t.Error(err)
+	}
+	col = dt.ColByName("Val").(*etensor.Float64)
+	if col.Values[0] != -1 || col.Values[5] != 1 || col.Values[2] != 1000 {
+		t.Errorf("MinMaxScale: %v\n", col.Values)
+	}
+	if math.Abs(col.Values[3]-0.2) > 1.0e-10 {
+		t.Errorf("MinMaxScale: %v != 0.2\n", col.Values[3])
+	}
+
+	if err := ZScore(dt, "Name"); err == nil {
+		t.Errorf("ZScore: no error for string column\n")
+	}
+	if err := ZScore(dt, "Foo"); err == nil {
+		t.Errorf("ZScore: no error for missing column\n")
+	}
+}
+
+func TestNormalizeByGroup(t *testing.T) {
+	dt := testTable()
+	for i, nm := range []string{"a", "b", "a", "b", "a", "b"} {
+		dt.SetCellString("Name", i, nm)
+	}
+	dt.SetCellFloat("Val", 5, 60) // different scale in group b
+	if err := NormalizeByGroup(dt, []string{"Name"}, "Val", ZScoreNorm); err != nil {
+		t.Fatal(err)
+	}
+	col := dt.ColByName("Val").(*etensor.Float64)
+	if col.Values[2] != 1000 || !col.IsNull1D(2) {
+		t.Errorf("NormalizeByGroup: null value changed: %v\n", col.Values[2])
+	}
+	groups := [][]float64{{col.Values[0], col.Values[4]}, {col.Values[1], col.Values[3], col.Values[5]}}
+	for gi, vals := range groups {
+		if mn := Mean64(vals); math.Abs(mn) > 1.0e-10 {
+			t.Errorf("NormalizeByGroup: group %v mean %v != 0\n", gi, mn)
+		}
+		if sd := Std64(vals); math.Abs(sd-1) > 1.0e-10 {
+			t.Errorf("NormalizeByGroup: group %v std %v != 1\n", gi, sd)
+		}
+	}
+
+	dt = testTable()
+	for i, nm := range []string{"a", "b", "a", "b", "a", "b"} {
+		dt.SetCellString("Name", i, nm)
+	}
+	if err := NormalizeByGroup(dt, []string{"Name"}, "Val", MinMaxNorm); err != nil {
+		t.Fatal(err)
+	}
+	col = dt.ColByName("Val").(*etensor.Float64)
+	exp := []float64{0, 0, 1000, 0.5, 1, 1} // a: 1, 5; b: 2, 4, 6
+	for i, v := range exp {
+		if col.Values[i] != v {
+			t.Errorf("NormalizeByGroup: MinMax row %v: %v != %v\n", i, col.Values[i], v)
+		}
+	}
+	if err := NormalizeByGroup(dt, []string{"Name"}, "Name", ZScoreNorm); err == nil {
+		t.Errorf("NormalizeByGroup: no error for string column\n")
+	}
+	if err := NormalizeByGroup(dt, []string{"NoSuchCol"}, "Val", ZScoreNorm); err == nil {
+		t.Errorf("NormalizeByGroup: no error for invalid group column\n")
+	}
+}
@@ -6,6 +6,7 @@ package pca
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/emer/etable/etable"
 	"github.com/emer/etable/etensor"
@@ -19,12 +20,14 @@ type PCA struct {
 	Covar   *etensor.Float64 `view:"no-inline" desc:"the covariance matrix computed on original data, which is then eigen-factored"`
 	Vectors *etensor.Float64 `view:"no-inline" desc:"the eigenvectors, in same size as Covar - each eigenvector is a column in this 2D square matrix, ordered *lowest* to *highest* across the columns -- i.e., maximum eigenvector is the last column"`
 	Values  []float64        `view:"no-inline" desc:"the eigenvalues, ordered *lowest* to *highest*"`
+	Means   []float64        `view:"no-inline" desc:"the mean of each cell (variable) across the rows of the data the PCA was computed on, by TableCol or Tensor -- subtracted by ProjectTensor"`
 }
 
 func (pca *PCA) Init() {
 	pca.Covar = &etensor.Float64{}
 	pca.Vectors = &etensor.Float64{}
 	pca.Values = nil
+	pca.Means = nil
 }
 
 // TableCol is a convenience method that computes a covariance matrix
@@ -48,6 +51,7 @@ func (pca *PCA) TableCol(ix *etable.IdxView, colNm string, mfun metric.Func64) e
 	if err != nil {
 		return err
 	}
+	pca.setMeans(ix.Table.ColByName(colNm), ix.Idxs)
 	return pca.PCA()
 }
 
@@ -72,9 +76,37 @@ func (pca *PCA) Tensor(tsr etensor.Tensor, mfun metric.Func64) error {
 	if err != nil {
 		return err
 	}
+	rows := make([]int, tsr.Dim(0))
+	for i := range rows {
+		rows[i] = i
+	}
+	pca.setMeans(tsr, rows)
 	return pca.PCA()
 }
 
+// setMeans sets the Means of each cell of given tensor, where the outer-most
+// dimension is rows, across the given rows, skipping NaN values
+func (pca *PCA) setMeans(tsr etensor.Tensor, rows []int) {
+	_, nc := tsr.RowCellSize()
+	pca.Means = make([]float64, nc)
+	ns := make([]int, nc)
+	for _, ri := range rows {
+		for ci := 0; ci < nc; ci++ {
+			v := tsr.FloatVal1D(ri*nc + ci)
+			if math.IsNaN(v) {
+				continue
+			}
+			pca.Means[ci] += v
+			ns[ci]++
+		}
+	}
+	for ci, n := range ns {
+		if n > 0 {
+			pca.Means[ci] /= float64(n)
+		}
+	}
+}
+
 // TableColStd is a convenience method that computes a covariance matrix
 // on given column of table and then performs the PCA on the resulting matrix.
 // If no error occurs, the results can be read out from Vectors and Values
@@ -219,3 +251,75 @@ func (pca *PCA) ProjectColToTable(prjns *etable.Table, ix *etable.IdxView, colNm
 	}
 	return nil
 }
+
+// Components returns the top k eigenvectors (principal components), as
+// a k x n tensor where each row is an eigenvector, ordered from highest
+// to lowest eigenvalue (i.e., row 0 is the first principal component).
+// k is limited to the number of variables (cells) n -- note that components
+// beyond the rank of the data (e.g., when there are fewer rows than cells)
+// have eigenvalues of 0 and arbitrary directions.  Returns an error if k < 0.
+// Must have already called PCA() method.
+func (pca *PCA) Components(k int) (*etensor.Float64, error) {
+	if pca.Vectors == nil || pca.Vectors.NumDims() != 2 {
+		return nil, fmt.Errorf("PCA.Components Vectors are nil -- must call PCA first")
+	}
+	if k < 0 {
+		return nil, fmt.Errorf("PCA.Components number of components k = %v must be >= 0", k)
+	}
+	nr := pca.Vectors.Dim(0)
+	if k > nr {
+		k = nr
+	}
+	cmps := etensor.NewFloat64([]int{k, nr}, nil, []string{"Component", "Var"})
+	for ci := 0; ci < k; ci++ {
+		eidx := nr - 1 - ci // eigens in reverse order
+		for ri := 0; ri < nr; ri++ {
+			cmps.Values[ci*nr+ri] = pca.Vectors.Value([]int{ri, eidx})
+		}
+	}
+	return cmps, nil
+}
+
+// ProjectTensor projects the rows of given tensor, where the outer-most dimension
+// is rows (as used in the Tensor method), onto the top k eigenvectors,
+// returning a rows x k tensor of the projected coordinates.  The values are
+// mean-centered by subtracting the Means of the data the PCA was computed on
+// (by TableCol or Tensor), so new data is projected into the same coordinates,
+// and the projections of the original data are centered around 0.
+// NaN values are treated as being at the mean.  If Means is not set (e.g.,
+// PCA was called directly on a Covar matrix), values are not centered,
+// as in ProjectCol, which otherwise differs only by the projection of the Means.
+// Must have already called PCA() method.
+func (pca *PCA) ProjectTensor(tsr etensor.Tensor, k int) (*etensor.Float64, error) {
+	cmps, err := pca.Components(k)
+	if err != nil {
+		return nil, err
+	}
+	k = cmps.Dim(0)
+	nr := cmps.Dim(1)
+	rows := tsr.Dim(0)
+	if rows == 0 || tsr.Len()/rows != nr {
+		return nil, fmt.Errorf("PCA.ProjectTensor tensor cell size != pca eigenvectors")
+	}
+	means := pca.Means
+	if len(means) != nr {
+		means = make([]float64, nr)
+	}
+	var vals []float64
+	tsr.Floats(&vals)
+	prjns := etensor.NewFloat64([]int{rows, k}, nil, []string{"Row", "Component"})
+	for ri := 0; ri < rows; ri++ {
+		for pi := 0; pi < k; pi++ {
+			sum := 0.0
+			for ci := 0; ci < nr; ci++ {
+				v := vals[ri*nr+ci]
+				if math.IsNaN(v) {
+					continue
+				}
+				sum += cmps.Values[pi*nr+ci] * (v - means[ci])
+			}
+			prjns.Values[ri*k+pi] = sum
+		}
+	}
+	return prjns, nil
+}
@@ -7,6 +7,7 @@ package pca
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/emer/etable/etable"
@@ -65,3 +66,81 @@ func TestPCAIris(t *testing.T) {
 	}
 	// prjt.SaveCSV("test_data/projection01.csv", etable.Comma, true)
 }
+
+func TestPCADominant(t *testing.T) {
+	// points spread along the (1, 2, 0) direction, with small noise in other directions
+	rnd := rand.New(rand.NewSource(1))
+	rows := 200
+	tsr := etensor.NewFloat64([]int{rows, 3}, nil, nil)
+	for ri := 0; ri < rows; ri++ {
+		s := rnd.NormFloat64() * 10
+		tsr.Values[ri*3] = 5 + s + rnd.NormFloat64()*.1
+		tsr.Values[ri*3+1] = -3 + 2*s + rnd.NormFloat64()*.1
+		tsr.Values[ri*3+2] = 1 + rnd.NormFloat64()*.1
+	}
+	pc := &PCA{}
+	err := pc.Tensor(tsr, metric.Covariance64)
+	if err != nil {
+		t.Error(err)
+	}
+	cmps, err := pc.Components(1)
+	if err != nil {
+		t.Error(err)
+	}
+	dir := []float64{1 / math.Sqrt(5), 2 / math.Sqrt(5), 0}
+	dot := 0.0
+	for i, d := range dir {
+		dot += d * cmps.Values[i]
+	}
+	if math.Abs(math.Abs(dot)-1) > 1.0e-3 {
+		t.Errorf("PCA first component %v not aligned with %v: %v\n", cmps.Values, dir, dot)
+	}
+	prjns, err := pc.ProjectTensor(tsr, 2)
+	if err != nil {
+		t.Error(err)
+	}
+	if prjns.Dim(0) != rows || prjns.Dim(1) != 2 {
+		t.Errorf("PCA ProjectTensor shape: %v\n", prjns.Shapes())
+	}
+	mean := 0.0
+	for ri := 0; ri < rows; ri++ {
+		mean += prjns.Values[ri*2]
+	}
+	if math.Abs(mean/float64(rows)) > 1.0e-9 {
+		t.Errorf("PCA ProjectTensor not mean-centered: %v\n", mean/float64(rows))
+	}
+
+	// new data is centered on the training means, not its own
+	held := etensor.NewFloat64([]int{2, 3}, nil, nil)
+	for ci, m := range pc.Means {
+		held.Values[ci] = m
+		held.Values[3+ci] = m + 10*dir[ci]
+	}
+	hp, err := pc.ProjectTensor(held, 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if math.Abs(hp.Values[0]) > 1.0e-9 || math.Abs(math.Abs(hp.Values[1])-10) > 1.0e-2 {
+		t.Errorf("PCA ProjectTensor new data: %v, expected 0, +/-10\n", hp.Values)
+	}
+	if _, err := pc.Components(-1); err == nil {
+		t.Errorf("PCA Components: no error for negative k\n")
+	}
+
+	// more dims than samples
+	tsr = etensor.NewFloat64([]int{3, 6}, nil, nil)
+	for i := range tsr.Values {
+		tsr.Values[i] = rnd.Float64()
+	}
+	err = pc.Tensor(tsr, metric.Covariance64)
+	if err != nil {
+		t.Error(err)
+	}
+	prjns, err = pc.ProjectTensor(tsr, 10)
+	if err != nil {
+		t.Error(err)
+	}
+	if prjns.Dim(0) != 3 || prjns.Dim(1) != 6 {
+		t.Errorf("PCA ProjectTensor dims > samples shape: %v\n", prjns.Shapes())
+	}
+}
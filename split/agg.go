@@ -95,3 +95,38 @@ func DescTry(spl *etable.Splits, colNm string) error {
 	DescIdx(spl, colIdx)
 	return nil
 }
+
+///////////////////////////////////////////////////
+//   GroupStats
+
+// GroupStats groups the rows of given IdxView by the values in groupCols
+// (as in GroupBy), and returns a table with one row per group, with the
+// values of the group columns followed by a column for each of the given
+// statistics of the valueCol column in each group.  Stats are named as in
+// AggsName (i.e., the Aggs names without the Agg prefix, e.g., "Mean", "Std",
+// "Min", "Max", "Count", "Sem"), and the stat columns are named valueCol:Stat
+// (e.g., Score:Mean).  Null and NaN values are excluded from all stats.
+// Returns error for bad column or stat names.
+func GroupStats(ix *etable.IdxView, groupCols []string, valueCol string, stats []string) (*etable.Table, error) {
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("split.GroupStats: no stats provided")
+	}
+	colIdx, err := ix.Table.ColIdxTry(valueCol)
+	if err != nil {
+		return nil, err
+	}
+	ags := make([]agg.Aggs, len(stats))
+	for i, st := range stats {
+		if err := ags[i].FromString("Agg" + st); err != nil {
+			return nil, fmt.Errorf("split.GroupStats: %v is not a valid stat name", st)
+		}
+	}
+	spl, err := GroupByTry(ix, groupCols)
+	if err != nil {
+		return nil, err
+	}
+	for _, ag := range ags {
+		AggIdx(spl, colIdx, ag)
+	}
+	return spl.AggsToTable(etable.AddAggName), nil
+}
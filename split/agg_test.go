@@ -0,0 +1,57 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package split
+
+import (
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+func TestGroupStats(t *testing.T) {
+	dt := etable.New(etable.Schema{
+		{"Cond", etensor.STRING, nil, nil},
+		{"Score", etensor.FLOAT64, nil, nil},
+	}, 7)
+	conds := []string{"a", "b", "a", "b", "a", "b", "b"}
+	scores := []float64{1, 10, 2, 20, 6, 30, 99}
+	for ri := range conds {
+		dt.SetCellString("Cond", ri, conds[ri])
+		dt.SetCellFloat("Score", ri, scores[ri])
+	}
+	dt.ColByName("Score").SetNull1D(6, true)
+	st, err := GroupStats(etable.NewIdxView(dt), []string{"Cond"}, "Score", []string{"Mean", "Count", "Max"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Rows != 2 || st.NumCols() != 4 {
+		t.Fatalf("GroupStats: rows %v cols %v, expected 2 4\n", st.Rows, st.NumCols())
+	}
+	exp := []struct {
+		cond             string
+		mean, count, max float64
+	}{
+		{"a", 3, 3, 6},
+		{"b", 20, 3, 30},
+	}
+	for ri, e := range exp {
+		if c := st.CellString("Cond", ri); c != e.cond {
+			t.Errorf("GroupStats: row %v Cond %v, expected %v\n", ri, c, e.cond)
+		}
+		if v := st.CellFloat("Score:Mean", ri); v != e.mean {
+			t.Errorf("GroupStats: %v Mean %v, expected %v\n", e.cond, v, e.mean)
+		}
+		if v := st.CellFloat("Score:Count", ri); v != e.count {
+			t.Errorf("GroupStats: %v Count %v, expected %v\n", e.cond, v, e.count)
+		}
+		if v := st.CellFloat("Score:Max", ri); v != e.max {
+			t.Errorf("GroupStats: %v Max %v, expected %v\n", e.cond, v, e.max)
+		}
+	}
+	if _, err := GroupStats(etable.NewIdxView(dt), []string{"Cond"}, "Score", []string{"Bogus"}); err == nil {
+		t.Errorf("GroupStats: no error for bad stat name\n")
+	}
+}
@@ -22,6 +22,9 @@ func All(ix *etable.IdxView) *etable.Splits {
 
 // GroupByIdx returns a new Splits set based on the groups of values
 // across the given set of column indexes.
+// The groups are in a deterministic order, regardless of the order of rows in
+// the view, with keys compared column by column in ascending order:
+// strings lexically, and numbers numerically with NaN last -- see Splits.SetGroups.
 // Uses a stable sort on columns, so ordering of other dimensions is preserved.
 func GroupByIdx(ix *etable.IdxView, colIdxs []int) *etable.Splits {
 	nc := len(colIdxs)
@@ -33,37 +36,13 @@ func GroupByIdx(ix *etable.IdxView, colIdxs []int) *etable.Splits {
 		return nil
 	}
 	spl := &etable.Splits{}
-	spl.Levels = make([]string, nc)
-	for i, ci := range colIdxs {
-		spl.Levels[i] = ix.Table.ColNames[ci]
-	}
-	srt := ix.Clone()
-	srt.SortStableCols(colIdxs, true) // important for consistency
-	lstVals := make([]string, nc)
-	curVals := make([]string, nc)
-	var curIx *etable.IdxView
-	for _, rw := range srt.Idxs {
-		diff := false
-		for i, ci := range colIdxs {
-			cl := ix.Table.Cols[ci]
-			cv := cl.StringVal1D(rw)
-			curVals[i] = cv
-			if cv != lstVals[i] {
-				diff = true
-			}
-		}
-		if diff || curIx == nil {
-			curIx = spl.New(ix.Table, curVals, rw)
-			copy(lstVals, curVals)
-		} else {
-			curIx.AddIndex(rw)
-		}
-	}
+	spl.SetGroups(ix, colIdxs...)
 	return spl
 }
 
 // GroupBy returns a new Splits set based on the groups of values
 // across the given set of column names (see Try for version with error)
+// The groups are in a deterministic order -- see GroupByIdx.
 // Uses a stable sort on columns, so ordering of other dimensions is preserved.
 func GroupBy(ix *etable.IdxView, colNms []string) *etable.Splits {
 	return GroupByIdx(ix, ix.Table.ColIdxsByNames(colNms))
@@ -71,6 +50,7 @@ func GroupBy(ix *etable.IdxView, colNms []string) *etable.Splits {
 
 // GroupByTry returns a new Splits set based on the groups of values
 // across the given set of column names.  returns error for bad column names.
+// The groups are in a deterministic order -- see GroupByIdx.
 // Uses a stable sort on columns, so ordering of other dimensions is preserved.
 func GroupByTry(ix *etable.IdxView, colNms []string) (*etable.Splits, error) {
 	cidx, err := ix.Table.ColIdxsByNamesTry(colNms)
@@ -84,6 +64,7 @@ func GroupByTry(ix *etable.IdxView, colNms []string) (*etable.Splits, error) {
 // which returns value(s) to group on for each row of the table.
 // The function should always return the same number of values -- if
 // it doesn't behavior is undefined.
+// The groups are in ascending lexical order of the function values.
 // Uses a stable sort on columns, so ordering of other dimensions is preserved.
 func GroupByFunc(ix *etable.IdxView, fun func(row int) []string) *etable.Splits {
 	if ix.Table == nil {
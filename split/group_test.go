@@ -0,0 +1,45 @@
+// Copyright (c) 2019, The eTable Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package split
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/emer/etable/etable"
+	"github.com/emer/etable/etensor"
+)
+
+func TestGroupByOrder(t *testing.T) {
+	dt := etable.New(etable.Schema{
+		{"Cond", etensor.STRING, nil, nil},
+		{"Val", etensor.FLOAT64, nil, nil},
+	}, 12)
+	conds := []string{"b", "a", "b", "a", "b", "a", "b", "a", "b", "a", "a", "b"}
+	vals := []float64{2, math.NaN(), -1, 0, math.Copysign(0, -1), 2, math.NaN(), 10, 0, -1, 2, 2}
+	for ri := range conds {
+		dt.SetCellString("Cond", ri, conds[ri])
+		dt.SetCellFloat("Val", ri, vals[ri])
+	}
+	// 10 sorts after 2 numerically, NaN last, and -0 groups with 0
+	exp := "[[a -1] [a 0] [a 2] [a 10] [a NaN] [b -1] [b 0] [b 2] [b NaN]]"
+	ix := etable.NewIdxView(dt)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 5; i++ {
+		spl, err := GroupByTry(ix, []string{"Cond", "Val"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vs := fmt.Sprint(spl.Values); vs != exp {
+			t.Errorf("GroupBy: iteration %v order:\n%v\nexpected:\n%v\n", i, vs, exp)
+		}
+		if n := spl.Splits[6].Len(); n != 2 { // b 0 and b -0
+			t.Errorf("GroupBy: iteration %v: b 0 group has %v rows, expected 2\n", i, n)
+		}
+		ix.Idxs = rnd.Perm(dt.Rows)
+	}
+}